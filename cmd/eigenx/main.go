@@ -9,6 +9,7 @@ import (
 	"github.com/Layr-Labs/eigenx-cli/pkg/commands"
 	"github.com/Layr-Labs/eigenx-cli/pkg/commands/version"
 	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	commonlogger "github.com/Layr-Labs/eigenx-cli/pkg/common/logger"
 	"github.com/Layr-Labs/eigenx-cli/pkg/hooks"
 	"github.com/urfave/cli/v2"
 )
@@ -24,11 +25,18 @@ func main() {
 
 	ctx := common.WithShutdown(context.Background())
 
+	// auditLog is the --log-file sink, closed once the command finishes
+	var auditLog *os.File
+
 	app := &cli.App{
 		Name:  "eigenx",
 		Usage: "EigenX Development Kit",
 		Flags: common.GlobalFlags,
 		Before: func(cCtx *cli.Context) error {
+			// Honor --no-color, NO_COLOR and CLICOLOR_FORCE for all colorized output,
+			// reading --no-color from raw argv to capture it from subcommand flags
+			common.ApplyColorPreference(cCtx.Bool("no-color") || common.PeelBoolFromFlags(os.Args[1:], "--no-color", "--no-color"))
+
 			err := hooks.LoadEnvFile(cCtx)
 			if err != nil {
 				return err
@@ -48,6 +56,38 @@ func main() {
 			// Get logger based on CLI context (handles verbosity internally)
 			logger, tracker := common.GetLoggerFromCLIContext(cCtx)
 
+			// Tee all logger output to --log-file, read from raw argv to capture it from subcommand flags
+			logFile := cCtx.String("log-file")
+			if value, ok := common.PeelStringFromFlags(os.Args[1:], "--log-file"); ok {
+				logFile = value
+			}
+			if logFile != "" {
+				path, err := common.ResolveLogFilePath(logFile)
+				if err != nil {
+					return fmt.Errorf("failed to resolve log file path: %w", err)
+				}
+				file, err := commonlogger.OpenLogFile(path, commonlogger.LogFileMaxBytes, commonlogger.LogFileBackups)
+				if err != nil {
+					return err
+				}
+				auditLog = file
+				logger = commonlogger.NewFileLogger(logger, file)
+				// Register keys passed on the command line before echoing it into the log
+				if key, ok := common.PeelStringFromFlags(os.Args[1:], "--private-key"); ok {
+					commonlogger.RegisterSecret(key)
+				}
+				commonlogger.RegisterSecret(os.Getenv(common.EigenXPrivateKeyEnvVar))
+				logger.Debug("eigenx %s", common.CommandLineForLog(cCtx.App.Commands, os.Args[1:]))
+			}
+
+			// Parse --quiet from raw argv too, and keep only warnings and errors from here on.
+			// The log file still gets every message.
+			if cCtx.Bool("quiet") || common.PeelBoolFromFlags(os.Args[1:], "--quiet", "--quiet") {
+				logger = commonlogger.Quiet(logger)
+				tracker = commonlogger.NewNoopProgressTracker()
+				cCtx.Context = common.WithQuietOutput(cCtx.Context)
+			}
+
 			// Store logger and tracker in the context
 			cCtx.Context = common.WithLogger(cCtx.Context, logger)
 			cCtx.Context = common.WithProgressTracker(cCtx.Context, tracker)
@@ -74,8 +114,17 @@ func main() {
 			commands.UndelegateCommand,
 			commands.UpgradeCommand,
 			commands.TelemetryCommand,
+			commands.DoctorCommand,
+		},
+		After: func(cCtx *cli.Context) error {
+			if auditLog != nil {
+				return auditLog.Close()
+			}
+			return nil
 		},
 		UseShortOptionHandling: true,
+		// Slice flags like --build-secret take comma-separated values themselves
+		DisableSliceFlagSeparator: true,
 	}
 
 	actionChain := hooks.NewActionChain()
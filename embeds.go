@@ -13,5 +13,13 @@ var RawKmsClient []byte
 //go:embed tools/tls-keygen-linux-amd64
 var RawTlsKeygenBinary []byte
 
+// SHA256 checksums of the embedded helper binaries, checked before they are written into an
+// image. Update them whenever a binary in tools/ is rebuilt (sha256sum tools/*); make tls-keygen
+// rebuilds tls-keygen and updates its checksum in one step.
+const (
+	KmsClientSHA256 = "65064b0ec36fd62ba65246382a681bf9e9b50a5506d77fedfea4985eca849209"
+	TlsKeygenSHA256 = "932399ff41c489031186434e5969cd7e0abc7cfb8ff644b343f044f35040d5f0"
+)
+
 //go:embed internal/templates/*
 var TemplatesFS embed.FS
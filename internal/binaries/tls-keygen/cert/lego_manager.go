@@ -3,7 +3,6 @@ package cert
 import (
 	"context"
 	"crypto"
-	"crypto/ecdsa"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -32,9 +31,10 @@ func (u *LegoUser) GetPrivateKey() crypto.PrivateKey        { return u.key }
 
 // LegoManager handles certificate management using Lego library
 type LegoManager struct {
-	storage storage.Storage     // Remote storage
-	local   storage.LocalWriter // Local file writer
-	clock   func() time.Time    // Time provider (defaults to time.Now)
+	storage storage.Storage                      // Remote storage
+	local   storage.LocalWriter                  // Local file writer
+	clock   func() time.Time                     // Time provider (defaults to time.Now)
+	after   func(time.Duration) <-chan time.Time // Retry delay (defaults to time.After)
 	log     *slog.Logger
 }
 
@@ -44,6 +44,7 @@ func NewLegoManager(remoteStorage storage.Storage, localWriter storage.LocalWrit
 		storage: remoteStorage,
 		local:   localWriter,
 		clock:   time.Now,
+		after:   time.After,
 		log:     logger,
 	}
 }
@@ -67,7 +68,10 @@ func (m *LegoManager) EnsureCertificate(ctx context.Context, opts config.Config)
 	// Derive deterministic keys
 	seed := keys.SeedFromMnemonic(opts.Mnemonic)
 	acctKey := keys.DeriveAccountKey(seed)
-	tlsKey := keys.DeriveTLSKey(seed, primary, opts.Version)
+	tlsKey, err := deriveTLSKey(seed, primary, opts)
+	if err != nil {
+		return storage.Bundle{}, fmt.Errorf("derive TLS key: %w", err)
+	}
 
 	// Fast path: forced re-issue
 	if opts.ForceIssue {
@@ -118,8 +122,16 @@ func (m *LegoManager) EnsureCertificate(ctx context.Context, opts config.Config)
 	return m.installFromRemote(opts.OutDir, chain, tlsKey, expiry)
 }
 
+// deriveTLSKey derives the TLS key of the configured type for the primary domain
+func deriveTLSKey(seed []byte, primary string, opts config.Config) (crypto.Signer, error) {
+	if opts.KeyType == config.KeyRSA {
+		return keys.DeriveRSATLSKey(seed, primary, opts.Version, opts.RSABits)
+	}
+	return keys.DeriveTLSKey(seed, primary, opts.Version), nil
+}
+
 // issueAndPersist obtains a new certificate and persists it
-func (m *LegoManager) issueAndPersist(ctx context.Context, opts config.Config, primary string, sans []string, tlsKey *ecdsa.PrivateKey, acctKey crypto.Signer) (storage.Bundle, error) {
+func (m *LegoManager) issueAndPersist(ctx context.Context, opts config.Config, primary string, sans []string, tlsKey crypto.Signer, acctKey crypto.Signer) (storage.Bundle, error) {
 	m.log.Info("obtaining new certificate", "SANs", sans)
 
 	// Create Lego user
@@ -158,13 +170,18 @@ func (m *LegoManager) issueAndPersist(ctx context.Context, opts config.Config, p
 	regOpts := registration.RegisterOptions{
 		TermsOfServiceAgreed: true,
 	}
-	reg, err := client.Registration.Register(regOpts)
-	if err != nil {
-		// Try to retrieve existing registration
-		reg, err = client.Registration.ResolveAccountByKey()
+	var reg *registration.Resource
+	err = m.withACMERetry(ctx, "register account", opts.ACMERetries, opts.ACMERetryBackoff, func() error {
+		var err error
+		reg, err = client.Registration.Register(regOpts)
 		if err != nil {
-			return storage.Bundle{}, fmt.Errorf("register account: %w", err)
+			// Try to retrieve existing registration
+			reg, err = client.Registration.ResolveAccountByKey()
 		}
+		return err
+	})
+	if err != nil {
+		return storage.Bundle{}, fmt.Errorf("register account: %w", err)
 	}
 	user.Registration = reg
 	m.log.Info("registered ACME account", "location", reg.URI)
@@ -174,10 +191,16 @@ func (m *LegoManager) issueAndPersist(ctx context.Context, opts config.Config, p
 		Domains:    sans,
 		Bundle:     true,
 		PrivateKey: tlsKey, // Use our deterministically derived key!
+		MustStaple: opts.MustStaple,
 	}
 
-	// Obtain certificate (Lego v4 doesn't have ObtainWithContext)
-	certResource, err := client.Certificate.Obtain(request)
+	// Obtain certificate (Lego v4 doesn't have ObtainWithContext, so ctx only bounds the retries)
+	var certResource *certificate.Resource
+	err = m.withACMERetry(ctx, "obtain certificate", opts.ACMERetries, opts.ACMERetryBackoff, func() error {
+		var err error
+		certResource, err = client.Certificate.Obtain(request)
+		return err
+	})
 	if err != nil {
 		return storage.Bundle{}, fmt.Errorf("obtain certificate: %w", err)
 	}
@@ -207,7 +230,7 @@ func (m *LegoManager) issueAndPersist(ctx context.Context, opts config.Config, p
 }
 
 // writeCertificateFiles writes certificate chain and private key to local filesystem
-func (m *LegoManager) writeCertificateFiles(outDir string, chain storage.ChainPEM, tlsKey *ecdsa.PrivateKey) (fullChainPath, privKeyPath string, err error) {
+func (m *LegoManager) writeCertificateFiles(outDir string, chain storage.ChainPEM, tlsKey crypto.Signer) (fullChainPath, privKeyPath string, err error) {
 	// Write certificate chain
 	fullChainPath, err = m.local.WriteChain(outDir, chain)
 	if err != nil {
@@ -224,9 +247,9 @@ func (m *LegoManager) writeCertificateFiles(outDir string, chain storage.ChainPE
 }
 
 // installFromRemote installs a certificate from remote storage
-func (m *LegoManager) installFromRemote(outDir string, chain storage.ChainPEM, tlsKey *ecdsa.PrivateKey, expiry time.Time) (storage.Bundle, error) {
+func (m *LegoManager) installFromRemote(outDir string, chain storage.ChainPEM, tlsKey crypto.Signer, expiry time.Time) (storage.Bundle, error) {
 	// Verify the certificate matches our key
-	if !LeafPubMatches(chain, &tlsKey.PublicKey) {
+	if !LeafPubMatches(chain, tlsKey.Public()) {
 		return storage.Bundle{}, errors.New("remote certificate does not match derived key")
 	}
 
@@ -245,4 +268,4 @@ func (m *LegoManager) installFromRemote(outDir string, chain storage.ChainPEM, t
 		Issued:        false,
 		Reconstructed: true,
 	}, nil
-}
\ No newline at end of file
+}
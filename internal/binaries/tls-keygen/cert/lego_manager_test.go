@@ -2,6 +2,7 @@ package cert
 
 import (
 	"context"
+	"crypto"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
@@ -51,7 +52,7 @@ func (m *mockLegoLocalWriter) WriteChain(dir string, chain storage.ChainPEM) (st
 	return m.chainPath, nil
 }
 
-func (m *mockLegoLocalWriter) WriteKey(dir string, key *ecdsa.PrivateKey) (string, error) {
+func (m *mockLegoLocalWriter) WriteKey(dir string, key crypto.Signer) (string, error) {
 	if m.err != nil {
 		return "", m.err
 	}
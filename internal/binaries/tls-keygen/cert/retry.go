@@ -0,0 +1,97 @@
+package cert
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+)
+
+// ACME problem types (RFC 8555 section 6.7) that are worth retrying
+const (
+	problemRateLimited    = "urn:ietf:params:acme:error:rateLimited"
+	problemServerInternal = "urn:ietf:params:acme:error:serverInternal"
+	problemConnection     = "urn:ietf:params:acme:error:connection"
+	problemBadNonce       = "urn:ietf:params:acme:error:badNonce"
+)
+
+// retryAfterPattern matches the "retry after <time> UTC" Let's Encrypt adds to rate limit errors
+var retryAfterPattern = regexp.MustCompile(`retry after (\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}) UTC`)
+
+// withACMERetry runs an ACME operation up to attempts times, doubling the delay from backoff
+// between attempts. Permanent errors are returned at once, and a rate limit's retry-after is
+// waited for only when it ends before ctx does.
+func (m *LegoManager) withACMERetry(ctx context.Context, operation string, attempts int, backoff time.Duration, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+
+		retryable, retryAt := classifyACMEError(err)
+		if !retryable || attempt >= attempts {
+			return err
+		}
+
+		delay := backoff
+		if !retryAt.IsZero() {
+			delay = max(retryAt.Sub(m.clock()), 0)
+		}
+		if deadline, ok := ctx.Deadline(); ok && m.clock().Add(delay).After(deadline) {
+			return fmt.Errorf("%w (next attempt in %s would exceed the timeout)", err, delay.Round(time.Second))
+		}
+
+		m.log.Warn("ACME request failed, retrying", "operation", operation, "attempt", attempt, "attempts", attempts, "delay", delay, "error", err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (gave up: %w)", err, ctx.Err())
+		case <-m.after(delay):
+		}
+		backoff *= 2
+	}
+}
+
+// classifyACMEError reports whether err is transient, and for rate limits that say when they
+// lift, the time to retry at. Failures the CA or network would repeat (bad CSR, unauthorized,
+// CAA, unknown domain) are permanent.
+func classifyACMEError(err error) (bool, time.Time) {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false, time.Time{}
+	}
+
+	message := err.Error()
+	if match := retryAfterPattern.FindStringSubmatch(message); match != nil {
+		if retryAt, parseErr := time.ParseInLocation(time.DateTime, match[1], time.UTC); parseErr == nil {
+			return true, retryAt
+		}
+	}
+
+	var problem *acme.ProblemDetails
+	if errors.As(err, &problem) {
+		switch problem.Type {
+		case problemRateLimited, problemServerInternal, problemConnection, problemBadNonce:
+			return true, time.Time{}
+		}
+		return problem.HTTPStatus >= 500, time.Time{}
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) {
+		return true, time.Time{}
+	}
+
+	// Obtain aggregates per-domain failures into one message, so fall back to the problem types
+	for _, problemType := range []string{problemRateLimited, problemServerInternal, problemConnection} {
+		if strings.Contains(message, problemType) {
+			return true, time.Time{}
+		}
+	}
+	return false, time.Time{}
+}
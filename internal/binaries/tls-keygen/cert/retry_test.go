@@ -0,0 +1,147 @@
+package cert
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-acme/lego/v4/acme"
+)
+
+// newRetryTestManager returns a manager whose retry delays elapse at once and are recorded
+func newRetryTestManager(now time.Time) (*LegoManager, *[]time.Duration) {
+	var delays []time.Duration
+	manager := NewLegoManager(&mockLegoStorage{}, &mockLegoLocalWriter{}, slog.Default())
+	manager.SetClock(func() time.Time { return now })
+	manager.after = func(d time.Duration) <-chan time.Time {
+		delays = append(delays, d)
+		ch := make(chan time.Time, 1)
+		ch <- now
+		return ch
+	}
+	return manager, &delays
+}
+
+// failingThenSucceeding returns an operation that fails with errs in turn, then succeeds
+func failingThenSucceeding(calls *int, errs ...error) func() error {
+	return func() error {
+		*calls++
+		if *calls <= len(errs) {
+			return errs[*calls-1]
+		}
+		return nil
+	}
+}
+
+func TestWithACMERetry_RetryableThenSuccess(t *testing.T) {
+	manager, delays := newRetryTestManager(time.Now())
+
+	serverErr := &acme.ProblemDetails{Type: problemServerInternal, HTTPStatus: http.StatusInternalServerError}
+	calls := 0
+	err := manager.withACMERetry(context.Background(), "obtain certificate", 3, time.Second, failingThenSucceeding(&calls, serverErr, serverErr))
+	if err != nil {
+		t.Fatalf("expected success after retries, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls)
+	}
+	if want := []time.Duration{time.Second, 2 * time.Second}; !slices.Equal(*delays, want) {
+		t.Errorf("expected backoff %v, got %v", want, *delays)
+	}
+}
+
+func TestWithACMERetry_PermanentError(t *testing.T) {
+	manager, delays := newRetryTestManager(time.Now())
+
+	unauthorized := &acme.ProblemDetails{Type: "urn:ietf:params:acme:error:unauthorized", HTTPStatus: http.StatusForbidden}
+	calls := 0
+	err := manager.withACMERetry(context.Background(), "obtain certificate", 3, time.Second, failingThenSucceeding(&calls, unauthorized))
+	if !errors.Is(err, unauthorized) {
+		t.Fatalf("expected the permanent error, got %v", err)
+	}
+	if calls != 1 || len(*delays) != 0 {
+		t.Errorf("expected a single attempt without retries, got %d attempts and delays %v", calls, *delays)
+	}
+}
+
+func TestWithACMERetry_AttemptsExhausted(t *testing.T) {
+	manager, _ := newRetryTestManager(time.Now())
+
+	calls := 0
+	err := manager.withACMERetry(context.Background(), "register account", 2, time.Second, failingThenSucceeding(&calls, errors.New("x: urn:ietf:params:acme:error:connection"), errors.New("y: urn:ietf:params:acme:error:connection")))
+	if err == nil || !strings.HasPrefix(err.Error(), "y:") {
+		t.Fatalf("expected the last error, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 attempts, got %d", calls)
+	}
+}
+
+func TestWithACMERetry_RateLimitRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	rateLimited := &acme.ProblemDetails{
+		Type:       problemRateLimited,
+		Detail:     "too many failed authorizations recently, retry after 2025-01-01 12:00:30 UTC: see https://letsencrypt.org/docs/rate-limits/",
+		HTTPStatus: http.StatusTooManyRequests,
+	}
+
+	t.Run("waits for the retry-after within the timeout", func(t *testing.T) {
+		manager, delays := newRetryTestManager(now)
+		ctx, cancel := context.WithDeadline(context.Background(), now.Add(time.Minute))
+		defer cancel()
+
+		calls := 0
+		if err := manager.withACMERetry(ctx, "obtain certificate", 3, time.Second, failingThenSucceeding(&calls, rateLimited)); err != nil {
+			t.Fatalf("expected success after the retry-after, got %v", err)
+		}
+		if want := []time.Duration{30 * time.Second}; !slices.Equal(*delays, want) {
+			t.Errorf("expected delays %v, got %v", want, *delays)
+		}
+	})
+
+	t.Run("fails fast when the retry-after is past the timeout", func(t *testing.T) {
+		manager, delays := newRetryTestManager(now)
+		ctx, cancel := context.WithDeadline(context.Background(), now.Add(10*time.Second))
+		defer cancel()
+
+		calls := 0
+		err := manager.withACMERetry(ctx, "obtain certificate", 3, time.Second, failingThenSucceeding(&calls, rateLimited))
+		if !errors.Is(err, rateLimited) || !strings.Contains(err.Error(), "would exceed the timeout") {
+			t.Fatalf("expected a rate limit error beyond the timeout, got %v", err)
+		}
+		if calls != 1 || len(*delays) != 0 {
+			t.Errorf("expected no retries, got %d attempts and delays %v", calls, *delays)
+		}
+	})
+}
+
+func TestClassifyACMEError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		retryable bool
+	}{
+		{name: "server error", err: &acme.ProblemDetails{Type: problemServerInternal, HTTPStatus: 500}, retryable: true},
+		{name: "service unavailable", err: &acme.ProblemDetails{Type: "about:blank", HTTPStatus: 503}, retryable: true},
+		{name: "rate limited", err: &acme.ProblemDetails{Type: problemRateLimited, HTTPStatus: 429}, retryable: true},
+		{name: "unauthorized", err: &acme.ProblemDetails{Type: "urn:ietf:params:acme:error:unauthorized", HTTPStatus: 403}},
+		{name: "caa", err: &acme.ProblemDetails{Type: "urn:ietf:params:acme:error:caa", HTTPStatus: 403}},
+		{name: "aggregated connection failure", err: errors.New("error: one or more domains had a problem:\n[example.com] acme: error: 400 :: urn:ietf:params:acme:error:connection :: refused"), retryable: true},
+		{name: "timeout", err: context.DeadlineExceeded},
+		{name: "other", err: errors.New("invalid domain")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retryable, _ := classifyACMEError(tt.err)
+			if retryable != tt.retryable {
+				t.Errorf("classifyACMEError(%v) retryable = %v, want %v", tt.err, retryable, tt.retryable)
+			}
+		})
+	}
+}
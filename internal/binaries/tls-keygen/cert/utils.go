@@ -1,7 +1,7 @@
 package cert
 
 import (
-	"crypto/ecdsa"
+	"crypto"
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
@@ -37,8 +37,8 @@ func LeafCertificateExpiry(pemData []byte) time.Time {
 
 // LeafPubMatches checks if the first cert in chain matches the given public key
 //
-// Returns true if the leaf certificate's public key matches the provided ECDSA key.
-func LeafPubMatches(chainPEM []byte, pub *ecdsa.PublicKey) bool {
+// Returns true if the leaf certificate's public key matches the provided ECDSA or RSA key.
+func LeafPubMatches(chainPEM []byte, pub crypto.PublicKey) bool {
 	block, _ := pem.Decode(chainPEM)
 	if block == nil || block.Type != "CERTIFICATE" {
 		return false
@@ -47,7 +47,7 @@ func LeafPubMatches(chainPEM []byte, pub *ecdsa.PublicKey) bool {
 	if err != nil {
 		return false
 	}
-	certPub, ok := cert.PublicKey.(*ecdsa.PublicKey)
+	certPub, ok := cert.PublicKey.(interface{ Equal(crypto.PublicKey) bool })
 	return ok && certPub.Equal(pub)
 }
 
@@ -43,6 +43,23 @@ func main() {
 				Value:   "http-01",
 				EnvVars: []string{"ACME_CHALLENGE"},
 			},
+			&cli.StringFlag{
+				Name:    "key-type",
+				Usage:   "TLS key type: ecdsa (P-256) or rsa",
+				Value:   "ecdsa",
+				EnvVars: []string{"TLS_KEY_TYPE"},
+			},
+			&cli.IntFlag{
+				Name:    "rsa-bits",
+				Usage:   "RSA key size when key-type is rsa: 2048, 3072 or 4096",
+				Value:   2048,
+				EnvVars: []string{"TLS_RSA_BITS"},
+			},
+			&cli.BoolFlag{
+				Name:    "must-staple",
+				Usage:   "Request the OCSP Must-Staple extension",
+				EnvVars: []string{"ACME_MUST_STAPLE"},
+			},
 			&cli.StringFlag{
 				Name:    "ca",
 				Usage:   "ACME CA URL (overrides -staging)",
@@ -59,6 +76,18 @@ func main() {
 				Value:   2 * time.Minute,
 				EnvVars: []string{"ACME_TIMEOUT"},
 			},
+			&cli.IntFlag{
+				Name:    "acme-retries",
+				Usage:   "Attempts for each ACME request before giving up on transient and rate limit errors",
+				Value:   3,
+				EnvVars: []string{"ACME_RETRIES"},
+			},
+			&cli.DurationFlag{
+				Name:    "acme-retry-backoff",
+				Usage:   "Delay before the first ACME retry, doubled after each attempt",
+				Value:   5 * time.Second,
+				EnvVars: []string{"ACME_RETRY_BACKOFF"},
+			},
 			&cli.BoolFlag{
 				Name:    "force",
 				Usage:   "Force certificate reissue even if a valid one exists",
@@ -200,20 +229,25 @@ func buildOptions(c *cli.Context) config.Config {
 	}
 
 	return config.Config{
-		Mnemonic:      mnemonic,
-		Domain:        domain,
-		AltNames:      altNamesList,
-		Email:         c.String("email"),
-		OutDir:        "/run/tls", // Hardcoded
-		Challenge:     config.Challenge(c.String("challenge")),
-		CADir:         caURL,
-		Timeout:       c.Duration("timeout"),
-		RenewalWindow: c.Duration("renewal-window"),
-		Version:       uint32(c.Uint("version")),
-		ForceIssue:    forceIssue,
-		APIURL:        c.String("api-url"),
-		Staging:       staging,
-		TokenAudience: tokenAudience,
-		UserAgent:     "eigenx-tls-keygen/1.0",
+		Mnemonic:         mnemonic,
+		Domain:           domain,
+		AltNames:         altNamesList,
+		Email:            c.String("email"),
+		OutDir:           "/run/tls", // Hardcoded
+		Challenge:        config.Challenge(c.String("challenge")),
+		KeyType:          config.KeyType(c.String("key-type")),
+		RSABits:          c.Int("rsa-bits"),
+		MustStaple:       c.Bool("must-staple"),
+		CADir:            caURL,
+		Timeout:          c.Duration("timeout"),
+		ACMERetries:      c.Int("acme-retries"),
+		ACMERetryBackoff: c.Duration("acme-retry-backoff"),
+		RenewalWindow:    c.Duration("renewal-window"),
+		Version:          uint32(c.Uint("version")),
+		ForceIssue:       forceIssue,
+		APIURL:           c.String("api-url"),
+		Staging:          staging,
+		TokenAudience:    tokenAudience,
+		UserAgent:        "eigenx-tls-keygen/1.0",
 	}
-}
\ No newline at end of file
+}
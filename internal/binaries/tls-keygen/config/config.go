@@ -9,10 +9,16 @@ import (
 // Challenge represents the ACME challenge type
 type Challenge string
 
+// KeyType represents the TLS private key algorithm
+type KeyType string
+
 const (
 	HTTP01    Challenge = "http-01"
 	TLSALPN01 Challenge = "tls-alpn-01"
 
+	KeyECDSA KeyType = "ecdsa"
+	KeyRSA   KeyType = "rsa"
+
 	// Let's Encrypt CA URLs
 	LEProd    = "https://acme-v02.api.letsencrypt.org/directory"
 	LEStaging = "https://acme-staging-v02.api.letsencrypt.org/directory"
@@ -38,9 +44,21 @@ type Config struct {
 	// Challenge type for ACME
 	Challenge Challenge
 
+	// TLS key algorithm (empty means ECDSA P-256)
+	KeyType KeyType
+	// RSA modulus size in bits, used when KeyType is rsa
+	RSABits int
+	// Request the OCSP Must-Staple extension
+	MustStaple bool
+
 	// Operation timeout
 	Timeout time.Duration
 
+	// Attempts for each ACME request (account registration, issuance); 0 or 1 disables retries
+	ACMERetries int
+	// Delay before the first ACME retry, doubled after each attempt
+	ACMERetryBackoff time.Duration
+
 	// Remote persistence API
 	APIURL string
 
@@ -59,7 +77,7 @@ type Config struct {
 
 // Validate checks structural constraints
 //
-// Returns error if mnemonic or domain is missing, or if challenge type is invalid.
+// Returns error if mnemonic or domain is missing, or if challenge or key type is invalid.
 func (o *Config) Validate() error {
 	if o.Mnemonic == "" {
 		return errors.New("mnemonic is required")
@@ -70,5 +88,14 @@ func (o *Config) Validate() error {
 	if o.Challenge != HTTP01 && o.Challenge != TLSALPN01 {
 		return fmt.Errorf("invalid challenge type: %s", o.Challenge)
 	}
+	switch o.KeyType {
+	case "", KeyECDSA:
+	case KeyRSA:
+		if o.RSABits != 2048 && o.RSABits != 3072 && o.RSABits != 4096 {
+			return fmt.Errorf("invalid RSA key size: %d (must be 2048, 3072 or 4096)", o.RSABits)
+		}
+	default:
+		return fmt.Errorf("invalid key type: %s", o.KeyType)
+	}
 	return nil
-}
\ No newline at end of file
+}
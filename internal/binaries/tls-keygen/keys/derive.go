@@ -4,8 +4,13 @@ import (
 	"crypto"
 	"crypto/ecdsa"
 	"crypto/elliptic"
+	"crypto/rsa"
 	"crypto/sha256"
 	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
 
 	"github.com/tyler-smith/go-bip39"
 	"golang.org/x/crypto/hkdf"
@@ -15,6 +20,10 @@ import (
 const (
 	keyInfoAccount   = "eigenx/acme-account/v1"
 	keyInfoTLSPrefix = "eigenx/tls-key/v1"
+	keyInfoRSAPrefix = "eigenx/tls-key-rsa/v1"
+
+	// rsaPublicExponent is the public exponent of derived RSA keys (F4, as crypto/rsa uses)
+	rsaPublicExponent = 65537
 )
 
 // SeedFromMnemonic converts a BIP-39 mnemonic into a seed
@@ -40,11 +49,90 @@ func DeriveAccountKey(seed []byte) crypto.Signer {
 //
 // Returns P-256 ECDSA private key for TLS certificate.
 func DeriveTLSKey(seed []byte, domain string, version uint32) *ecdsa.PrivateKey {
-	info := append([]byte(keyInfoTLSPrefix), []byte(domain)...)
+	return deriveP256(seed, tlsKeyInfo(keyInfoTLSPrefix, domain, version)).(*ecdsa.PrivateKey)
+}
+
+// DeriveRSATLSKey deterministically derives an RSA key of the given size for domain/version
+//
+// The primes are found by searching upwards from HKDF output, so the same inputs always give
+// the same key. Keys of different sizes are derived independently.
+//
+// Returns error if bits is not a positive multiple of 16.
+func DeriveRSATLSKey(seed []byte, domain string, version uint32, bits int) (*rsa.PrivateKey, error) {
+	if bits <= 0 || bits%16 != 0 {
+		return nil, fmt.Errorf("invalid RSA key size: %d", bits)
+	}
+	info := tlsKeyInfo(keyInfoRSAPrefix, domain, version)
+	var b [4]byte
+	binary.BigEndian.PutUint32(b[:], uint32(bits))
+	info = append(info, b[:]...)
+
+	rd := hkdf.New(sha256.New, seed, nil, info)
+	p, err := derivePrime(rd, bits/2)
+	if err != nil {
+		return nil, err
+	}
+	q, err := derivePrime(rd, bits/2)
+	if err != nil {
+		return nil, err
+	}
+	if p.Cmp(q) == 0 {
+		return nil, errors.New("derived RSA primes are equal")
+	}
+
+	one := big.NewInt(1)
+	pMinus1 := new(big.Int).Sub(p, one)
+	qMinus1 := new(big.Int).Sub(q, one)
+	phi := new(big.Int).Mul(pMinus1, qMinus1)
+	d := new(big.Int).ModInverse(big.NewInt(rsaPublicExponent), phi)
+	if d == nil {
+		return nil, errors.New("derived RSA primes have no private exponent")
+	}
+
+	key := &rsa.PrivateKey{
+		PublicKey: rsa.PublicKey{N: new(big.Int).Mul(p, q), E: rsaPublicExponent},
+		D:         d,
+		Primes:    []*big.Int{p, q},
+	}
+	key.Precompute()
+	if err := key.Validate(); err != nil {
+		return nil, fmt.Errorf("validate derived RSA key: %w", err)
+	}
+	return key, nil
+}
+
+// tlsKeyInfo builds the HKDF info for a TLS key: prefix || domain || version (big-endian)
+func tlsKeyInfo(prefix, domain string, version uint32) []byte {
+	info := append([]byte(prefix), []byte(domain)...)
 	var v [4]byte
 	binary.BigEndian.PutUint32(v[:], version)
-	info = append(info, v[:]...)
-	return deriveP256(seed, info).(*ecdsa.PrivateKey)
+	return append(info, v[:]...)
+}
+
+// derivePrime reads bits/8 bytes from r and returns the first suitable prime at or above them
+//
+// The top two bits are set so the product of two such primes has exactly 2*bits bits.
+func derivePrime(r io.Reader, bits int) (*big.Int, error) {
+	buf := make([]byte, bits/8)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, fmt.Errorf("read prime candidate: %w", err)
+	}
+	buf[0] |= 0xc0
+	buf[len(buf)-1] |= 1
+
+	one, two := big.NewInt(1), big.NewInt(2)
+	e := big.NewInt(rsaPublicExponent)
+	gcd, pMinus1 := new(big.Int), new(big.Int)
+	for p := new(big.Int).SetBytes(buf); p.BitLen() == bits; p.Add(p, two) {
+		if !p.ProbablyPrime(20) {
+			continue
+		}
+		// e must be invertible mod p-1
+		if gcd.GCD(nil, nil, pMinus1.Sub(p, one), e).Cmp(one) == 0 {
+			return p, nil
+		}
+	}
+	return nil, errors.New("no prime found below the candidate size")
 }
 
 // deriveP256 derives a P-256 ECDSA key using HKDF
@@ -68,4 +156,3 @@ func hkdfExpand(seed, info []byte) [32]byte {
 	_, _ = rd.Read(out[:])
 	return out
 }
-
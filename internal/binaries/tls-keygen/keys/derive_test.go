@@ -41,4 +41,39 @@ func TestDeriveTLSKeyDomainAndVersionAffectKey(t *testing.T) {
 	if k1.D.Cmp(k3.D) == 0 {
 		t.Fatalf("expected different keys for different versions")
 	}
-}
\ No newline at end of file
+}
+
+// TestDeriveRSATLSKey verifies RSA keys are deterministic, valid and of the requested size.
+func TestDeriveRSATLSKey(t *testing.T) {
+	seed := SeedFromMnemonic(testMnemonic)
+	k1, err := DeriveRSATLSKey(seed, "example.com", 0, 2048)
+	if err != nil {
+		t.Fatalf("DeriveRSATLSKey() error = %v", err)
+	}
+	if k1.N.BitLen() != 2048 {
+		t.Fatalf("expected 2048-bit modulus, got %d", k1.N.BitLen())
+	}
+	if err := k1.Validate(); err != nil {
+		t.Fatalf("derived key is invalid: %v", err)
+	}
+
+	k2, err := DeriveRSATLSKey(seed, "example.com", 0, 2048)
+	if err != nil {
+		t.Fatalf("DeriveRSATLSKey() error = %v", err)
+	}
+	if k1.N.Cmp(k2.N) != 0 || k1.D.Cmp(k2.D) != 0 {
+		t.Fatalf("expected deterministic RSA key")
+	}
+
+	k3, err := DeriveRSATLSKey(seed, "example.com", 1, 2048)
+	if err != nil {
+		t.Fatalf("DeriveRSATLSKey() error = %v", err)
+	}
+	if k1.N.Cmp(k3.N) == 0 {
+		t.Fatalf("expected different keys for different versions")
+	}
+
+	if _, err := DeriveRSATLSKey(seed, "example.com", 0, 2047); err == nil {
+		t.Fatalf("expected error for invalid key size")
+	}
+}
@@ -1,7 +1,7 @@
 package storage
 
 import (
-	"crypto/ecdsa"
+	"crypto"
 	"time"
 )
 
@@ -49,8 +49,8 @@ type LocalWriter interface {
 	// Returns the full path to the written file.
 	WriteChain(outDir string, chain ChainPEM) (string, error)
 
-	// WriteKey writes private key to disk
+	// WriteKey writes private key (ECDSA or RSA) to disk
 	//
 	// Returns the full path to the written file.
-	WriteKey(outDir string, key *ecdsa.PrivateKey) (string, error)
+	WriteKey(outDir string, key crypto.Signer) (string, error)
 }
@@ -1,7 +1,9 @@
 package storage
 
 import (
+	"crypto"
 	"crypto/ecdsa"
+	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
@@ -31,12 +33,21 @@ func (LocalFileWriter) WriteChain(outDir string, chain ChainPEM) (string, error)
 }
 
 // WriteKey writes private key to disk
-func (LocalFileWriter) WriteKey(outDir string, key *ecdsa.PrivateKey) (string, error) {
-	der, err := x509.MarshalECPrivateKey(key)
-	if err != nil {
-		return "", fmt.Errorf("marshal EC key: %w", err)
+func (LocalFileWriter) WriteKey(outDir string, key crypto.Signer) (string, error) {
+	var block *pem.Block
+	switch k := key.(type) {
+	case *ecdsa.PrivateKey:
+		der, err := x509.MarshalECPrivateKey(k)
+		if err != nil {
+			return "", fmt.Errorf("marshal EC key: %w", err)
+		}
+		block = &pem.Block{Type: "EC PRIVATE KEY", Bytes: der}
+	case *rsa.PrivateKey:
+		block = &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(k)}
+	default:
+		return "", fmt.Errorf("unsupported key type: %T", key)
 	}
-	data := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+	data := pem.EncodeToMemory(block)
 
 	_, path := CertPaths(outDir)
 	// Ensure directory exists
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -17,8 +18,16 @@ const (
 	metadataServerURL = "http://metadata.google.internal/computeMetadata/v1"
 	identityEndpoint  = "/instance/service-accounts/default/identity"
 	instanceHeader    = "X-Instance-Token"
+
+	// The metadata server can be briefly unavailable while the instance boots
+	metadataTokenAttempts = 4
+	metadataRetryDelay    = 500 * time.Millisecond
 )
 
+// ErrMetadataUnreachable means no response came from the GCE metadata server at all, as opposed
+// to the server answering with an error
+var ErrMetadataUnreachable = errors.New("GCE metadata server unreachable")
+
 // RemoteCertificateStorage represents the certificate data sent to the API
 type RemoteCertificateStorage struct {
 	Certificate string `json:"certificate"`
@@ -45,14 +54,17 @@ type RemoteStorage struct {
 	TokenAudience string // Audience for GCE identity token
 	Client        *http.Client
 	Log           *slog.Logger
+
+	tokenRetryDelay time.Duration // Delay before the first token fetch retry, doubled after each
 }
 
 // NewRemoteStorage creates a new remote storage client
 func NewRemoteStorage(baseURL string, audience string, log *slog.Logger) *RemoteStorage {
 	return &RemoteStorage{
-		BaseURL:       baseURL,
-		TokenAudience: audience,
-		Log:           log,
+		BaseURL:         baseURL,
+		TokenAudience:   audience,
+		Log:             log,
+		tokenRetryDelay: metadataRetryDelay,
 	}
 }
 
@@ -168,8 +180,36 @@ func (r *RemoteStorage) Store(domain string, chain ChainPEM) error {
 	return nil
 }
 
-// fetchGCEToken retrieves a GCE instance identity token from the metadata server
+// fetchGCEToken retrieves a GCE instance identity token from the metadata server, retrying
+// while the server is unreachable or failing
 func (r *RemoteStorage) fetchGCEToken() (string, error) {
+	delay := r.tokenRetryDelay
+	var err error
+	for attempt := 1; attempt <= metadataTokenAttempts; attempt++ {
+		var token string
+		var retryable bool
+		token, retryable, err = r.requestGCEToken()
+		if err == nil {
+			return token, nil
+		}
+		if !retryable {
+			return "", err
+		}
+		if attempt < metadataTokenAttempts {
+			r.Log.Warn("GCE metadata server not ready, retrying", "attempt", attempt, "delay", delay, "error", err)
+			time.Sleep(delay)
+			delay *= 2
+		}
+	}
+
+	if errors.Is(err, ErrMetadataUnreachable) {
+		return "", fmt.Errorf("%w after %d attempts; not running on GCE, or the metadata server is disabled?", err, metadataTokenAttempts)
+	}
+	return "", fmt.Errorf("%w (after %d attempts)", err, metadataTokenAttempts)
+}
+
+// requestGCEToken makes a single token request and reports whether a failure is worth retrying
+func (r *RemoteStorage) requestGCEToken() (string, bool, error) {
 	// Build the request URL with query parameters
 	url := fmt.Sprintf("%s%s?audience=%s&format=full",
 		metadataServerURL, identityEndpoint, r.TokenAudience)
@@ -180,7 +220,7 @@ func (r *RemoteStorage) fetchGCEToken() (string, error) {
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return "", fmt.Errorf("creating metadata request: %w", err)
+		return "", false, fmt.Errorf("creating metadata request: %w", err)
 	}
 
 	// Add required metadata header
@@ -189,29 +229,30 @@ func (r *RemoteStorage) fetchGCEToken() (string, error) {
 	// Execute request
 	resp, err := r.httpClient().Do(req)
 	if err != nil {
-		return "", fmt.Errorf("metadata server request failed: %w", err)
+		return "", true, fmt.Errorf("%w: %w", ErrMetadataUnreachable, err)
 	}
 	defer resp.Body.Close()
 
 	// Check response status
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("metadata server returned %d: %s",
+		retryable := resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+		return "", retryable, fmt.Errorf("metadata server returned %d: %s",
 			resp.StatusCode, strings.TrimSpace(string(body)))
 	}
 
 	// Read token from response body
 	tokenBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("reading token response: %w", err)
+		return "", true, fmt.Errorf("reading token response: %w", err)
 	}
 
 	token := strings.TrimSpace(string(tokenBytes))
 	if token == "" {
-		return "", fmt.Errorf("metadata server returned empty token")
+		return "", false, fmt.Errorf("metadata server returned empty token")
 	}
 
-	return token, nil
+	return token, false, nil
 }
 
 // httpClient returns the HTTP client to use, creating one if necessary
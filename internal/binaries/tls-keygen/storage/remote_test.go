@@ -2,12 +2,21 @@ package storage
 
 import (
 	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"io"
 	"log/slog"
 	"net/http"
 	"os"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 )
@@ -323,6 +332,95 @@ func TestGCETokenAudience(t *testing.T) {
 	}
 }
 
+// TestGCETokenRetry tests that transient metadata server failures are retried
+func TestGCETokenRetry(t *testing.T) {
+	transport := &mockHTTPTransport{
+		responses: []mockResponse{
+			{err: syscall.ECONNREFUSED}, // Metadata server not up yet
+			{status: 503, body: "Service Unavailable"},
+			{status: 200, body: "test-gce-jwt-token"},
+			{status: 200, body: `{"certificate": "test", "metadata": {"expires_at": "2024-12-31T23:59:59Z", "issued_at": "2024-01-01T00:00:00Z"}}`},
+		},
+	}
+	storage := &RemoteStorage{
+		BaseURL:       "https://api.test.com",
+		TokenAudience: "test-audience",
+		Client:        &http.Client{Transport: transport},
+		Log:           slog.Default(),
+	}
+
+	if _, _, err := storage.Load("example.com"); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(transport.requests) != 4 {
+		t.Fatalf("expected 4 requests, got %d", len(transport.requests))
+	}
+	if transport.requests[3].Header.Get("X-Instance-Token") != "test-gce-jwt-token" {
+		t.Errorf("missing or incorrect instance token header")
+	}
+}
+
+// TestGCETokenErrors tests that an unreachable metadata server is reported apart from one
+// that answers with an error, and that client errors are not retried
+func TestGCETokenErrors(t *testing.T) {
+	tests := []struct {
+		name            string
+		response        mockResponse
+		wantRequests    int
+		wantUnreachable bool
+		wantErr         string
+	}{
+		{
+			name:            "unreachable",
+			response:        mockResponse{err: syscall.ECONNREFUSED},
+			wantRequests:    metadataTokenAttempts,
+			wantUnreachable: true,
+			wantErr:         "not running on GCE, or the metadata server is disabled?",
+		},
+		{
+			name:         "server error",
+			response:     mockResponse{status: 500, body: "Internal Server Error"},
+			wantRequests: metadataTokenAttempts,
+			wantErr:      "metadata server returned 500: Internal Server Error",
+		},
+		{
+			name:         "client error",
+			response:     mockResponse{status: 404, body: "Not Found"},
+			wantRequests: 1,
+			wantErr:      "metadata server returned 404: Not Found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var responses []mockResponse
+			for range metadataTokenAttempts {
+				responses = append(responses, tt.response)
+			}
+			transport := &mockHTTPTransport{responses: responses}
+			storage := &RemoteStorage{
+				TokenAudience: "test-audience",
+				Client:        &http.Client{Transport: transport},
+				Log:           slog.Default(),
+			}
+
+			_, err := storage.fetchGCEToken()
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("fetchGCEToken() error = %q, want it to contain %q", err, tt.wantErr)
+			}
+			if errors.Is(err, ErrMetadataUnreachable) != tt.wantUnreachable {
+				t.Errorf("errors.Is(err, ErrMetadataUnreachable) = %v, want %v", !tt.wantUnreachable, tt.wantUnreachable)
+			}
+			if len(transport.requests) != tt.wantRequests {
+				t.Errorf("expected %d requests, got %d", tt.wantRequests, len(transport.requests))
+			}
+		})
+	}
+}
+
 // TestLocalFileWriter tests the local file writing implementation
 func TestLocalFileWriter(t *testing.T) {
 	writer := &LocalFileWriter{}
@@ -346,3 +444,49 @@ func TestLocalFileWriter(t *testing.T) {
 		t.Errorf("file content mismatch")
 	}
 }
+
+// TestLocalFileWriterKeyTypes tests that ECDSA and RSA keys are written as parseable PEM
+func TestLocalFileWriterKeyTypes(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		key       crypto.Signer
+		blockType string
+		parse     func([]byte) (any, error)
+	}{
+		{"ecdsa", ecKey, "EC PRIVATE KEY", func(der []byte) (any, error) { return x509.ParseECPrivateKey(der) }},
+		{"rsa", rsaKey, "RSA PRIVATE KEY", func(der []byte) (any, error) { return x509.ParsePKCS1PrivateKey(der) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, err := LocalFileWriter{}.WriteKey(t.TempDir(), tt.key)
+			if err != nil {
+				t.Fatalf("WriteKey() error = %v", err)
+			}
+			content, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("failed to read written file: %v", err)
+			}
+			block, _ := pem.Decode(content)
+			if block == nil || block.Type != tt.blockType {
+				t.Fatalf("expected %s PEM block", tt.blockType)
+			}
+			parsed, err := tt.parse(block.Bytes)
+			if err != nil {
+				t.Fatalf("failed to parse written key: %v", err)
+			}
+			if !tt.key.Public().(interface{ Equal(crypto.PublicKey) bool }).Equal(parsed.(crypto.Signer).Public()) {
+				t.Errorf("written key does not match")
+			}
+		})
+	}
+}
@@ -12,12 +12,20 @@ var AppCommand = &cli.Command{
 		app.CreateCommand,
 		app.DeployCommand,
 		app.UpgradeCommand,
+		app.CloneCommand,
+		app.RedeployCommand,
+		app.DiffCommand,
+		app.ExportCommand,
+		app.EnvCommand,
 		app.StartCommand,
 		app.StopCommand,
 		app.TerminateCommand,
 		app.ListCommand,
 		app.InfoCommand,
+		app.StatusCommand,
+		app.OpenCommand,
 		app.LogsCommand,
+		app.SSHCommand,
 		app.ProfileCommand,
 		app.ConfigureTLSCommand,
 	},
@@ -0,0 +1,226 @@
+package app
+
+import (
+	"crypto/rand"
+	"fmt"
+	"maps"
+	"os"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/commands/utils"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common/output"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/urfave/cli/v2"
+)
+
+var CloneCommand = &cli.Command{
+	Name:      "clone",
+	Usage:     "Deploy a new app with the image, public env, instance type and log visibility of an existing app",
+	ArgsUsage: "<src-app-id|name> [new-name]",
+	Flags: append(common.GlobalFlags, []cli.Flag{
+		common.EnvironmentFlag,
+		common.RpcUrlFlag,
+		common.PrivateKeyFlag,
+		common.PrivateEnvFileFlag,
+		common.EnvCheckEntropyFlag,
+		common.ShowValuesFlag,
+		common.EnvEntropyThresholdFlag,
+		common.WaitIntervalBackoffFlag,
+		common.ProgressFormatFlag,
+		common.OutputTxHashFileFlag,
+		common.SkipBillingCheckFlag,
+	}...),
+	Action: cloneAction,
+}
+
+func cloneAction(cCtx *cli.Context) error {
+	logger := common.LoggerFromContext(cCtx)
+
+	if cCtx.Args().Len() < 1 {
+		return fmt.Errorf("please provide the source app ID or name")
+	}
+	if err := utils.ApplyProgressFormat(cCtx); err != nil {
+		return err
+	}
+
+	// 1. Do preflight checks (auth, network, etc.) first
+	preflightCtx, err := utils.DoPreflightChecks(cCtx)
+	if err != nil {
+		return err
+	}
+	environment := preflightCtx.EnvironmentConfig.Name
+
+	// 2. Check quota availability
+	if err := checkQuotaAvailable(cCtx, preflightCtx); err != nil {
+		return err
+	}
+
+	// 3. Read the source app's latest release and settings
+	sourceID, err := utils.ResolveAppIDOrName(cCtx, cCtx.Args().Get(0))
+	if err != nil {
+		return fmt.Errorf("failed to resolve source app: %w", err)
+	}
+
+	source, err := utils.GetDeployedRelease(cCtx.Context, preflightCtx.Caller, sourceID)
+	if err != nil {
+		return err
+	}
+
+	publicLogs, err := utils.CheckAppLogPermission(cCtx, sourceID)
+	if err != nil {
+		return fmt.Errorf("failed to check source app log visibility: %w", err)
+	}
+
+	// The instance type is re-injected into the new release's public env
+	instanceType := source.PublicEnv[common.EigenMachineTypeEnvVar]
+	if instanceType == "" {
+		instanceType = getCurrentInstanceType(cCtx, sourceID)
+	}
+	if instanceType == "" {
+		return fmt.Errorf("failed to determine the instance type of source app %s", sourceID.Hex())
+	}
+	delete(source.PublicEnv, common.EigenMachineTypeEnvVar)
+
+	logger.Info("Cloning %s", common.FormatAppDisplay(environment, sourceID, ""))
+	logger.Info("Image: %s", source.ImageRef())
+
+	// 4. Get the new app's name
+	appName, err := getCloneName(cCtx, environment)
+	if err != nil {
+		return err
+	}
+
+	// 5. Private env is encrypted per app and cannot be copied
+	privateEnvFilePath, err := getClonePrivateEnvFile(cCtx)
+	if err != nil {
+		return err
+	}
+
+	publicEnv, privateEnv, err := utils.ParseEnvWithPublicBase(cCtx, source.PublicEnv, "copied from "+sourceID.Hex(), privateEnvFilePath)
+	if err != nil {
+		return err
+	}
+
+	// 6. Deploy the unchanged image, encrypting the private env for the new app
+	return deployImage(cCtx, preflightCtx, source, publicEnv, privateEnv, instanceType, publicLogs, appName)
+}
+
+// deployImage deploys a new app running the digest-pinned image, names it appName
+// and watches until it is running. The private env is encrypted for the new app.
+func deployImage(cCtx *cli.Context, preflightCtx *utils.PreflightContext, image *utils.DeployedRelease, publicEnv, privateEnv map[string]string, instanceType string, publicLogs bool, appName string) error {
+	logger := common.LoggerFromContext(cCtx)
+
+	// Generate random salt and calculate the new app ID
+	salt := [32]byte{}
+	if _, err := rand.Read(salt[:]); err != nil {
+		return fmt.Errorf("failed to generate random salt: %w", err)
+	}
+
+	_, appController, err := utils.GetAppControllerBinding(cCtx)
+	if err != nil {
+		return fmt.Errorf("failed to get app controller binding: %w", err)
+	}
+	rpcCtx, cancel := utils.RPCContext(cCtx)
+	appIDToBeDeployed, err := appController.CalculateAppId(&bind.CallOpts{Context: rpcCtx}, preflightCtx.Caller.SelfAddress, salt)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to get app id: %w", err)
+	}
+
+	// Build the release for the unchanged image
+	release, err := utils.NewReleaseForImage(cCtx, preflightCtx.EnvironmentConfig, appIDToBeDeployed, image.Digest, image.Registry, publicEnv, privateEnv, instanceType)
+	if err != nil {
+		return err
+	}
+
+	// Deploy the app
+	utils.ReportStage(cCtx, utils.StageSubmitTx, 0, "Submitting deploy transaction")
+	appID, txHash, err := preflightCtx.Caller.DeployApp(cCtx.Context, salt, release, publicLogs, image.ImageRef())
+	if err != nil {
+		return fmt.Errorf("failed to deploy app: %w", err)
+	}
+	utils.ReportStage(cCtx, utils.StageSubmitTx, 100, "Deploy transaction confirmed")
+	utils.RecordTxHash(cCtx, txHash)
+
+	if appName != "" {
+		if err := common.SetAppName(preflightCtx.EnvironmentConfig.Name, appID.Hex(), appName); err != nil {
+			logger.Warn("Failed to name app '%s': %s", appName, err.Error())
+		} else {
+			logger.Info("App named '%s'", appName)
+		}
+	}
+
+	// Watch until deployment completes, unless --wait=false
+	if utils.SkipWatch(cCtx) {
+		utils.PrintSkippedWatchHint(cCtx, appID)
+		return nil
+	}
+	if err := utils.WatchUntilTransitionComplete(cCtx, appID, common.AppStatusDeploying); err != nil {
+		return err
+	}
+
+	// Check the app answers requests if --verify-running is set
+	env := map[string]string{}
+	maps.Copy(env, publicEnv)
+	maps.Copy(env, privateEnv)
+	return utils.VerifyAppServingWithEnv(cCtx, appID, env)
+}
+
+// getCloneName returns the name for the cloned app from the second argument or a prompt.
+// Names passed as an argument must be free; prompted names are re-asked until they are.
+func getCloneName(cCtx *cli.Context, environment string) (string, error) {
+	if cCtx.Args().Len() > 1 {
+		name := cCtx.Args().Get(1)
+		if err := common.ValidateAppName(name); err != nil {
+			return "", fmt.Errorf("invalid app name: %w", err)
+		}
+		if !utils.IsAppNameAvailable(environment, name) {
+			return "", fmt.Errorf("app name '%s' is already taken", name)
+		}
+		return name, nil
+	}
+
+	for {
+		name, err := output.InputString(
+			"Enter a name for the new app:",
+			"Lowercase letters, numbers, hyphens and underscores",
+			"",
+			common.ValidateAppName,
+		)
+		if err != nil {
+			return "", fmt.Errorf("failed to get app name: %w", err)
+		}
+		if utils.IsAppNameAvailable(environment, name) {
+			return name, nil
+		}
+		fmt.Printf("App name '%s' is already taken.\n", name)
+	}
+}
+
+// getClonePrivateEnvFile returns the private env file for the cloned app from
+// --private-env-file or a prompt. An empty path deploys without private variables.
+func getClonePrivateEnvFile(cCtx *cli.Context) (string, error) {
+	if path := cCtx.String(common.PrivateEnvFileFlag.Name); path != "" {
+		if _, err := os.Stat(path); err != nil {
+			return "", fmt.Errorf("private env file %s: %w", path, err)
+		}
+		return path, nil
+	}
+
+	path, err := output.InputString(
+		"Private env file (optional):",
+		"Private variables cannot be copied between apps. Enter a path to set new ones, or press Enter to skip",
+		"",
+		func(s string) error {
+			if s == "" {
+				return nil
+			}
+			_, err := os.Stat(s)
+			return err
+		},
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to get private env file: %w", err)
+	}
+	return path, nil
+}
@@ -24,6 +24,7 @@ var CreateCommand = &cli.Command{
 	Flags: append(common.GlobalFlags, []cli.Flag{
 		common.TemplateRepoFlag,
 		common.TemplateVersionFlag,
+		common.TemplateVarFlag,
 	}...),
 	Action: createAction,
 }
@@ -47,6 +48,7 @@ type projectConfig struct {
 	repoURL       string
 	ref           string
 	subPath       string
+	variables     map[string]string
 }
 
 func createAction(cCtx *cli.Context) error {
@@ -71,7 +73,7 @@ func createAction(cCtx *cli.Context) error {
 	}
 
 	if cfg.subPath != "" {
-		if err := postProcessTemplate(cfg.name, cfg.language, cfg.templateEntry); err != nil {
+		if err := postProcessTemplate(cfg.name, cfg.language, cfg.templateEntry, cfg.variables); err != nil {
 			return fmt.Errorf("failed to post-process template: %w", err)
 		}
 	}
@@ -151,9 +153,47 @@ func gatherProjectConfig(cCtx *cli.Context) (*projectConfig, error) {
 	}
 	cfg.subPath = matchedTemplate.Path
 
+	cfg.variables, err = gatherTemplateVariables(cCtx, matchedTemplate, name)
+	if err != nil {
+		return nil, err
+	}
+
 	return cfg, nil
 }
 
+// gatherTemplateVariables collects a value for each variable the template declares, from --var
+// or a prompt, plus PROJECT_NAME
+func gatherTemplateVariables(cCtx *cli.Context, templateEntry *template.TemplateEntry, projectName string) (map[string]string, error) {
+	values, err := template.ParseVariableAssignments(cCtx.StringSlice(common.TemplateVarFlag.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	declared := templateEntry.PostProcess.Variables
+	if unknown := template.UnknownVariables(declared, values); len(unknown) > 0 {
+		return nil, fmt.Errorf("template does not declare variable(s): %s", strings.Join(unknown, ", "))
+	}
+
+	if _, ok := values[template.ProjectNameVariable]; !ok {
+		values[template.ProjectNameVariable] = filepath.Base(projectName)
+	}
+	for _, variable := range declared {
+		if _, ok := values[variable.Name]; ok {
+			continue
+		}
+		prompt := variable.Description
+		if prompt == "" {
+			prompt = variable.Name
+		}
+		value, err := output.InputString(prompt+":", fmt.Sprintf("Substituted for {{%s}} in the template", variable.Name), variable.Default, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get template variable %s: %w", variable.Name, err)
+		}
+		values[variable.Name] = value
+	}
+	return values, nil
+}
+
 func populateProjectFromTemplate(cCtx *cli.Context, cfg *projectConfig) error {
 	// Handle local templates for development
 	if os.Getenv(template.EnvVarUseLocalTemplates) == "true" {
@@ -211,7 +251,7 @@ func populateProjectFromTemplate(cCtx *cli.Context, cfg *projectConfig) error {
 	return nil
 }
 
-func postProcessTemplate(projectDir, language string, templateEntry *template.TemplateEntry) error {
+func postProcessTemplate(projectDir, language string, templateEntry *template.TemplateEntry, variables map[string]string) error {
 	projectName := filepath.Base(projectDir)
 	templateName := fmt.Sprintf("eigenx-tee-%s-app", language)
 
@@ -236,7 +276,8 @@ func postProcessTemplate(projectDir, language string, templateEntry *template.Te
 		}
 	}
 
-	return nil
+	// Substitute declared template variables
+	return template.SubstituteVariablesInFiles(projectDir, templateEntry.PostProcess.SubstituteIn, variables)
 }
 
 func copySharedTemplateFiles(projectDir string) error {
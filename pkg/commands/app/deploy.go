@@ -1,7 +1,6 @@
 package app
 
 import (
-	"crypto/rand"
 	"fmt"
 
 	"github.com/Layr-Labs/eigenx-cli/pkg/commands/utils"
@@ -19,30 +18,149 @@ var DeployCommand = &cli.Command{
 		common.RpcUrlFlag,
 		common.PrivateKeyFlag,
 		common.EnvFlag,
+		common.EnvPrefixFlag,
+		common.PublicEnvFileFlag,
+		common.PrivateEnvFileFlag,
+		common.MaxEnvSizeFlag,
 		common.FileFlag,
 		common.LogVisibilityFlag,
 		common.InstanceTypeFlag,
+		common.TLSFlag,
+		common.NoTLSFlag,
+		common.TLSEmailFlag,
+		common.TLSStagingFlag,
+		common.ForceFlagWithUsage("Skip confirmation prompts (for automation)"),
+		common.FailOnPlatformMismatchFlag,
+		common.PlatformFlag,
+		common.BuildTimeoutFlag,
+		common.KeepBaseImageFlag,
+		common.WaitIntervalBackoffFlag,
+		common.ProgressFlag,
+		common.ProgressFormatFlag,
+		common.SummaryOnlyFlag,
+		common.EnvCheckEntropyFlag,
+		common.ShowValuesFlag,
+		common.EnvEntropyThresholdFlag,
+		common.BuildSecretFlag,
+		common.BuildArgFlag,
+		common.DockerfileTargetFlag,
+		common.ManifestOutFlag,
+		common.OutputTxHashFileFlag,
+		common.ReuseDelegationCheckFlag,
+		common.SkipBillingCheckFlag,
+		common.OutputEnvTemplateFlag,
+		common.DryRunEnvFlag,
+		common.WaitFlag,
+		common.VerifyRunningFlag,
+		common.HealthPathFlag,
+		common.HealthTimeoutFlag,
+		common.AppManifestFlag,
+		common.BatchManifestFlag,
+		common.ParallelFlag,
+		common.RegistryRetrySameFlag,
+		common.PropagationWaitFlag,
+		common.GitDirtyCheckFlag,
+		common.AllowDirtyFlag,
+		common.RegistryAuthFileFlag,
+		common.RegistryScopeCheckFlag,
+		common.RegistryCACertFlag,
+		common.VerifySignatureFlag,
+		common.CosignKeyFlag,
+		common.DiffOnlyFlag,
+		common.NameFromDirFlag,
 		common.NameFlag,
 		common.WebsiteFlag,
 		common.DescriptionFlag,
 		common.XURLFlag,
 		common.ImageFlag,
+		common.ResizeImageFlag,
+		common.SaltFlag,
+		common.SkipUnchangedFlagWithUsage("With --salt, skip the deploy when the app at that address already runs the same image digest, public env and log visibility. Private env changes are not detected; set to false to deploy anyway"),
 	}...),
 	Action: deployAction,
 }
 
 func deployAction(cCtx *cli.Context) error {
+	utils.ApplySummaryOnly(cCtx)
+	if err := utils.ApplyProgressFormat(cCtx); err != nil {
+		return err
+	}
 	logger := common.LoggerFromContext(cCtx)
 
+	if err := utils.ValidateTLSFlags(cCtx); err != nil {
+		return err
+	}
+	if err := utils.ApplyPlatformFlag(cCtx); err != nil {
+		return err
+	}
+	if err := utils.ValidateSignatureFlags(cCtx); err != nil {
+		return err
+	}
+	if err := utils.ValidateHealthFlags(cCtx); err != nil {
+		return err
+	}
+	if utils.SkipWatch(cCtx) && cCtx.Bool(common.VerifyRunningFlag.Name) {
+		return fmt.Errorf("--%s cannot be combined with --%s=false", common.VerifyRunningFlag.Name, common.WaitFlag.Name)
+	}
+	if err := utils.ValidateEnvFileFlags(cCtx); err != nil {
+		return err
+	}
+	if err := utils.ValidateRegistryAuthFile(cCtx); err != nil {
+		return err
+	}
+	if err := utils.ValidateRegistryCACert(cCtx); err != nil {
+		return err
+	}
+
+	// Preview the env categorization without deploying; no auth or network is needed
+	if cCtx.Bool(common.DryRunEnvFlag.Name) {
+		return dryRunEnv(cCtx)
+	}
+
 	// 1. Do preflight checks (auth, network, etc.) first
 	preflightCtx, err := utils.DoPreflightChecks(cCtx)
 	if err != nil {
 		return err
 	}
 
-	// 2. Check quota availability
-	if err := checkQuotaAvailable(cCtx, preflightCtx); err != nil {
-		return err
+	// A batch deploy checks the quota for the apps it creates itself
+	diffTarget := cCtx.String(common.DiffOnlyFlag.Name)
+	if batchPath := cCtx.String(common.BatchManifestFlag.Name); batchPath != "" {
+		for _, flag := range []string{common.AppManifestFlag.Name, common.DiffOnlyFlag.Name} {
+			if cCtx.IsSet(flag) {
+				return fmt.Errorf("--%s cannot be combined with --%s", common.BatchManifestFlag.Name, flag)
+			}
+		}
+		return deployBatch(cCtx, preflightCtx, batchPath)
+	}
+
+	// 2. Check quota availability (nothing is deployed when only diffing)
+	if diffTarget == "" {
+		if err := checkQuotaAvailable(cCtx, preflightCtx); err != nil {
+			return err
+		}
+	}
+
+	// Deploy an exported app manifest as is, without building
+	if manifestPath := cCtx.String(common.AppManifestFlag.Name); manifestPath != "" {
+		if diffTarget != "" {
+			return fmt.Errorf("--%s cannot be combined with --%s", common.AppManifestFlag.Name, common.DiffOnlyFlag.Name)
+		}
+		return deployFromManifest(cCtx, preflightCtx, manifestPath)
+	}
+
+	// Resolve the directory-based app name before building so a taken name fails fast
+	var appName string
+	if cCtx.Bool(common.NameFromDirFlag.Name) && diffTarget == "" {
+		appName, err = utils.GetAppNameFromDir(preflightCtx.EnvironmentConfig.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Nothing is built or pushed when only diffing
+	if diffTarget != "" {
+		return diffOnly(cCtx, preflightCtx, diffTarget)
 	}
 
 	// 3. Check if docker is running, else try to start it
@@ -58,11 +176,21 @@ func deployAction(cCtx *cli.Context) error {
 	}
 	buildFromDockerfile := dockerfilePath != ""
 
+	// Refuse to ship uncommitted code when the dirty check applies
+	gitCommit := ""
+	if buildFromDockerfile {
+		gitCommit, err = utils.CheckGitWorkingTree(cCtx, preflightCtx.EnvironmentConfig, ".")
+		if err != nil {
+			return err
+		}
+	}
+
 	// 5. Get image reference (context-aware based on Dockerfile decision)
 	imageRef, err := utils.GetImageReferenceInteractive(cCtx, 0, buildFromDockerfile)
 	if err != nil {
 		return fmt.Errorf("failed to get image reference: %w", err)
 	}
+	utils.CheckRegistryPushScope(cCtx, imageRef)
 
 	// 6. Get environment file configuration
 	envFilePath, err := utils.GetEnvFileInteractive(cCtx)
@@ -82,11 +210,10 @@ func deployAction(cCtx *cli.Context) error {
 		return fmt.Errorf("failed to get log settings: %w", err)
 	}
 
-	// 9. Generate random salt
-	salt := [32]byte{}
-	_, err = rand.Read(salt[:])
+	// 9. Use the --salt value or a random salt
+	salt, err := utils.DeploySalt(cCtx)
 	if err != nil {
-		return fmt.Errorf("failed to generate random salt: %w", err)
+		return err
 	}
 
 	// 10. Get app ID
@@ -94,29 +221,93 @@ func deployAction(cCtx *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to get app controller binding: %w", err)
 	}
-	appIDToBeDeployed, err := appController.CalculateAppId(&bind.CallOpts{Context: cCtx.Context}, preflightCtx.Caller.SelfAddress, salt)
+	rpcCtx, cancel := utils.RPCContext(cCtx)
+	appIDToBeDeployed, err := appController.CalculateAppId(&bind.CallOpts{Context: rpcCtx}, preflightCtx.Caller.SelfAddress, salt)
+	cancel()
 	if err != nil {
 		return fmt.Errorf("failed to get app id: %w", err)
 	}
+	logger.Info("App ID: %s", appIDToBeDeployed.Hex())
 
 	// 11. Prepare the release (includes build/push if needed, with automatic retry on permission errors)
-	release, imageRef, err := utils.PrepareReleaseFromContext(cCtx, preflightCtx.EnvironmentConfig, appIDToBeDeployed, dockerfilePath, imageRef, envFilePath, logRedirect, instanceType, 3)
+	release, privateEnv, imageRef, err := utils.PrepareReleaseFromContext(cCtx, preflightCtx.EnvironmentConfig, appIDToBeDeployed, dockerfilePath, imageRef, envFilePath, logRedirect, instanceType, 3)
 	if err != nil {
 		return err
 	}
 
+	// An app created earlier with the same --salt that already runs this release is left as is,
+	// so the same deploy can be repeated in CI. A random salt never matches an existing app.
+	if cCtx.String(common.SaltFlag.Name) != "" && cCtx.Bool(common.SkipUnchangedFlag.Name) {
+		upToDate, err := utils.ReleaseUpToDate(cCtx.Context, preflightCtx.Caller, appIDToBeDeployed, release)
+		if err != nil {
+			return err
+		}
+		if upToDate {
+			currentlyPublic, err := utils.CheckAppLogPermission(cCtx, appIDToBeDeployed)
+			if err != nil {
+				return fmt.Errorf("failed to check current permission state: %w", err)
+			}
+			upToDate = currentlyPublic == publicLogs
+		}
+		if upToDate {
+			fmt.Printf("App ID: %s\n", appIDToBeDeployed.Hex())
+			logger.Warn("App %s already runs this image, public env and log visibility; skipping deploy (private env changes are not detected, pass --%s=false to deploy anyway)", appIDToBeDeployed.Hex(), common.SkipUnchangedFlag.Name)
+			return nil
+		}
+	}
+
 	// 12. Deploy the app
-	appID, err := preflightCtx.Caller.DeployApp(cCtx.Context, salt, release, publicLogs, imageRef)
+	utils.ReportStage(cCtx, utils.StageSubmitTx, 0, "Submitting deploy transaction")
+	appID, txHash, err := preflightCtx.Caller.DeployApp(cCtx.Context, salt, release, publicLogs, imageRef)
 	if err != nil {
 		return fmt.Errorf("failed to deploy app: %w", err)
 	}
+	utils.ReportStage(cCtx, utils.StageSubmitTx, 100, "Deploy transaction confirmed")
+	utils.RecordTxHash(cCtx, txHash)
+
+	// Catch tag races where the registry served a different manifest than the one pinned
+	utils.VerifyReleaseDigest(cCtx, preflightCtx.Caller, appID, release, imageRef)
+
+	if appName != "" {
+		if err := common.SetAppName(preflightCtx.EnvironmentConfig.Name, appID.Hex(), appName); err != nil {
+			logger.Warn("Failed to name app '%s': %s", appName, err.Error())
+		} else {
+			logger.Info("App named '%s'", appName)
+		}
+	}
+
+	// Record the release inputs if requested (non-blocking - the deployment already succeeded)
+	if manifestPath := cCtx.String(common.ManifestOutFlag.Name); manifestPath != "" {
+		err := utils.WriteReleaseManifest(cCtx, manifestPath, utils.ReleaseManifestInput{
+			EnvironmentConfig: preflightCtx.EnvironmentConfig,
+			AppID:             appID,
+			Release:           release,
+			PrivateEnv:        privateEnv,
+			InstanceType:      instanceType,
+			LogRedirect:       logRedirect,
+			PublicLogs:        publicLogs,
+			GitCommit:         gitCommit,
+			TxHash:            txHash,
+		})
+		if err != nil {
+			logger.Warn("Failed to write release manifest: %s", err.Error())
+		}
+	}
+	if templatePath := cCtx.String(common.OutputEnvTemplateFlag.Name); templatePath != "" {
+		if err := utils.WriteEnvTemplate(cCtx, templatePath, appID, release, privateEnv); err != nil {
+			logger.Warn("Failed to write env template: %s", err.Error())
+		}
+	}
 
 	// 13. Collect app profile while deployment is in progress (optional)
 	environment := preflightCtx.EnvironmentConfig.Name
-	suggestedName, err := utils.ExtractAndFindAvailableName(environment, imageRef)
-	if err != nil {
-		logger.Warn("Failed to extract suggested name: %s", err.Error())
-		suggestedName = ""
+	suggestedName := appName
+	if suggestedName == "" {
+		suggestedName, err = utils.ExtractAndFindAvailableName(environment, imageRef)
+		if err != nil {
+			logger.Warn("Failed to extract suggested name: %s", err.Error())
+			suggestedName = ""
+		}
 	}
 
 	logger.Info("Deployment confirmed onchain. While your instance provisions, set up a public profile")
@@ -125,6 +316,7 @@ func deployAction(cCtx *cli.Context) error {
 		logger.Warn("Failed to collect profile: %s", err.Error())
 		profile = nil
 	}
+	defer profile.RemoveResizedImage()
 
 	// 14. Upload profile if provided (non-blocking - warn on failure but don't fail deployment)
 	if profile != nil {
@@ -142,13 +334,43 @@ func deployAction(cCtx *cli.Context) error {
 		}
 	}
 
-	// 15. Watch until deployment completes
-	return utils.WatchUntilTransitionComplete(cCtx, appID, common.AppStatusDeploying)
+	// 15. Watch until deployment completes, unless --wait=false
+	if utils.SkipWatch(cCtx) {
+		utils.PrintSkippedWatchHint(cCtx, appID)
+		utils.PrintReleaseSummary(cCtx, "Deployed", appID, appName, release, txHash)
+		return nil
+	}
+	if err := utils.WatchUntilTransitionComplete(cCtx, appID, common.AppStatusDeploying); err != nil {
+		return err
+	}
+
+	// 16. Check the app answers requests if --verify-running is set
+	if err := utils.VerifyAppServing(cCtx, appID, envFilePath); err != nil {
+		return err
+	}
+
+	utils.PrintReleaseSummary(cCtx, "Deployed", appID, appName, release, txHash)
+	return nil
+}
+
+// dryRunEnv prints the public and private variables a deploy would use, private values masked
+func dryRunEnv(cCtx *cli.Context) error {
+	envFilePath, err := utils.GetEnvFileInteractive(cCtx)
+	if err != nil {
+		return fmt.Errorf("failed to get env file path: %w", err)
+	}
+	_, _, err = utils.ParseEnvFromContext(cCtx, envFilePath)
+	return err
 }
 
 // checkQuotaAvailable verifies that the user has deployment quota available
 // by checking their allowlist status on the contract
 func checkQuotaAvailable(cCtx *cli.Context, preflightCtx *utils.PreflightContext) error {
+	return checkQuotaAvailableFor(cCtx, preflightCtx, 1)
+}
+
+// checkQuotaAvailableFor verifies that the user's quota has room for the given number of new apps
+func checkQuotaAvailableFor(cCtx *cli.Context, preflightCtx *utils.PreflightContext, newApps int) error {
 	ctx := cCtx.Context
 
 	// Check user's quota limit from contract
@@ -159,7 +381,7 @@ func checkQuotaAvailable(cCtx *cli.Context, preflightCtx *utils.PreflightContext
 
 	// If quota is 0, user needs to subscribe
 	if maxQuota == 0 {
-		return fmt.Errorf("no app quota available. Run 'eigenx billing subscribe' to enable app deployment")
+		return utils.SubscriptionRequiredError(cCtx, preflightCtx.EnvironmentConfig.Name)
 	}
 
 	// Check current active app count from contract
@@ -172,6 +394,97 @@ func checkQuotaAvailable(cCtx *cli.Context, preflightCtx *utils.PreflightContext
 	if activeCount >= maxQuota {
 		return fmt.Errorf("app quota reached for %s (%d/%d). Please contact the Eigen team at eigencloud_support@eigenlabs.org for additional capacity", preflightCtx.EnvironmentConfig.Name, activeCount, maxQuota)
 	}
+	if newApps > 1 && activeCount+uint32(newApps) > maxQuota {
+		return fmt.Errorf("app quota for %s has room for %d more app(s), not %d (%d/%d). Please contact the Eigen team at eigencloud_support@eigenlabs.org for additional capacity", preflightCtx.EnvironmentConfig.Name, maxQuota-activeCount, newApps, activeCount, maxQuota)
+	}
+
+	return nil
+}
+
+// diffOnly compares the configuration a deploy would submit with the latest release of an
+// existing app, returning an error if they differ. Nothing is built, pushed or sent, so the
+// image, whose digest is only known after building, is not compared.
+func diffOnly(cCtx *cli.Context, preflightCtx *utils.PreflightContext, nameOrID string) error {
+	appID, err := utils.ResolveAppIDOrName(cCtx, nameOrID)
+	if err != nil {
+		return fmt.Errorf("failed to resolve --%s app: %w", common.DiffOnlyFlag.Name, err)
+	}
+
+	deployed, err := preflightCtx.Caller.GetLatestRelease(cCtx.Context, appID)
+	if err != nil {
+		return fmt.Errorf("failed to get deployed release: %w", err)
+	}
+
+	envFilePath, err := utils.GetEnvFileInteractive(cCtx)
+	if err != nil {
+		return fmt.Errorf("failed to get env file path: %w", err)
+	}
+	instanceType, err := utils.GetInstanceTypeInteractive(cCtx, "")
+	if err != nil {
+		return fmt.Errorf("failed to get instance: %w", err)
+	}
+
+	publicEnv, privateEnv, err := utils.ParseEnvFromContext(cCtx, envFilePath)
+	if err != nil {
+		return err
+	}
+	publicEnv[common.EigenMachineTypeEnvVar] = instanceType
 
+	changed, err := utils.DiffDeployedRelease(cCtx, appID.Hex(), deployed, "", publicEnv, privateEnv)
+	if err != nil {
+		return err
+	}
+	if changed {
+		return fmt.Errorf("local configuration differs from the deployed release")
+	}
 	return nil
 }
+
+// deployFromManifest deploys a new app from an 'app export' manifest. The pinned image, public
+// env, instance type and log visibility come from the manifest, the private env from a file.
+func deployFromManifest(cCtx *cli.Context, preflightCtx *utils.PreflightContext, manifestPath string) error {
+	logger := common.LoggerFromContext(cCtx)
+	environment := preflightCtx.EnvironmentConfig.Name
+
+	if cCtx.Args().Len() > 0 {
+		return fmt.Errorf("an image reference cannot be combined with --%s", common.AppManifestFlag.Name)
+	}
+
+	manifest, err := utils.ReadAppManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+	if manifest.Environment != environment {
+		return fmt.Errorf("manifest %s is for environment %s, not %s. Pass --environment %s", manifestPath, manifest.Environment, environment, manifest.Environment)
+	}
+
+	image, err := manifest.Release()
+	if err != nil {
+		return err
+	}
+	logger.Info("Deploying from manifest %s", manifestPath)
+	logger.Info("Image: %s", image.ImageRef())
+
+	// Reuse the exported name while it is free
+	appName := ""
+	if manifest.Name != "" {
+		if utils.IsAppNameAvailable(environment, manifest.Name) {
+			appName = manifest.Name
+		} else {
+			logger.Warn("App name '%s' from the manifest is already taken, the new app is left unnamed", manifest.Name)
+		}
+	}
+
+	// Private env is encrypted per app and is never part of a manifest
+	privateEnvFilePath, err := getClonePrivateEnvFile(cCtx)
+	if err != nil {
+		return err
+	}
+
+	publicEnv, privateEnv, err := utils.ParseEnvWithPublicBase(cCtx, manifest.PublicEnv, manifestPath, privateEnvFilePath)
+	if err != nil {
+		return err
+	}
+
+	return deployImage(cCtx, preflightCtx, image, publicEnv, privateEnv, manifest.InstanceType, manifest.PublicLogs, appName)
+}
@@ -0,0 +1,234 @@
+package app
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/commands/utils"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	kmstypes "github.com/Layr-Labs/eigenx-kms/pkg/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+// batchDeployment is a batch manifest app with everything resolved before its image is built
+type batchDeployment struct {
+	app        utils.BatchApp
+	release    common.AppRelease
+	publicEnv  kmstypes.Env
+	privateEnv kmstypes.Env
+}
+
+// deployBatch builds and pushes the images of every app in a batch manifest concurrently, then
+// creates and upgrades all of them in a single transaction and waits for them to start. Anything
+// that can prompt runs before the builds start, and nothing is submitted unless every image was
+// pushed.
+func deployBatch(cCtx *cli.Context, preflightCtx *utils.PreflightContext, manifestPath string) error {
+	logger := common.LoggerFromContext(cCtx)
+	environment := preflightCtx.EnvironmentConfig.Name
+
+	if cCtx.Args().Len() > 0 {
+		return fmt.Errorf("an image reference cannot be combined with --%s", common.BatchManifestFlag.Name)
+	}
+	for _, flag := range []string{common.FileFlag.Name, common.EnvFlag.Name, common.PublicEnvFileFlag.Name, common.PrivateEnvFileFlag.Name, common.SaltFlag.Name, common.BuildArgFlag.Name, common.DockerfileTargetFlag.Name} {
+		if cCtx.IsSet(flag) {
+			return fmt.Errorf("--%s cannot be combined with --%s; set it per app in the manifest", flag, common.BatchManifestFlag.Name)
+		}
+	}
+	if cCtx.Bool(common.VerifySignatureFlag.Name) {
+		return fmt.Errorf("--%s verifies a published image and cannot be combined with --%s, whose images are built from Dockerfiles", common.VerifySignatureFlag.Name, common.BatchManifestFlag.Name)
+	}
+
+	manifest, err := utils.ReadBatchManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+	if manifest.Environment != environment {
+		return fmt.Errorf("manifest %s is for environment %s, not %s. Pass --environment %s", manifestPath, manifest.Environment, environment, manifest.Environment)
+	}
+	logger.Info("Deploying %d app(s) from batch manifest %s", len(manifest.Apps), manifestPath)
+
+	deployments := make([]batchDeployment, len(manifest.Apps))
+	upgraded := map[ethcommon.Address]string{}
+	newApps := 0
+	for i, app := range manifest.Apps {
+		deployment, err := prepareBatchApp(cCtx, preflightCtx, app)
+		if err != nil {
+			return err
+		}
+		if !deployment.release.Create {
+			if other, ok := upgraded[deployment.release.AppID]; ok {
+				return fmt.Errorf("apps %s and %s both upgrade %s", other, app.Name, deployment.release.AppID.Hex())
+			}
+			upgraded[deployment.release.AppID] = app.Name
+		} else {
+			newApps++
+		}
+		deployments[i] = deployment
+	}
+
+	if newApps > 0 {
+		if err := checkQuotaAvailableFor(cCtx, preflightCtx, newApps); err != nil {
+			return err
+		}
+	}
+
+	if err := common.EnsureDockerIsRunning(cCtx); err != nil {
+		return err
+	}
+
+	// Build every image before submitting so a failed push leaves all apps untouched
+	images, err := utils.BuildBatchImages(cCtx, preflightCtx.EnvironmentConfig, manifest.Apps, cCtx.Int(common.ParallelFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	releases := make([]common.AppRelease, len(deployments))
+	for i, deployment := range deployments {
+		release, err := utils.NewReleaseForImage(cCtx, preflightCtx.EnvironmentConfig, deployment.release.AppID, images[i].Digest, images[i].Registry, deployment.publicEnv, deployment.privateEnv, deployment.app.InstanceType)
+		if err != nil {
+			return fmt.Errorf("app %s: %w", deployment.app.Name, err)
+		}
+		deployments[i].release.Release = release
+		releases[i] = deployments[i].release
+	}
+
+	utils.ReportStage(cCtx, utils.StageSubmitTx, 0, "Submitting batch release transaction")
+	txHash, err := preflightCtx.Caller.ReleaseApps(cCtx.Context, releases)
+	if err != nil {
+		return fmt.Errorf("failed to release apps: %w", err)
+	}
+	utils.ReportStage(cCtx, utils.StageSubmitTx, 100, "Batch release transaction confirmed")
+	utils.RecordTxHash(cCtx, txHash)
+
+	for i, deployment := range deployments {
+		appID := deployment.release.AppID
+		if deployment.release.Create {
+			nameBatchApp(cCtx, environment, appID, deployment.app.Name)
+			logger.Info("Deployed %s: %s (%s)", deployment.app.Name, appID.Hex(), images[i].ImageRef)
+		} else {
+			logger.Info("Upgraded %s: %s (%s)", deployment.app.Name, appID.Hex(), images[i].ImageRef)
+		}
+	}
+
+	// Watch until every app is running, unless --wait=false
+	if utils.SkipWatch(cCtx) {
+		logger.Info("Not waiting for the apps to start. Check the status of each app with: eigenx app info <app-id>")
+		return nil
+	}
+	return watchBatch(cCtx, deployments)
+}
+
+// watchBatch waits for every app of a batch to start and, with --verify-running, to answer
+// requests. The apps are watched concurrently with quiet output and the result of each app is
+// logged as it finishes. The error names every app that failed.
+func watchBatch(cCtx *cli.Context, deployments []batchDeployment) error {
+	logger := common.LoggerFromContext(cCtx)
+	watchCtx := utils.QuietChildContext(cCtx)
+
+	logger.Info("Waiting for %d app(s) to start...", len(deployments))
+	errs := common.ParallelEach(len(deployments), len(deployments), func(i int) error {
+		deployment := deployments[i]
+		appID := deployment.release.AppID
+
+		status := common.AppStatusUpgrading
+		if deployment.release.Create {
+			status = common.AppStatusDeploying
+		}
+		err := utils.WatchUntilTransitionComplete(watchCtx, appID, status)
+		if err == nil {
+			env := map[string]string{}
+			maps.Copy(env, deployment.publicEnv)
+			maps.Copy(env, deployment.privateEnv)
+			err = utils.VerifyAppServingWithEnv(watchCtx, appID, env)
+		}
+		if err != nil {
+			logger.Error("✗ %s: %v", deployment.app.Name, err)
+			return err
+		}
+		logger.Info("✓ %s: %s is running", deployment.app.Name, appID.Hex())
+		return nil
+	})
+
+	var failed []string
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, deployments[i].app.Name)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d app(s) failed to start: %s", len(failed), len(deployments), strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// prepareBatchApp resolves the app a batch entry upgrades, or the address of the app it creates,
+// and reads its env. These steps may prompt, so they run before the concurrent builds.
+func prepareBatchApp(cCtx *cli.Context, preflightCtx *utils.PreflightContext, app utils.BatchApp) (batchDeployment, error) {
+	logger := common.LoggerFromContext(cCtx)
+
+	_, publicLogs, err := app.LogSettings()
+	if err != nil {
+		return batchDeployment{}, fmt.Errorf("app %s: %w", app.Name, err)
+	}
+
+	release := common.AppRelease{PublicLogs: publicLogs}
+	if app.App != "" {
+		release.AppID, err = utils.ResolveAppIDOrName(cCtx, app.App)
+		if err != nil {
+			return batchDeployment{}, fmt.Errorf("app %s: failed to resolve %s: %w", app.Name, app.App, err)
+		}
+		currentlyPublic, err := utils.CheckAppLogPermission(cCtx, release.AppID)
+		if err != nil {
+			return batchDeployment{}, fmt.Errorf("app %s: failed to check current permission state: %w", app.Name, err)
+		}
+		release.PermissionChange = currentlyPublic != publicLogs
+		logger.Info("%s: upgrading app %s", app.Name, release.AppID.Hex())
+	} else {
+		release.Create = true
+		release.Salt, err = utils.DeploySalt(cCtx)
+		if err != nil {
+			return batchDeployment{}, err
+		}
+		_, appController, err := utils.GetAppControllerBinding(cCtx)
+		if err != nil {
+			return batchDeployment{}, fmt.Errorf("failed to get app controller binding: %w", err)
+		}
+		rpcCtx, cancel := utils.RPCContext(cCtx)
+		release.AppID, err = appController.CalculateAppId(&bind.CallOpts{Context: rpcCtx}, preflightCtx.Caller.SelfAddress, release.Salt)
+		cancel()
+		if err != nil {
+			return batchDeployment{}, fmt.Errorf("app %s: failed to get app id: %w", app.Name, err)
+		}
+		logger.Info("%s: deploying new app %s", app.Name, release.AppID.Hex())
+	}
+
+	if _, err := utils.CheckGitWorkingTree(cCtx, preflightCtx.EnvironmentConfig, app.Dir); err != nil {
+		return batchDeployment{}, fmt.Errorf("app %s: %w", app.Name, err)
+	}
+	if err := utils.CheckBuildContextSize(cCtx, app.Dir, app.Dockerfile); err != nil {
+		return batchDeployment{}, fmt.Errorf("app %s: %w", app.Name, err)
+	}
+	utils.CheckRegistryPushScope(cCtx, app.Image)
+
+	publicEnv, privateEnv, err := utils.ParseBatchAppEnv(cCtx, app)
+	if err != nil {
+		return batchDeployment{}, err
+	}
+	return batchDeployment{app: app, release: release, publicEnv: publicEnv, privateEnv: privateEnv}, nil
+}
+
+// nameBatchApp gives a new app its manifest name while the name is free
+func nameBatchApp(cCtx *cli.Context, environment string, appID ethcommon.Address, name string) {
+	logger := common.LoggerFromContext(cCtx)
+
+	if !utils.IsAppNameAvailable(environment, name) {
+		logger.Warn("App name '%s' is already taken, app %s is left unnamed", name, appID.Hex())
+		return
+	}
+	if err := common.SetAppName(environment, appID.Hex(), name); err != nil {
+		logger.Warn("Failed to name app '%s': %s", name, err.Error())
+	}
+}
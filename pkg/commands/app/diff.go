@@ -0,0 +1,122 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/commands/utils"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/urfave/cli/v2"
+)
+
+var DiffCommand = &cli.Command{
+	Name:      "diff",
+	Usage:     "Show what an upgrade would change compared to the deployed release, without submitting it",
+	ArgsUsage: "<app-id|name> [image_ref]",
+	Flags: append(common.GlobalFlags, []cli.Flag{
+		common.EnvironmentFlag,
+		common.RpcUrlFlag,
+		common.PrivateKeyFlag,
+		common.EnvFlag,
+		common.EnvPrefixFlag,
+		common.PublicEnvFileFlag,
+		common.PrivateEnvFileFlag,
+		common.FileFlag,
+		common.LogVisibilityFlag,
+		common.InstanceTypeFlag,
+		common.TLSFlag,
+		common.NoTLSFlag,
+		common.TLSEmailFlag,
+		common.TLSStagingFlag,
+		common.FailOnPlatformMismatchFlag,
+		common.PlatformFlag,
+		common.BuildTimeoutFlag,
+		common.KeepBaseImageFlag,
+		common.EnvCheckEntropyFlag,
+		common.ShowValuesFlag,
+		common.EnvEntropyThresholdFlag,
+		common.BuildSecretFlag,
+		common.BuildArgFlag,
+		common.DockerfileTargetFlag,
+		common.RegistryAuthFileFlag,
+		common.RegistryCACertFlag,
+	}...),
+	Action: diffAction,
+}
+
+func diffAction(cCtx *cli.Context) error {
+	if err := utils.ValidateTLSFlags(cCtx); err != nil {
+		return err
+	}
+	if err := utils.ApplyPlatformFlag(cCtx); err != nil {
+		return err
+	}
+	if err := utils.ValidateEnvFileFlags(cCtx); err != nil {
+		return err
+	}
+	if err := utils.ValidateRegistryAuthFile(cCtx); err != nil {
+		return err
+	}
+	if err := utils.ValidateRegistryCACert(cCtx); err != nil {
+		return err
+	}
+
+	// 1. Do preflight checks (auth, network, etc.) first
+	preflightCtx, err := utils.DoPreflightChecks(cCtx)
+	if err != nil {
+		return err
+	}
+
+	// 2. Get app ID from args or interactive selection
+	appID, err := utils.GetAppIDInteractive(cCtx, 0, "diff")
+	if err != nil {
+		return fmt.Errorf("failed to get app id: %w", err)
+	}
+
+	deployed, err := preflightCtx.Caller.GetLatestRelease(cCtx.Context, appID)
+	if err != nil {
+		return fmt.Errorf("failed to get deployed release: %w", err)
+	}
+
+	// 3. Check if docker is running, else try to start it
+	err = common.EnsureDockerIsRunning(cCtx)
+	if err != nil {
+		return err
+	}
+
+	// 4. Collect the same inputs an upgrade would use
+	dockerfilePath, err := utils.GetDockerfileInteractive(cCtx)
+	if err != nil {
+		return fmt.Errorf("failed to get dockerfile path: %w", err)
+	}
+
+	imageRef, err := utils.GetImageReferenceInteractive(cCtx, 1, dockerfilePath != "")
+	if err != nil {
+		return fmt.Errorf("failed to get image reference: %w", err)
+	}
+
+	envFilePath, err := utils.GetEnvFileInteractive(cCtx)
+	if err != nil {
+		return fmt.Errorf("failed to get env file path: %w", err)
+	}
+
+	// Keep the current instance type unless a new one is requested, as upgrade does
+	currentInstanceType := getCurrentInstanceType(cCtx, appID)
+	instanceType, err := utils.GetInstanceTypeInteractive(cCtx, currentInstanceType)
+	if err != nil {
+		return fmt.Errorf("failed to get instance: %w", err)
+	}
+
+	logRedirect, _, err := utils.GetLogSettingsInteractive(cCtx)
+	if err != nil {
+		return fmt.Errorf("failed to get log settings: %w", err)
+	}
+
+	// 5. Prepare the release (builds and pushes the image to learn its digest) without submitting it
+	release, privateEnv, _, err := utils.PrepareReleaseFromContext(cCtx, preflightCtx.EnvironmentConfig, appID, dockerfilePath, imageRef, envFilePath, logRedirect, instanceType, 3)
+	if err != nil {
+		return err
+	}
+
+	_, err = utils.DiffPreparedRelease(cCtx, appID.Hex(), deployed, release, privateEnv)
+	return err
+}
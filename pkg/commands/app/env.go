@@ -0,0 +1,186 @@
+package app
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/commands/utils"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/urfave/cli/v2"
+)
+
+var EnvCommand = &cli.Command{
+	Name:  "env",
+	Usage: "View or change individual environment variables of an app",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "get",
+			Usage:     "Print the public env of the deployed release and the names of local private variables",
+			ArgsUsage: "<app-id|name> [KEY]",
+			Flags: append(common.GlobalFlags, []cli.Flag{
+				common.EnvironmentFlag,
+				common.RpcUrlFlag,
+				common.PrivateKeyFlag,
+				common.EnvFlag,
+				common.PrivateEnvFileFlag,
+			}...),
+			Action: envGetAction,
+		},
+		{
+			Name:      "set",
+			Usage:     "Set variables and upgrade the app with its current image",
+			ArgsUsage: "<app-id|name> KEY=VALUE...",
+			Flags: append(common.GlobalFlags, []cli.Flag{
+				common.EnvironmentFlag,
+				common.RpcUrlFlag,
+				common.PrivateKeyFlag,
+				common.EnvFlag,
+				common.PrivateEnvFileFlag,
+				&cli.BoolFlag{
+					Name:  "public",
+					Usage: "Store the variables unencrypted in the public env instead of the private env",
+				},
+				common.EnvCheckEntropyFlag,
+				common.ShowValuesFlag,
+				common.EnvEntropyThresholdFlag,
+				common.ForceFlagWithUsage("Replace the deployed private env even when no local env file is found"),
+				common.WaitIntervalBackoffFlag,
+				common.ProgressFormatFlag,
+			}...),
+			Action: envSetAction,
+		},
+	},
+}
+
+func envGetAction(cCtx *cli.Context) error {
+	if cCtx.NArg() < 1 {
+		return fmt.Errorf("please provide an app ID or name")
+	}
+
+	preflightCtx, err := utils.DoPreflightChecks(cCtx)
+	if err != nil {
+		return err
+	}
+
+	appID, err := utils.ResolveAppIDOrName(cCtx, cCtx.Args().Get(0))
+	if err != nil {
+		return fmt.Errorf("failed to resolve app: %w", err)
+	}
+
+	deployed, err := utils.GetDeployedRelease(cCtx.Context, preflightCtx.Caller, appID)
+	if err != nil {
+		return err
+	}
+
+	// The deployed private env is encrypted; only the local definitions can be named
+	private, source, err := utils.LocalPrivateEnv(cCtx)
+	if err != nil {
+		return err
+	}
+
+	if key := cCtx.Args().Get(1); key != "" {
+		if value, ok := deployed.PublicEnv[key]; ok {
+			fmt.Println(value)
+			return nil
+		}
+		if _, ok := private[key]; ok {
+			return fmt.Errorf("%s is private: its deployed value is encrypted and cannot be shown", key)
+		}
+		return fmt.Errorf("%s is not in the public env of %s or in the local private env", key, appID.Hex())
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(deployed.PublicEnv)) {
+		fmt.Printf("%s=%s\n", key, deployed.PublicEnv[key])
+	}
+
+	if len(private) > 0 {
+		fmt.Println()
+		fmt.Printf("Private (encrypted, names from %s):\n", source)
+		for _, key := range slices.Sorted(maps.Keys(private)) {
+			fmt.Printf("  %s\n", key)
+		}
+	}
+	return nil
+}
+
+func envSetAction(cCtx *cli.Context) error {
+	if cCtx.NArg() < 2 {
+		return fmt.Errorf("please provide an app ID or name and at least one KEY=VALUE")
+	}
+	if err := utils.ApplyProgressFormat(cCtx); err != nil {
+		return err
+	}
+
+	assignments, err := utils.ParseEnvAssignments(cCtx.Args().Slice()[1:])
+	if err != nil {
+		return err
+	}
+	if _, ok := assignments[common.EigenMachineTypeEnvVar]; ok {
+		return fmt.Errorf("%s is managed by eigenx, use 'eigenx app upgrade --instance-type' to change it", common.EigenMachineTypeEnvVar)
+	}
+
+	// 1. Do preflight checks (auth, network, etc.) first
+	preflightCtx, err := utils.DoPreflightChecks(cCtx)
+	if err != nil {
+		return err
+	}
+
+	appID, err := utils.ResolveAppIDOrName(cCtx, cCtx.Args().Get(0))
+	if err != nil {
+		return fmt.Errorf("failed to resolve app: %w", err)
+	}
+
+	// 2. Read the current release, keeping its image and instance type
+	deployed, err := utils.GetDeployedRelease(cCtx.Context, preflightCtx.Caller, appID)
+	if err != nil {
+		return err
+	}
+	instanceType := deployed.PublicEnv[common.EigenMachineTypeEnvVar]
+	if instanceType == "" {
+		instanceType = getCurrentInstanceType(cCtx, appID)
+	}
+	if instanceType == "" {
+		return fmt.Errorf("failed to determine the instance type of %s", appID.Hex())
+	}
+	delete(deployed.PublicEnv, common.EigenMachineTypeEnvVar)
+
+	// 3. The private env is replaced as a whole, so it is rebuilt from the local definitions
+	private, source, err := utils.LocalPrivateEnv(cCtx)
+	if err != nil {
+		return err
+	}
+	if source == "" {
+		if err := utils.ConfirmPrivateEnvReplacement(cCtx); err != nil {
+			return err
+		}
+		source = "no private env file"
+	}
+
+	publicEnv, privateEnv, err := utils.ApplyEnvAssignments(cCtx, deployed.PublicEnv, private, assignments, cCtx.Bool("public"), fmt.Sprintf("public: deployed release, private: %s", source))
+	if err != nil {
+		return err
+	}
+
+	// 4. Build the release for the unchanged image
+	release, err := utils.NewReleaseForImage(cCtx, preflightCtx.EnvironmentConfig, appID, deployed.Digest, deployed.Registry, publicEnv, privateEnv, instanceType)
+	if err != nil {
+		return err
+	}
+
+	publicLogs, err := utils.CheckAppLogPermission(cCtx, appID)
+	if err != nil {
+		return fmt.Errorf("failed to check current permission state: %w", err)
+	}
+
+	// 5. Upgrade the app, leaving log visibility unchanged
+	utils.ReportStage(cCtx, utils.StageSubmitTx, 0, "Submitting upgrade transaction")
+	_, err = preflightCtx.Caller.UpgradeApp(cCtx.Context, appID, release, publicLogs, false, deployed.ImageRef())
+	if err != nil {
+		return fmt.Errorf("failed to upgrade app: %w", err)
+	}
+	utils.ReportStage(cCtx, utils.StageSubmitTx, 100, "Upgrade transaction confirmed")
+
+	// 6. Watch until upgrade completes
+	return utils.WatchUntilTransitionComplete(cCtx, appID, common.AppStatusUpgrading)
+}
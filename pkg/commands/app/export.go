@@ -0,0 +1,74 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/commands/utils"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/urfave/cli/v2"
+)
+
+var ExportCommand = &cli.Command{
+	Name:      "export",
+	Usage:     "Write an app's image digest, public env, instance type and log visibility to a manifest for 'app deploy --manifest'",
+	ArgsUsage: "[app-id|name]",
+	Flags: append(common.GlobalFlags, []cli.Flag{
+		common.EnvironmentFlag,
+		common.RpcUrlFlag,
+		common.PrivateKeyFlag,
+		&cli.StringFlag{
+			Name:  "out",
+			Usage: "Path to write the manifest to, e.g. app.yaml (default: stdout)",
+		},
+	}...),
+	Action: exportAction,
+}
+
+func exportAction(cCtx *cli.Context) error {
+	logger := common.LoggerFromContext(cCtx)
+
+	// 1. Do preflight checks (auth, network, etc.) first
+	preflightCtx, err := utils.DoPreflightChecks(cCtx)
+	if err != nil {
+		return err
+	}
+	environment := preflightCtx.EnvironmentConfig.Name
+
+	// 2. Get app ID from args or interactive selection
+	appID, err := utils.GetAppIDInteractive(cCtx, 0, "export")
+	if err != nil {
+		return fmt.Errorf("failed to get app id: %w", err)
+	}
+
+	// 3. Read the deployed release and settings
+	deployed, err := utils.GetDeployedRelease(cCtx.Context, preflightCtx.Caller, appID)
+	if err != nil {
+		return err
+	}
+
+	publicLogs, err := utils.CheckAppLogPermission(cCtx, appID)
+	if err != nil {
+		return fmt.Errorf("failed to check app log visibility: %w", err)
+	}
+
+	instanceType := deployed.PublicEnv[common.EigenMachineTypeEnvVar]
+	if instanceType == "" {
+		instanceType = getCurrentInstanceType(cCtx, appID)
+	}
+	if instanceType == "" {
+		return fmt.Errorf("failed to determine the instance type of app %s", appID.Hex())
+	}
+
+	// 4. Write the manifest
+	manifest := utils.NewAppManifest(environment, appID, common.GetAppName(environment, appID.Hex()), deployed, instanceType, publicLogs)
+
+	outPath := cCtx.String("out")
+	if err := utils.WriteAppManifest(manifest, outPath); err != nil {
+		return err
+	}
+	if outPath != "" {
+		logger.Info("Manifest for %s written to %s", common.FormatAppDisplay(environment, appID, ""), outPath)
+		logger.Info("Private env is not exported. Deploy it with: eigenx app deploy --manifest %s --private-env-file <file>", outPath)
+	}
+	return nil
+}
@@ -1,15 +1,19 @@
 package app
 
 import (
+	"encoding/json"
 	"fmt"
-	"math/big"
+	"regexp"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/Layr-Labs/eigenx-cli/pkg/commands/utils"
 	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common/progress"
 	"github.com/Layr-Labs/eigenx-contracts/pkg/bindings/v1/AppController"
-	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/fatih/color"
 	"github.com/urfave/cli/v2"
 )
 
@@ -22,6 +26,12 @@ var ListCommand = &cli.Command{
 		common.PrivateKeyFlag,
 		common.AllFlag,
 		common.AddressCountFlag,
+		common.AddressChainsFlag,
+		&cli.StringSliceFlag{
+			Name:  "status",
+			Usage: "Only list apps in these statuses, e.g. running,stopped (repeatable or comma-separated; terminated or terminating includes terminated apps as with --all)",
+		},
+		common.JSONFlag,
 	}...),
 	Action: listAction,
 }
@@ -34,7 +44,13 @@ var InfoCommand = &cli.Command{
 		common.EnvironmentFlag,
 		common.RpcUrlFlag,
 		common.AddressCountFlag,
+		common.AddressChainsFlag,
 		common.WatchFlag,
+		common.PollIntervalFlag,
+		&cli.DurationFlag{
+			Name:  "refresh-interval",
+			Usage: "Show a live dashboard of the app's info and recent logs, refreshed at this interval (e.g. 5s) until Ctrl+C; same as --watch --poll-interval",
+		},
 	}...),
 	Action: infoAction,
 }
@@ -47,13 +63,57 @@ var LogsCommand = &cli.Command{
 		common.EnvironmentFlag,
 		common.RpcUrlFlag,
 		common.WatchFlag,
+		common.PollIntervalFlag,
+		&cli.BoolFlag{
+			Name:  "raw",
+			Usage: "Print logs unmodified instead of escaping non-printable and invalid UTF-8 bytes",
+		},
+		&cli.StringFlag{
+			Name:  "until-match",
+			Usage: "With --watch, exit 0 once a new log line matches this regular expression, e.g. 'Server started'",
+		},
+		&cli.StringFlag{
+			Name:  "fail-match",
+			Usage: "With --watch, exit with an error once a new log line matches this regular expression, e.g. 'FATAL|panic'",
+		},
+		&cli.DurationFlag{
+			Name:  "idle-timeout",
+			Usage: "With --watch, exit with an error when no new log lines arrive for this long, e.g. 5m",
+		},
+		&cli.BoolFlag{
+			Name:  "timestamps",
+			Usage: "Prefix each printed line with the local time the CLI received it (not the container's own timestamp)",
+		},
 	}...),
+	Subcommands: []*cli.Command{
+		LogsSetVisibilityCommand,
+	},
 	Action: logsAction,
 }
 
+// listedApp is one entry of 'app list --json'
+type listedApp struct {
+	AppID           string   `json:"appId"`
+	Name            string   `json:"name,omitempty"`
+	Status          string   `json:"status"`
+	IP              string   `json:"ip,omitempty"`
+	MachineType     string   `json:"machineType,omitempty"`
+	EVMAddresses    []string `json:"evmAddresses,omitempty"`
+	SolanaAddresses []string `json:"solanaAddresses,omitempty"`
+}
+
 func listAction(cCtx *cli.Context) error {
-	ctx := cCtx.Context
 	logger := common.LoggerFromContext(cCtx)
+	jsonOutput := cCtx.Bool(common.JSONFlag.Name)
+	statusFilter, err := parseStatusFilter(cCtx.StringSlice("status"))
+	if err != nil {
+		return err
+	}
+
+	chains, err := utils.AddressChainsFromContext(cCtx)
+	if err != nil {
+		return err
+	}
 
 	// Get contract caller from context
 	client, appController, err := utils.GetAppControllerBinding(cCtx)
@@ -66,24 +126,29 @@ func listAction(cCtx *cli.Context) error {
 		return fmt.Errorf("failed to get developer address: %w", err)
 	}
 
-	// List apps with pagination (start with first 50)
-	result, err := appController.GetAppsByDeveloper(&bind.CallOpts{Context: ctx}, developerAddr, big.NewInt(0), big.NewInt(50))
+	allApps, allConfigs, err := utils.GetAllAppsByDeveloper(cCtx, appController, developerAddr)
 	if err != nil {
-		return fmt.Errorf("failed to list apps: %w", err)
+		return err
 	}
 
-	if len(result.Apps) == 0 {
+	if len(allApps) == 0 {
+		if jsonOutput {
+			return printListedApps(nil)
+		}
 		logger.Info("No apps found for developer %s", developerAddr.Hex())
 		return nil
 	}
 
-	showAll := cCtx.Bool(common.AllFlag.Name)
+	// Terminated and terminating apps are only listed with --all, so asking for them implies it
+	showAll := cCtx.Bool(common.AllFlag.Name) ||
+		slices.Contains(statusFilter, strings.ToLower(common.AppStatusTerminated)) ||
+		slices.Contains(statusFilter, strings.ToLower(common.AppStatusTerminating))
 	var filteredApps []ethcommon.Address
 	var filteredConfigs []AppController.IAppControllerAppConfig
 
 	// Filter out terminated apps unless --all flag is used
-	for i, appAddr := range result.Apps {
-		config := result.AppConfigsMem[i]
+	for i, appAddr := range allApps {
+		config := allConfigs[i]
 		if !showAll && common.AppStatus(config.Status) == common.ContractAppStatusTerminated {
 			continue
 		}
@@ -92,6 +157,9 @@ func listAction(cCtx *cli.Context) error {
 	}
 
 	if len(filteredApps) == 0 {
+		if jsonOutput {
+			return printListedApps(nil)
+		}
 		if showAll {
 			logger.Info("No apps found for developer %s", developerAddr.Hex())
 		} else {
@@ -116,7 +184,7 @@ func listAction(cCtx *cli.Context) error {
 		count = 1
 	}
 
-	infos, err := userApiClient.GetInfos(cCtx, filteredApps, count)
+	infos, err := userApiClient.GetInfos(cCtx, filteredApps, count, chains)
 	if err != nil {
 		return fmt.Errorf("failed to get info: %w", err)
 	}
@@ -125,12 +193,35 @@ func listAction(cCtx *cli.Context) error {
 		return fmt.Errorf("expected %d app infos but got %d", len(filteredApps), len(infos.Apps))
 	}
 
-	for i, appAddr := range filteredApps {
-		err = utils.PrintAppInfo(ctx, logger, client, appAddr, filteredConfigs[i], infos.Apps[i], environmentConfig.Name)
+	// Keep only the apps whose reconciled status was asked for
+	var selected []int
+	for i := range filteredApps {
+		if matchesStatusFilter(utils.DisplayStatus(filteredConfigs[i], infos.Apps[i]), statusFilter) {
+			selected = append(selected, i)
+		}
+	}
+
+	if jsonOutput {
+		apps := make([]listedApp, 0, len(selected))
+		for _, i := range selected {
+			apps = append(apps, newListedApp(environmentConfig.Name, filteredApps[i], filteredConfigs[i], infos.Apps[i]))
+		}
+		return printListedApps(apps)
+	}
+
+	if len(selected) == 0 {
+		logger.Info("No apps with status %s found for developer %s", strings.Join(cCtx.StringSlice("status"), ", "), developerAddr.Hex())
+		return nil
+	}
+
+	for n, i := range selected {
+		rpcCtx, cancel := utils.RPCContext(cCtx)
+		err = utils.PrintAppInfo(rpcCtx, logger, client, filteredApps[i], filteredConfigs[i], infos.Apps[i], environmentConfig.Name)
+		cancel()
 		if err != nil {
 			return fmt.Errorf("failed to print app info: %w", err)
 		}
-		if i < len(filteredApps)-1 {
+		if n < len(selected)-1 {
 			fmt.Println("----------------------------------------------------------------------")
 		}
 	}
@@ -138,7 +229,79 @@ func listAction(cCtx *cli.Context) error {
 	return nil
 }
 
+// listStatuses are the statuses 'app list' shows, lowercased as --status values are
+var listStatuses = func() []string {
+	statuses := make([]string, len(common.AppDisplayStatuses))
+	for i, status := range common.AppDisplayStatuses {
+		statuses[i] = strings.ToLower(status)
+	}
+	return statuses
+}()
+
+// parseStatusFilter splits and lowercases --status values, dropping empty entries, and rejects
+// statuses no app can have
+func parseStatusFilter(values []string) ([]string, error) {
+	var filter []string
+	for _, value := range values {
+		for _, status := range strings.Split(value, ",") {
+			status = strings.ToLower(strings.TrimSpace(status))
+			if status == "" {
+				continue
+			}
+			if !slices.Contains(listStatuses, status) {
+				return nil, fmt.Errorf("invalid --status value %q (valid values: %s)", status, strings.Join(listStatuses, ", "))
+			}
+			filter = append(filter, status)
+		}
+	}
+	return filter, nil
+}
+
+// matchesStatusFilter reports whether status is in filter; an empty filter matches every status
+func matchesStatusFilter(status string, filter []string) bool {
+	return len(filter) == 0 || slices.Contains(filter, strings.ToLower(status))
+}
+
+// newListedApp collects the fields of one app for 'app list --json'
+func newListedApp(environment string, appID ethcommon.Address, config AppController.IAppControllerAppConfig, info utils.AppInfo) listedApp {
+	app := listedApp{
+		AppID:       appID.Hex(),
+		Name:        common.GetAppName(environment, appID.Hex()),
+		Status:      utils.DisplayStatus(config, info),
+		IP:          info.Ip,
+		MachineType: info.MachineType,
+	}
+	if info.Profile != nil && info.Profile.Name != "" {
+		app.Name = info.Profile.Name
+	}
+	for _, addr := range info.EVMAddresses {
+		app.EVMAddresses = append(app.EVMAddresses, addr.Address.Hex())
+	}
+	for _, addr := range info.SolanaAddresses {
+		app.SolanaAddresses = append(app.SolanaAddresses, addr.Address)
+	}
+	return app
+}
+
+// printListedApps prints apps as a JSON array, which is empty rather than null with no apps
+func printListedApps(apps []listedApp) error {
+	if apps == nil {
+		apps = []listedApp{}
+	}
+	encoded, err := json.MarshalIndent(apps, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	fmt.Println(string(encoded))
+	return nil
+}
+
 func infoAction(cCtx *cli.Context) error {
+	refreshInterval := cCtx.Duration("refresh-interval")
+	if refreshInterval < 0 || (refreshInterval > 0 && refreshInterval < time.Second) {
+		return fmt.Errorf("--refresh-interval must be at least 1s")
+	}
+
 	// Get app address from args or interactive selection
 	appID, err := utils.GetAppIDInteractive(cCtx, 0, "view")
 	if err != nil {
@@ -146,15 +309,28 @@ func infoAction(cCtx *cli.Context) error {
 	}
 
 	// Check if watch mode is enabled
-	if !cCtx.Bool(common.WatchFlag.Name) {
+	if !cCtx.Bool(common.WatchFlag.Name) && refreshInterval == 0 {
 		return utils.GetAndPrintAppInfo(cCtx, appID)
 	}
 
-	// Watch mode: continuously fetch and display info
-	return utils.WatchAppInfoLoop(cCtx, appID, nil, nil)
+	// Watch mode: redraw the info as a live dashboard until interrupted
+	interval := utils.WatchPollInterval(cCtx)
+	if refreshInterval > 0 {
+		interval = int(refreshInterval / time.Second)
+	}
+	return utils.WatchAppInfoDashboard(cCtx, appID, interval)
 }
 
 func logsAction(cCtx *cli.Context) error {
+	watchMode := cCtx.Bool(common.WatchFlag.Name)
+	matcher, err := newLogMatcher(cCtx)
+	if err != nil {
+		return err
+	}
+	if (matcher.active() || cCtx.Duration("idle-timeout") > 0) && !watchMode {
+		return fmt.Errorf("--until-match, --fail-match and --idle-timeout require --watch")
+	}
+
 	fmt.Println()
 	logger := common.LoggerFromContext(cCtx)
 
@@ -177,14 +353,12 @@ func logsAction(cCtx *cli.Context) error {
 	formattedApp := common.FormatAppDisplay(environmentConfig.Name, appID, profileName)
 
 	logs, err := userApiClient.GetLogs(cCtx, appID)
-	watchMode := cCtx.Bool(common.WatchFlag.Name)
-
 	if err != nil || strings.TrimSpace(logs) == "" {
 		// If watch mode is enabled, enter watch loop even without initial logs
 		if watchMode {
 			logger.Info("Waiting for logs to become available...")
 			fmt.Println()
-			return watchLogs(cCtx, appID, userApiClient, "")
+			return watchLogs(cCtx, appID, userApiClient, "", matcher)
 		}
 
 		// Not watch mode - check app status to provide helpful message and exit
@@ -218,70 +392,292 @@ func logsAction(cCtx *cli.Context) error {
 		return fmt.Errorf("failed to get logs, you can watch for logs by calling this command with the --watch flag (or --w): empty logs")
 	}
 
-	fmt.Println(logs)
-
 	// Check if watch mode is enabled
 	if !watchMode {
+		fmt.Println(renderLogs(cCtx, logs))
 		return nil
 	}
 
+	// Watch mode: show complete lines only, a trailing partial line is shown once it is finished.
+	// The backlog is not matched, so a line logged before the watch started can't end it.
+	emitted := completeLogLines(logs)
+	fmt.Println(renderLogs(cCtx, emitted))
+
 	// Watch mode: continuously fetch and display new logs
-	return watchLogs(cCtx, appID, userApiClient, logs)
+	return watchLogs(cCtx, appID, userApiClient, emitted, matcher)
 }
 
-func watchLogs(cCtx *cli.Context, appID ethcommon.Address, userApiClient *utils.UserApiClient, initialLogs string) error {
-	const tailSize = 65536 // 64KB
-
-	// Track previously seen logs
+func watchLogs(cCtx *cli.Context, appID ethcommon.Address, userApiClient *utils.UserApiClient, initialLogs string, matcher logMatcher) error {
+	// Track the complete lines shown so far
 	prevLogs := initialLogs
+	idleTimeout := cCtx.Duration("idle-timeout")
+	lastLine := time.Now()
+	heartbeat := &logHeartbeat{
+		enabled:  progress.IsTTY() && !common.IsQuietOutput(cCtx.Context),
+		interval: logHeartbeatInterval,
+	}
+	logger := common.LoggerFromContext(cCtx)
+
+	// The poll interval doubles while fetching logs keeps failing transiently
+	baseInterval := utils.WatchPollInterval(cCtx)
+	interval := baseInterval
+	failures := 0
 
 	for {
-		utils.ShowCountdown(cCtx.Context, common.WatchPollIntervalSeconds)
+		utils.ShowCountdown(cCtx.Context, interval)
 
 		select {
 		case <-cCtx.Context.Done():
 			fmt.Println("\nStopped watching")
 			return nil
 		default:
-			// Fetch fresh logs
+			if idleTimeout > 0 && time.Since(lastLine) >= idleTimeout {
+				fmt.Print("\r\033[K")
+				return fmt.Errorf("no new log lines for %s", idleTimeout)
+			}
+			if now := time.Now(); heartbeat.due(now, lastLine) {
+				heartbeat.show(now, lastLine)
+			}
+
+			// Fetch fresh logs, retrying every error but 401/403. prevLogs is kept across
+			// failures so lines shown before the outage are not printed again.
 			newLogs, err := userApiClient.GetLogs(cCtx, appID)
 			if err != nil {
-				// Silently continue on error in watch mode
+				if cCtx.Context.Err() != nil {
+					continue
+				}
+				if !utils.IsTransientUserApiError(err) {
+					fmt.Print("\r\033[K")
+					return fmt.Errorf("failed to get logs: %w", err)
+				}
+				failures++
+				interval = min(interval*2, common.WatchBackoffMaxIntervalSeconds)
+				fmt.Print("\r\033[K")
+				logger.Warn("Failed to fetch logs (attempt %d): %v; retrying in %ds", failures, err, interval)
 				continue
 			}
+			if failures > 0 {
+				fmt.Print("\r\033[K")
+				logger.Info("Reconnected after %d failed attempt(s)", failures)
+				failures = 0
+				interval = baseInterval
+			}
 
-			// Skip if no new logs
-			if newLogs == prevLogs {
+			newContent, marker, emitted := findNewLogContent(prevLogs, newLogs)
+			prevLogs = emitted
+
+			// Skip if no new complete lines
+			if newContent == "" && marker == "" {
 				continue
 			}
+			lastLine = time.Now()
 
-			// Clear the countdown line and add spacing
+			// Clear the countdown line and the blank line (or heartbeat) above it
 			fmt.Print("\r\033[K\033[A\033[K")
+			heartbeat.shown = false
 
-			if strings.HasPrefix(newLogs, prevLogs) {
-				// Normal append - show only new content
-				newContent := newLogs[len(prevLogs):]
-				fmt.Print(newContent)
-			} else {
-				// Check if logs were truncated (old tail matches somewhere in new)
-				tail := prevLogs[max(0, len(prevLogs)-tailSize):] // Last 64KB
-				if idx := strings.LastIndex(newLogs, tail); idx != -1 {
-					// Found the tail at position idx
-					// Print everything after where the old logs ended
-					fmt.Print(newLogs[idx+len(tail):])
-				} else {
-					if len(newLogs) < len(prevLogs) {
-						fmt.Println("--- Logs restarted ---")
-					} else {
-						fmt.Println("--- Log stream gap detected ---")
-					}
-					fmt.Print(newLogs)
-				}
+			if marker != "" {
+				fmt.Println(marker)
 			}
+			fmt.Print(renderLogs(cCtx, newContent))
+
 			// Reset any incomplete formatting/special chars and add blank line
-			fmt.Print("\033[0m")
+			if common.ColorEnabled() {
+				fmt.Print("\033[0m")
+			}
 			fmt.Println()
-			prevLogs = newLogs
+
+			if done, err := matcher.match(newContent); done || err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// logHeartbeatInterval is how often a quiet --watch shows that it is still polling
+const logHeartbeatInterval = time.Minute
+
+// logHeartbeat shows a dim "still watching" line on a terminal while no new logs arrive, so a
+// quiet app can be told apart from a stalled watch. Each heartbeat replaces the previous one.
+type logHeartbeat struct {
+	enabled  bool
+	interval time.Duration
+	lastBeat time.Time
+	shown    bool // A heartbeat line is on screen below the last logs
+}
+
+// due reports whether a heartbeat should be shown at now, given when logs last arrived
+func (h *logHeartbeat) due(now, lastUpdate time.Time) bool {
+	if !h.enabled {
+		return false
+	}
+	since := lastUpdate
+	if h.lastBeat.After(since) {
+		since = h.lastBeat
+	}
+	return now.Sub(since) >= h.interval
+}
+
+// show prints the heartbeat in place of the countdown line, replacing the previous heartbeat
+func (h *logHeartbeat) show(now, lastUpdate time.Time) {
+	fmt.Print("\r\033[K")
+	if h.shown {
+		fmt.Print("\033[A\033[K")
+	}
+	color.New(color.FgHiBlack).Printf("...still watching (last update %s ago)\n", now.Sub(lastUpdate).Round(time.Second))
+	h.lastBeat = now
+	h.shown = true
+}
+
+const (
+	// logTailSize bounds how much of the previously shown logs is used to find where they continue
+	logTailSize = 65536 // 64KB
+
+	logsRestartedMarker = "--- Logs restarted ---"
+	logsGapMarker       = "--- Log stream gap detected ---"
+)
+
+// findNewLogContent compares the complete lines already shown (prevLogs) with freshly fetched
+// logs and returns the complete lines that have not been shown yet. A trailing line without a
+// newline is held back until it is complete, so partial lines are never printed. If the old
+// lines cannot be located in the new logs, a marker describing the discontinuity is returned
+// along with all complete new lines. emitted is the new value for prevLogs.
+func findNewLogContent(prevLogs, newLogs string) (newContent, marker, emitted string) {
+	complete := completeLogLines(newLogs)
+
+	if prevLogs == "" {
+		return complete, "", complete
+	}
+
+	// Nothing complete to compare against yet (e.g. an empty response); keep the current state
+	if complete == "" {
+		return "", "", prevLogs
+	}
+
+	// Normal append - show only new lines
+	if strings.HasPrefix(complete, prevLogs) {
+		return complete[len(prevLogs):], "", complete
+	}
+
+	// Logs were truncated at the front (possibly mid-line): find the last shown lines in the
+	// new logs, dropping the oldest of them until the remainder is found
+	for tail := utils.LogTail(prevLogs, logTailSize); tail != ""; {
+		if idx := lastLineAlignedIndex(complete, tail); idx != -1 {
+			return complete[idx+len(tail):], "", complete
+		}
+		nl := strings.IndexByte(tail, '\n')
+		if nl == len(tail)-1 {
+			break
+		}
+		tail = tail[nl+1:]
+	}
+
+	if len(newLogs) < len(prevLogs) {
+		return complete, logsRestartedMarker, complete
+	}
+	return complete, logsGapMarker, complete
+}
+
+// completeLogLines returns logs up to and including the last newline
+func completeLogLines(logs string) string {
+	return logs[:strings.LastIndexByte(logs, '\n')+1]
+}
+
+// lastLineAlignedIndex returns the index of the last occurrence of substr in s that starts at a line start, or -1
+func lastLineAlignedIndex(s, substr string) int {
+	for end := len(s); end > 0; {
+		idx := strings.LastIndex(s[:end], substr)
+		if idx == -1 {
+			return -1
+		}
+		if idx == 0 || s[idx-1] == '\n' {
+			return idx
+		}
+		end = idx + len(substr) - 1
+	}
+	return -1
+}
+
+// renderLogs escapes bytes that could corrupt the terminal unless --raw is set, and adds
+// receive times with --timestamps
+func renderLogs(cCtx *cli.Context, logs string) string {
+	if !cCtx.Bool("raw") {
+		logs = common.SanitizeTerminalOutput(logs)
+	}
+	if cCtx.Bool("timestamps") {
+		logs = prefixLogTimestamps(logs, time.Now())
+	}
+	return logs
+}
+
+// logTimestampLayout is the local receive time added before each line by --timestamps
+const logTimestampLayout = "2006-01-02 15:04:05.000"
+
+// prefixLogTimestamps prefixes every line of logs with receivedAt. Matching and the
+// line-based diffing of --watch work on the unprefixed logs, so this is for display only.
+func prefixLogTimestamps(logs string, receivedAt time.Time) string {
+	if logs == "" {
+		return logs
+	}
+	prefix := "[" + receivedAt.Local().Format(logTimestampLayout) + "] "
+	trailingNewline := strings.HasSuffix(logs, "\n")
+	lines := strings.Split(strings.TrimSuffix(logs, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = prefix + line
+	}
+	prefixed := strings.Join(lines, "\n")
+	if trailingNewline {
+		prefixed += "\n"
+	}
+	return prefixed
+}
+
+// logMatcher ends a watched log stream on a matching line: --until-match with success and
+// --fail-match with an error. Only lines that arrive after the watch starts are matched.
+type logMatcher struct {
+	until *regexp.Regexp
+	fail  *regexp.Regexp
+}
+
+// newLogMatcher compiles the --until-match and --fail-match patterns
+func newLogMatcher(cCtx *cli.Context) (logMatcher, error) {
+	var matcher logMatcher
+	for _, pattern := range []struct {
+		flag string
+		re   **regexp.Regexp
+	}{
+		{"until-match", &matcher.until},
+		{"fail-match", &matcher.fail},
+	} {
+		if expr := cCtx.String(pattern.flag); expr != "" {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return logMatcher{}, fmt.Errorf("invalid --%s: %w", pattern.flag, err)
+			}
+			*pattern.re = re
+		}
+	}
+	return matcher, nil
+}
+
+// active reports whether a pattern is set
+func (m logMatcher) active() bool {
+	return m.until != nil || m.fail != nil
+}
+
+// match checks the complete lines of content in order and reports whether the stream should
+// end. A line matching both patterns counts as a failure.
+func (m logMatcher) match(content string) (bool, error) {
+	if !m.active() || content == "" {
+		return false, nil
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(content, "\n"), "\n") {
+		if m.fail != nil && m.fail.MatchString(line) {
+			return true, fmt.Errorf("log line matched --fail-match: %s", common.SanitizeTerminalOutput(line))
+		}
+		if m.until != nil && m.until.MatchString(line) {
+			return true, nil
 		}
 	}
+	return false, nil
 }
@@ -0,0 +1,178 @@
+package app
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindNewLogContent(t *testing.T) {
+	tests := []struct {
+		name        string
+		prevLogs    string
+		newLogs     string
+		wantContent string
+		wantMarker  string
+		wantEmitted string
+	}{
+		{
+			name:        "identical content",
+			prevLogs:    "a\nb\n",
+			newLogs:     "a\nb\n",
+			wantEmitted: "a\nb\n",
+		},
+		{
+			name:        "append",
+			prevLogs:    "a\nb\n",
+			newLogs:     "a\nb\nc\nd\n",
+			wantContent: "c\nd\n",
+			wantEmitted: "a\nb\nc\nd\n",
+		},
+		{
+			name:        "partial trailing line is held back",
+			prevLogs:    "a\n",
+			newLogs:     "a\nb\nhalf",
+			wantContent: "b\n",
+			wantEmitted: "a\nb\n",
+		},
+		{
+			name:        "partial line completed on next poll",
+			prevLogs:    "a\nb\n",
+			newLogs:     "a\nb\nhalf done\n",
+			wantContent: "half done\n",
+			wantEmitted: "a\nb\nhalf done\n",
+		},
+		{
+			name:        "only the partial line changed",
+			prevLogs:    "a\n",
+			newLogs:     "a\npart",
+			wantEmitted: "a\n",
+		},
+		{
+			name:        "front truncated mid-line",
+			prevLogs:    "first line\nsecond line\n",
+			newLogs:     "ne\nsecond line\nthird line\n",
+			wantContent: "third line\n",
+			wantEmitted: "ne\nsecond line\nthird line\n",
+		},
+		{
+			name:        "tail must match at a line start",
+			prevLogs:    "x\nline\n",
+			newLogs:     "dline\nline\nnext\n",
+			wantContent: "next\n",
+			wantEmitted: "dline\nline\nnext\n",
+		},
+		{
+			name:        "rotation",
+			prevLogs:    "old 1\nold 2\nold 3\n",
+			newLogs:     "new 1\n",
+			wantContent: "new 1\n",
+			wantMarker:  logsRestartedMarker,
+			wantEmitted: "new 1\n",
+		},
+		{
+			name:        "gap",
+			prevLogs:    "old\n",
+			newLogs:     "unrelated 1\nunrelated 2\n",
+			wantContent: "unrelated 1\nunrelated 2\n",
+			wantMarker:  logsGapMarker,
+			wantEmitted: "unrelated 1\nunrelated 2\n",
+		},
+		{
+			name:        "truncated to lines already shown",
+			prevLogs:    "a\nb\nc\n",
+			newLogs:     "c\n",
+			wantEmitted: "c\n",
+		},
+		{
+			name:        "empty response keeps state",
+			prevLogs:    "a\n",
+			newLogs:     "",
+			wantEmitted: "a\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, marker, emitted := findNewLogContent(tt.prevLogs, tt.newLogs)
+			assert.Equal(t, tt.wantContent, content)
+			assert.Equal(t, tt.wantMarker, marker)
+			assert.Equal(t, tt.wantEmitted, emitted)
+		})
+	}
+}
+
+func TestPrefixLogTimestamps(t *testing.T) {
+	at := time.Date(2025, 3, 4, 15, 4, 5, 123000000, time.Local)
+	prefix := "[2025-03-04 15:04:05.123] "
+
+	assert.Equal(t, "", prefixLogTimestamps("", at))
+	assert.Equal(t, prefix+"one\n"+prefix+"two\n", prefixLogTimestamps("one\ntwo\n", at))
+	assert.Equal(t, prefix+"one\n"+prefix+"partial", prefixLogTimestamps("one\npartial", at))
+	assert.Equal(t, prefix+"\n"+prefix+"after blank\n", prefixLogTimestamps("\nafter blank\n", at))
+}
+
+func TestLogHeartbeatDue(t *testing.T) {
+	lastUpdate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	h := &logHeartbeat{enabled: true, interval: time.Minute}
+
+	assert.False(t, h.due(lastUpdate.Add(59*time.Second), lastUpdate))
+	assert.True(t, h.due(lastUpdate.Add(time.Minute), lastUpdate))
+
+	// The next heartbeat is an interval after the previous one, not after the last update
+	h.lastBeat = lastUpdate.Add(time.Minute)
+	assert.False(t, h.due(lastUpdate.Add(90*time.Second), lastUpdate))
+	assert.True(t, h.due(lastUpdate.Add(2*time.Minute), lastUpdate))
+
+	// New logs restart the wait
+	assert.False(t, h.due(lastUpdate.Add(2*time.Minute), lastUpdate.Add(90*time.Second)))
+
+	disabled := &logHeartbeat{interval: time.Minute}
+	assert.False(t, disabled.due(lastUpdate.Add(time.Hour), lastUpdate))
+}
+
+func TestLogMatcher(t *testing.T) {
+	matcher := logMatcher{
+		until: regexp.MustCompile(`Server started`),
+		fail:  regexp.MustCompile(`FATAL|panic`),
+	}
+
+	done, err := matcher.match("booting\nloading config\n")
+	assert.False(t, done)
+	assert.NoError(t, err)
+
+	done, err = matcher.match("booting\nServer started on :8080\nFATAL later\n")
+	assert.True(t, done)
+	assert.NoError(t, err, "lines are checked in order")
+
+	done, err = matcher.match("panic: nil map\nServer started\n")
+	assert.True(t, done)
+	assert.ErrorContains(t, err, "panic: nil map")
+
+	done, err = matcher.match("FATAL: Server started twice\n")
+	assert.True(t, done)
+	assert.Error(t, err, "a line matching both patterns is a failure")
+
+	done, err = logMatcher{}.match("Server started\n")
+	assert.False(t, done)
+	assert.NoError(t, err)
+}
+
+func TestStatusFilter(t *testing.T) {
+	filter, err := parseStatusFilter([]string{"running, Stopped", "", "failed"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"running", "stopped", "failed"}, filter)
+
+	_, err = parseStatusFilter([]string{"running,stoped"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"stoped"`)
+	assert.Contains(t, err.Error(), "running, stopping, stopped")
+
+	assert.True(t, matchesStatusFilter("Running", filter))
+	assert.True(t, matchesStatusFilter("Failed", filter))
+	assert.False(t, matchesStatusFilter("Starting", filter))
+	assert.True(t, matchesStatusFilter("Starting", nil), "no filter keeps every app")
+}
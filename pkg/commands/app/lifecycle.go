@@ -2,9 +2,12 @@ package app
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/Layr-Labs/eigenx-cli/pkg/commands/utils"
 	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common/output"
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli/v2"
 )
 
@@ -41,6 +44,10 @@ var TerminateCommand = &cli.Command{
 		common.RpcUrlFlag,
 		common.PrivateKeyFlag,
 		common.ForceFlagWithUsage("Force termination without confirmation"),
+		&cli.BoolFlag{
+			Name:  "all",
+			Usage: "Terminate all of your non-terminated apps in a single transaction",
+		},
 	}...),
 	Action: terminateAction,
 }
@@ -123,6 +130,13 @@ func terminateAction(cCtx *cli.Context) error {
 		return err
 	}
 
+	if cCtx.Bool("all") {
+		if cCtx.NArg() > 0 {
+			return fmt.Errorf("--all cannot be combined with an app id or name")
+		}
+		return terminateAllApps(cCtx, preflightCtx)
+	}
+
 	// Get app address from args or interactive selection
 	appID, err := utils.GetAppIDInteractive(cCtx, 0, "terminate")
 	if err != nil {
@@ -144,3 +158,76 @@ func terminateAction(cCtx *cli.Context) error {
 
 	return utils.GetAndPrintAppInfo(cCtx, appID, common.AppStatusTerminating)
 }
+
+// terminateAllApps terminates every non-terminated app owned by the developer in one batched transaction
+func terminateAllApps(cCtx *cli.Context, preflightCtx *utils.PreflightContext) error {
+	ctx := cCtx.Context
+	logger := common.LoggerFromContext(cCtx)
+	environmentName := preflightCtx.EnvironmentConfig.Name
+
+	client, appController, err := utils.GetAppControllerBinding(cCtx)
+	if err != nil {
+		return fmt.Errorf("failed to get contract caller: %w", err)
+	}
+	defer client.Close()
+
+	developerAddr, err := utils.GetDeveloperAddress(cCtx)
+	if err != nil {
+		return fmt.Errorf("failed to get developer address: %w", err)
+	}
+
+	allApps, configs, err := utils.GetAllAppsByDeveloper(cCtx, appController, developerAddr)
+	if err != nil {
+		return err
+	}
+
+	var apps []ethcommon.Address
+	for i, appAddr := range allApps {
+		if common.AppStatus(configs[i].Status) == common.ContractAppStatusTerminated {
+			continue
+		}
+		apps = append(apps, appAddr)
+	}
+
+	if len(apps) == 0 {
+		logger.Info("No active apps found for developer %s", developerAddr.Hex())
+		return nil
+	}
+
+	displayNames := make([]string, len(apps))
+	logger.Info("The following %d app(s) will be permanently terminated:", len(apps))
+	for i, appAddr := range apps {
+		displayNames[i] = common.FormatAppDisplay(environmentName, appAddr, utils.GetAppProfileName(cCtx, appAddr))
+		logger.Info("  • %s", displayNames[i])
+	}
+
+	if !cCtx.Bool(common.ForceFlag.Name) {
+		expected := strconv.Itoa(len(apps))
+		_, err := output.InputString(
+			fmt.Sprintf("This cannot be undone. Type %s to confirm termination of all %s app(s):", expected, expected),
+			"Enter the number of apps listed above to confirm",
+			"",
+			func(input string) error {
+				if input != expected {
+					return fmt.Errorf("type %s to confirm", expected)
+				}
+				return nil
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("termination cancelled: %w", err)
+		}
+	}
+
+	// Confirmation was collected above, so skip the per-transaction prompt
+	if err := preflightCtx.Caller.TerminateApps(ctx, apps, true); err != nil {
+		return err
+	}
+
+	logger.Info("Terminated %d app(s):", len(apps))
+	for _, name := range displayNames {
+		logger.Info("  • %s", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/commands/utils"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/urfave/cli/v2"
+)
+
+var LogsSetVisibilityCommand = &cli.Command{
+	Name:      "set-visibility",
+	Usage:     "Make an app's logs public or private without upgrading it",
+	ArgsUsage: "<public|private|off> [app-id|name]",
+	Flags: append(common.GlobalFlags, []cli.Flag{
+		common.EnvironmentFlag,
+		common.RpcUrlFlag,
+		common.PrivateKeyFlag,
+	}...),
+	Action: logsSetVisibilityAction,
+}
+
+func logsSetVisibilityAction(cCtx *cli.Context) error {
+	logger := common.LoggerFromContext(cCtx)
+
+	var publicLogs bool
+	switch visibility := cCtx.Args().First(); visibility {
+	case "public":
+		publicLogs = true
+	case "private":
+		publicLogs = false
+	case "off":
+		// The log redirect is part of the image, so only an upgrade can turn logs off
+		return fmt.Errorf("turning logs off changes the image; run 'eigenx app upgrade --%s off' instead", common.LogVisibilityFlag.Name)
+	default:
+		return fmt.Errorf("please provide the log visibility: public, private or off")
+	}
+
+	// 1. Do preflight checks (auth, network, etc.) first
+	preflightCtx, err := utils.DoPreflightChecks(cCtx)
+	if err != nil {
+		return err
+	}
+
+	// 2. Get app ID from args or interactive selection
+	appID, err := utils.GetAppIDInteractive(cCtx, 1, "change log visibility for")
+	if err != nil {
+		return fmt.Errorf("failed to get app id: %w", err)
+	}
+	formattedApp := common.FormatAppDisplay(preflightCtx.EnvironmentConfig.Name, appID, "")
+
+	// 3. Skip the transaction when nothing changes
+	currentlyPublic, err := utils.CheckAppLogPermission(cCtx, appID)
+	if err != nil {
+		return fmt.Errorf("failed to check current permission state: %w", err)
+	}
+	if currentlyPublic == publicLogs {
+		logger.Info("Logs of %s are already %s", formattedApp, cCtx.Args().First())
+		return nil
+	}
+
+	// 4. Change only the log permission, keeping the current release
+	if err := preflightCtx.Caller.SetLogVisibility(cCtx.Context, appID, publicLogs); err != nil {
+		return fmt.Errorf("failed to change log visibility: %w", err)
+	}
+
+	logger.Info("Logs of %s are now %s", formattedApp, cCtx.Args().First())
+	return nil
+}
@@ -0,0 +1,90 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/commands/utils"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/pkg/browser"
+	"github.com/urfave/cli/v2"
+)
+
+var OpenCommand = &cli.Command{
+	Name:      "open",
+	Usage:     "Open the app's domain (or IP) in the default browser",
+	ArgsUsage: "[app-id|name]",
+	Flags: append(common.GlobalFlags, []cli.Flag{
+		common.EnvironmentFlag,
+		common.RpcUrlFlag,
+		common.PrivateKeyFlag,
+	}...),
+	Action: openAction,
+}
+
+func openAction(cCtx *cli.Context) error {
+	logger := common.LoggerFromContext(cCtx)
+
+	appID, err := utils.GetAppIDInteractive(cCtx, 0, "open")
+	if err != nil {
+		return fmt.Errorf("failed to get app id: %w", err)
+	}
+
+	domain := deployedDomain(cCtx, appID)
+	ip := ""
+	if domain == "" {
+		userApiClient, err := utils.NewUserApiClient(cCtx)
+		if err != nil {
+			return fmt.Errorf("failed to get userApi client: %w", err)
+		}
+		info, err := userApiClient.GetInfos(cCtx, []ethcommon.Address{appID}, 1, utils.AddressChainsEVM)
+		if err != nil {
+			return fmt.Errorf("failed to get app info: %w", err)
+		}
+		if len(info.Apps) > 0 {
+			ip = info.Apps[0].Ip
+		}
+	}
+
+	url, err := appURL(domain, ip)
+	if err != nil {
+		return fmt.Errorf("app %s: %w", appID.Hex(), err)
+	}
+
+	// Always print the URL so it can be copied on machines without a browser
+	fmt.Println(url)
+	if err := browser.OpenURL(url); err != nil {
+		logger.Warn("Failed to open browser automatically: %v", err)
+	}
+	return nil
+}
+
+// deployedDomain returns the DOMAIN in the public env of the app's latest release, or "" when
+// it is unset, private or the release cannot be read
+func deployedDomain(cCtx *cli.Context, appID ethcommon.Address) string {
+	logger := common.LoggerFromContext(cCtx)
+
+	caller, err := utils.GetContractCaller(cCtx)
+	if err != nil {
+		logger.Debug("Skipping domain lookup: %v", err)
+		return ""
+	}
+	deployed, err := utils.GetDeployedRelease(cCtx.Context, caller, appID)
+	if err != nil {
+		logger.Debug("Skipping domain lookup: %v", err)
+		return ""
+	}
+	return deployed.PublicEnv["DOMAIN"]
+}
+
+// appURL builds the address to open: https on the domain when TLS is configured, else plain
+// http on the instance IP
+func appURL(domain, ip string) (string, error) {
+	if domain != "" && domain != "localhost" {
+		return "https://" + domain, nil
+	}
+	if ip == "" || ip == "No IP assigned" {
+		return "", fmt.Errorf("app has no IP address yet")
+	}
+	return "http://" + ip, nil
+}
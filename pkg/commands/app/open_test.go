@@ -0,0 +1,24 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppURL(t *testing.T) {
+	url, err := appURL("app.example.com", "1.2.3.4")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://app.example.com", url)
+
+	url, err = appURL("", "1.2.3.4")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://1.2.3.4", url)
+
+	url, err = appURL("localhost", "1.2.3.4")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://1.2.3.4", url, "localhost is not a public domain")
+
+	_, err = appURL("", "No IP assigned")
+	assert.Error(t, err)
+}
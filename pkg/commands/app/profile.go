@@ -1,10 +1,13 @@
 package app
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/Layr-Labs/eigenx-cli/pkg/commands/utils"
 	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common/output"
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli/v2"
 )
 
@@ -25,9 +28,34 @@ var ProfileCommand = &cli.Command{
 				common.DescriptionFlag,
 				common.XURLFlag,
 				common.ImageFlag,
+				common.ResizeImageFlag,
 			}...),
 			Action: profileSetAction,
 		},
+		{
+			Name:      "show",
+			Usage:     "Show the public profile of an app",
+			ArgsUsage: "<app-id|name>",
+			Flags: append(common.GlobalFlags, []cli.Flag{
+				common.EnvironmentFlag,
+				common.RpcUrlFlag,
+				common.PrivateKeyFlag,
+				common.JSONFlag,
+			}...),
+			Action: profileShowAction,
+		},
+		{
+			Name:      "delete",
+			Usage:     "Remove the public profile of an app",
+			ArgsUsage: "<app-id|name>",
+			Flags: append(common.GlobalFlags, []cli.Flag{
+				common.EnvironmentFlag,
+				common.RpcUrlFlag,
+				common.PrivateKeyFlag,
+				common.ForceFlagWithUsage("Delete the profile without confirmation"),
+			}...),
+			Action: profileDeleteAction,
+		},
 	},
 }
 
@@ -47,6 +75,7 @@ func profileSetAction(cCtx *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	defer profile.RemoveResizedImage()
 
 	// Upload profile via API
 	logger.Info("Uploading app profile...")
@@ -66,19 +95,97 @@ func profileSetAction(cCtx *cli.Context) error {
 
 	// Show uploaded profile data
 	fmt.Println("\nUploaded Profile:")
-	fmt.Printf("  Name:        %s\n", response.Name)
-	if response.Website != nil {
-		fmt.Printf("  Website:     %s\n", *response.Website)
+	printProfile(response)
+
+	return nil
+}
+
+func profileShowAction(cCtx *cli.Context) error {
+	appID, err := utils.GetAppIDInteractive(cCtx, 0, "show profile for")
+	if err != nil {
+		return err
+	}
+
+	userApiClient, err := utils.NewUserApiClient(cCtx)
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	// The profile is part of the app info
+	info, err := userApiClient.GetInfos(cCtx, []ethcommon.Address{appID}, 1, utils.AddressChainsAll)
+	if err != nil {
+		return fmt.Errorf("failed to get profile: %w", err)
 	}
-	if response.Description != nil {
-		fmt.Printf("  Description: %s\n", *response.Description)
+	if len(info.Apps) == 0 {
+		return fmt.Errorf("failed to get profile: app %s not found", appID.Hex())
 	}
-	if response.XURL != nil {
-		fmt.Printf("  X URL:       %s\n", *response.XURL)
+	profile := info.Apps[0].Profile
+
+	if cCtx.Bool(common.JSONFlag.Name) {
+		// An app without a profile is printed as null
+		encoded, err := json.MarshalIndent(profile, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		fmt.Println(string(encoded))
+		return nil
 	}
-	if response.ImageURL != nil {
-		fmt.Printf("  Image URL:   %s\n", *response.ImageURL)
+
+	if profile == nil {
+		fmt.Printf("App %s has no profile. Set one with: eigenx app profile set %s\n", appID.Hex(), appID.Hex())
+		return nil
 	}
 
+	fmt.Printf("Profile of app %s:\n", appID.Hex())
+	printProfile(profile)
 	return nil
 }
+
+func profileDeleteAction(cCtx *cli.Context) error {
+	logger := common.LoggerFromContext(cCtx)
+
+	appID, err := utils.GetAppIDInteractive(cCtx, 0, "delete profile for")
+	if err != nil {
+		return err
+	}
+
+	if !cCtx.Bool(common.ForceFlag.Name) {
+		confirmed, err := output.Confirm(fmt.Sprintf("Delete the public profile of app %s?", appID.Hex()))
+		if err != nil {
+			return fmt.Errorf("failed to get confirmation: %w", err)
+		}
+		if !confirmed {
+			logger.Info("Profile deletion cancelled")
+			return nil
+		}
+	}
+
+	userApiClient, err := utils.NewUserApiClient(cCtx)
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	if err := userApiClient.DeleteAppProfile(cCtx, appID.Hex()); err != nil {
+		return fmt.Errorf("failed to delete profile: %w", err)
+	}
+
+	logger.Info("✓ Profile deleted for app %s", appID.Hex())
+	return nil
+}
+
+// printProfile prints the fields of a profile that are set
+func printProfile(profile *utils.AppProfileResponse) {
+	fmt.Printf("  Name:        %s\n", profile.Name)
+	if profile.Website != nil {
+		fmt.Printf("  Website:     %s\n", *profile.Website)
+	}
+	if profile.Description != nil {
+		fmt.Printf("  Description: %s\n", *profile.Description)
+	}
+	if profile.XURL != nil {
+		fmt.Printf("  X URL:       %s\n", *profile.XURL)
+	}
+	if profile.ImageURL != nil {
+		fmt.Printf("  Image URL:   %s\n", *profile.ImageURL)
+	}
+}
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/commands/utils"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/urfave/cli/v2"
+)
+
+var RedeployCommand = &cli.Command{
+	Name:      "redeploy",
+	Usage:     "Upgrade an app with fresh env variables, keeping its current image",
+	ArgsUsage: "[app-id|name]",
+	Flags: append(common.GlobalFlags, []cli.Flag{
+		common.EnvironmentFlag,
+		common.RpcUrlFlag,
+		common.PrivateKeyFlag,
+		common.EnvFlag,
+		common.EnvPrefixFlag,
+		common.PublicEnvFileFlag,
+		common.PrivateEnvFileFlag,
+		common.InstanceTypeFlag,
+		common.EnvCheckEntropyFlag,
+		common.ShowValuesFlag,
+		common.EnvEntropyThresholdFlag,
+		common.WaitIntervalBackoffFlag,
+		common.ProgressFormatFlag,
+	}...),
+	Action: redeployAction,
+}
+
+func redeployAction(cCtx *cli.Context) error {
+	logger := common.LoggerFromContext(cCtx)
+
+	if err := utils.ApplyProgressFormat(cCtx); err != nil {
+		return err
+	}
+	if err := utils.ValidateEnvFileFlags(cCtx); err != nil {
+		return err
+	}
+
+	// 1. Do preflight checks (auth, network, etc.) first
+	preflightCtx, err := utils.DoPreflightChecks(cCtx)
+	if err != nil {
+		return err
+	}
+
+	// 2. Get app ID from args or interactive selection
+	appID, err := utils.GetAppIDInteractive(cCtx, 0, "redeploy")
+	if err != nil {
+		return fmt.Errorf("failed to get app id: %w", err)
+	}
+
+	// 3. Read the image currently deployed for the app
+	deployed, err := utils.GetDeployedRelease(cCtx.Context, preflightCtx.Caller, appID)
+	if err != nil {
+		return err
+	}
+	logger.Info("Image: %s", deployed.ImageRef())
+
+	// 4. Keep the current instance type unless a new one is requested
+	instanceType := deployed.PublicEnv[common.EigenMachineTypeEnvVar]
+	if instanceType == "" || cCtx.String(common.InstanceTypeFlag.Name) != "" {
+		instanceType, err = utils.GetInstanceTypeInteractive(cCtx, instanceType)
+		if err != nil {
+			return fmt.Errorf("failed to get instance: %w", err)
+		}
+	}
+
+	// 5. Re-read and re-encrypt the environment
+	envFilePath, err := utils.GetEnvFileInteractive(cCtx)
+	if err != nil {
+		return fmt.Errorf("failed to get env file path: %w", err)
+	}
+
+	publicEnv, privateEnv, err := utils.ParseEnvFromContext(cCtx, envFilePath)
+	if err != nil {
+		return err
+	}
+
+	release, err := utils.NewReleaseForImage(cCtx, preflightCtx.EnvironmentConfig, appID, deployed.Digest, deployed.Registry, publicEnv, privateEnv, instanceType)
+	if err != nil {
+		return err
+	}
+
+	// 6. Upgrade the app, leaving log visibility unchanged
+	publicLogs, err := utils.CheckAppLogPermission(cCtx, appID)
+	if err != nil {
+		return fmt.Errorf("failed to check current permission state: %w", err)
+	}
+
+	utils.ReportStage(cCtx, utils.StageSubmitTx, 0, "Submitting upgrade transaction")
+	_, err = preflightCtx.Caller.UpgradeApp(cCtx.Context, appID, release, publicLogs, false, deployed.ImageRef())
+	if err != nil {
+		return fmt.Errorf("failed to upgrade app: %w", err)
+	}
+	utils.ReportStage(cCtx, utils.StageSubmitTx, 100, "Upgrade transaction confirmed")
+
+	// 7. Watch until upgrade completes
+	return utils.WatchUntilTransitionComplete(cCtx, appID, common.AppStatusUpgrading)
+}
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/commands/utils"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+var SSHCommand = &cli.Command{
+	Name:      "ssh",
+	Usage:     "Open an interactive shell in a running app",
+	ArgsUsage: "[app-id|name] [-- command...]",
+	Flags: append(common.GlobalFlags, []cli.Flag{
+		common.EnvironmentFlag,
+		common.RpcUrlFlag,
+	}...),
+	Action: sshAction,
+}
+
+func sshAction(cCtx *cli.Context) error {
+	logger := common.LoggerFromContext(cCtx)
+
+	appID, err := utils.GetAppIDInteractive(cCtx, 0, "open a shell in")
+	if err != nil {
+		return fmt.Errorf("failed to get app address: %w", err)
+	}
+
+	userApiClient, err := utils.NewUserApiClient(cCtx)
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	statuses, err := userApiClient.GetStatuses(cCtx, []ethcommon.Address{appID})
+	if err != nil {
+		return fmt.Errorf("failed to get app status: %w", err)
+	}
+	if len(statuses.Apps) > 0 && statuses.Apps[0].Status != common.AppStatusRunning {
+		return fmt.Errorf("app %s is %s; it must be running to open a shell", appID.Hex(), statuses.Apps[0].Status)
+	}
+
+	// Anything after the app argument is the command to run instead of the default shell
+	var command []string
+	if cCtx.Args().Len() > 1 {
+		command = cCtx.Args().Tail()
+	}
+
+	stdinFd := int(os.Stdin.Fd())
+	stdoutFd := int(os.Stdout.Fd())
+	tty := term.IsTerminal(stdinFd) && term.IsTerminal(stdoutFd)
+
+	opts := utils.ExecOptions{Command: command, TTY: tty}
+	if tty {
+		if cols, rows, err := term.GetSize(stdoutFd); err == nil {
+			opts.Cols, opts.Rows = cols, rows
+		}
+	}
+
+	session, err := userApiClient.OpenExecSession(cCtx, appID, opts)
+	if err != nil {
+		return err
+	}
+	defer session.Close()
+
+	if tty {
+		logger.Info("Connected to %s. Press Ctrl-D or type 'exit' to disconnect.", appID.Hex())
+
+		oldState, err := term.MakeRaw(stdinFd)
+		if err != nil {
+			return fmt.Errorf("failed to set terminal to raw mode: %w", err)
+		}
+		defer term.Restore(stdinFd, oldState)
+
+		ctx, cancel := context.WithCancel(cCtx.Context)
+		defer cancel()
+
+		lastCols, lastRows := opts.Cols, opts.Rows
+		watchTerminalResize(ctx, func() {
+			cols, rows, err := term.GetSize(stdoutFd)
+			if err != nil || (cols == lastCols && rows == lastRows) {
+				return
+			}
+			lastCols, lastRows = cols, rows
+			_ = session.Resize(cols, rows)
+		})
+	}
+
+	// Forward local input until it is closed. In raw mode Ctrl-D is passed through
+	// to the remote shell, which exits and ends the session.
+	go func() {
+		_, _ = io.Copy(session, os.Stdin)
+		_ = session.CloseInput()
+	}()
+
+	exitCode, err := session.Run(os.Stdout)
+	if err != nil {
+		return err
+	}
+	if exitCode != 0 {
+		return cli.Exit("", exitCode)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+//go:build !windows
+
+package app
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// watchTerminalResize calls onResize whenever the local terminal receives SIGWINCH,
+// until ctx is done
+func watchTerminalResize(ctx context.Context, onResize func()) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGWINCH)
+
+	go func() {
+		defer signal.Stop(sigCh)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sigCh:
+				onResize()
+			}
+		}
+	}()
+}
@@ -0,0 +1,29 @@
+//go:build windows
+
+package app
+
+import (
+	"context"
+	"time"
+)
+
+// terminalResizePollInterval is how often the console size is checked, as Windows
+// has no SIGWINCH equivalent
+const terminalResizePollInterval = 250 * time.Millisecond
+
+// watchTerminalResize calls onResize periodically until ctx is done; onResize is
+// expected to ignore calls where the size has not changed
+func watchTerminalResize(ctx context.Context, onResize func()) {
+	go func() {
+		ticker := time.NewTicker(terminalResizePollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				onResize()
+			}
+		}
+	}()
+}
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/commands/utils"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/urfave/cli/v2"
+)
+
+var StatusCommand = &cli.Command{
+	Name:      "status",
+	Usage:     "Show only the status of an app, exiting non-zero when it has failed",
+	ArgsUsage: "[app-id|name]",
+	Flags: append(common.GlobalFlags, []cli.Flag{
+		common.EnvironmentFlag,
+		common.RpcUrlFlag,
+		common.JSONFlag,
+	}...),
+	Action: statusAction,
+}
+
+func statusAction(cCtx *cli.Context) error {
+	appID, err := utils.GetAppIDInteractive(cCtx, 0, "check status of")
+	if err != nil {
+		return err
+	}
+
+	summary, err := utils.GetAppStatusSummary(cCtx, appID)
+	if err != nil {
+		return err
+	}
+
+	if cCtx.Bool(common.JSONFlag.Name) {
+		encoded, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		fmt.Println(string(encoded))
+	} else {
+		fmt.Println(summary.Status)
+	}
+
+	// Monitoring scripts alert on the exit code
+	if strings.EqualFold(summary.Status, common.AppStatusFailed) {
+		return cli.Exit(fmt.Sprintf("app %s is in %s state", summary.AppID, common.AppStatusFailed), 1)
+	}
+	return nil
+}
@@ -18,14 +18,71 @@ var UpgradeCommand = &cli.Command{
 		common.RpcUrlFlag,
 		common.PrivateKeyFlag,
 		common.EnvFlag,
+		common.EnvPrefixFlag,
+		common.PublicEnvFileFlag,
+		common.PrivateEnvFileFlag,
+		common.MaxEnvSizeFlag,
 		common.FileFlag,
 		common.LogVisibilityFlag,
 		common.InstanceTypeFlag,
+		common.TLSFlag,
+		common.NoTLSFlag,
+		common.TLSEmailFlag,
+		common.TLSStagingFlag,
+		common.ForceFlagWithUsage("Skip confirmation prompts (for automation)"),
+		common.FailOnPlatformMismatchFlag,
+		common.PlatformFlag,
+		common.BuildTimeoutFlag,
+		common.KeepBaseImageFlag,
+		common.WaitIntervalBackoffFlag,
+		common.ProgressFlag,
+		common.ProgressFormatFlag,
+		common.EnvCheckEntropyFlag,
+		common.ShowValuesFlag,
+		common.EnvEntropyThresholdFlag,
+		common.BuildSecretFlag,
+		common.BuildArgFlag,
+		common.DockerfileTargetFlag,
+		common.ManifestOutFlag,
+		common.OutputTxHashFileFlag,
+		common.ReuseDelegationCheckFlag,
+		common.RegistryRetrySameFlag,
+		common.PropagationWaitFlag,
+		common.GitDirtyCheckFlag,
+		common.AllowDirtyFlag,
+		common.RegistryAuthFileFlag,
+		common.RegistryScopeCheckFlag,
+		common.RegistryCACertFlag,
+		common.VerifySignatureFlag,
+		common.CosignKeyFlag,
+		common.SkipUnchangedFlag,
 	}...),
 	Action: upgradeAction,
 }
 
 func upgradeAction(cCtx *cli.Context) error {
+	if err := utils.ApplyProgressFormat(cCtx); err != nil {
+		return err
+	}
+	if err := utils.ValidateTLSFlags(cCtx); err != nil {
+		return err
+	}
+	if err := utils.ApplyPlatformFlag(cCtx); err != nil {
+		return err
+	}
+	if err := utils.ValidateSignatureFlags(cCtx); err != nil {
+		return err
+	}
+	if err := utils.ValidateEnvFileFlags(cCtx); err != nil {
+		return err
+	}
+	if err := utils.ValidateRegistryAuthFile(cCtx); err != nil {
+		return err
+	}
+	if err := utils.ValidateRegistryCACert(cCtx); err != nil {
+		return err
+	}
+
 	// 1. Do preflight checks (auth, network, etc.) first
 	preflightCtx, err := utils.DoPreflightChecks(cCtx)
 	if err != nil {
@@ -51,11 +108,21 @@ func upgradeAction(cCtx *cli.Context) error {
 	}
 	buildFromDockerfile := dockerfilePath != ""
 
+	// Refuse to ship uncommitted code when the dirty check applies
+	gitCommit := ""
+	if buildFromDockerfile {
+		gitCommit, err = utils.CheckGitWorkingTree(cCtx, preflightCtx.EnvironmentConfig, ".")
+		if err != nil {
+			return err
+		}
+	}
+
 	// 5. Get image reference (context-aware based on Dockerfile decision)
 	imageRef, err := utils.GetImageReferenceInteractive(cCtx, 1, buildFromDockerfile)
 	if err != nil {
 		return fmt.Errorf("failed to get image reference: %w", err)
 	}
+	utils.CheckRegistryPushScope(cCtx, imageRef)
 
 	// 6. Get environment file configuration
 	envFilePath, err := utils.GetEnvFileInteractive(cCtx)
@@ -79,7 +146,7 @@ func upgradeAction(cCtx *cli.Context) error {
 	}
 
 	// 10. Prepare the release (includes build/push if needed, with automatic retry on permission errors)
-	release, imageRef, err := utils.PrepareReleaseFromContext(cCtx, preflightCtx.EnvironmentConfig, appID, dockerfilePath, imageRef, envFilePath, logRedirect, instanceType, 3)
+	release, privateEnv, imageRef, err := utils.PrepareReleaseFromContext(cCtx, preflightCtx.EnvironmentConfig, appID, dockerfilePath, imageRef, envFilePath, logRedirect, instanceType, 3)
 	if err != nil {
 		return err
 	}
@@ -92,11 +159,46 @@ func upgradeAction(cCtx *cli.Context) error {
 
 	needsPermissionChange := currentlyPublic != publicLogs
 
+	if cCtx.Bool(common.SkipUnchangedFlag.Name) && !needsPermissionChange {
+		upToDate, err := utils.ReleaseUpToDate(cCtx.Context, preflightCtx.Caller, appID, release)
+		if err != nil {
+			return err
+		}
+		if upToDate {
+			common.LoggerFromContext(cCtx).Warn("App %s already runs this image and public env, skipping upgrade (private env changes are not detected, pass --%s=false to upgrade anyway)", appID.Hex(), common.SkipUnchangedFlag.Name)
+			return nil
+		}
+	}
+
 	// 12. Upgrade the app
-	err = preflightCtx.Caller.UpgradeApp(cCtx.Context, appID, release, publicLogs, needsPermissionChange, imageRef)
+	utils.ReportStage(cCtx, utils.StageSubmitTx, 0, "Submitting upgrade transaction")
+	txHash, err := preflightCtx.Caller.UpgradeApp(cCtx.Context, appID, release, publicLogs, needsPermissionChange, imageRef)
 	if err != nil {
 		return fmt.Errorf("failed to upgrade app: %w", err)
 	}
+	utils.ReportStage(cCtx, utils.StageSubmitTx, 100, "Upgrade transaction confirmed")
+	utils.RecordTxHash(cCtx, txHash)
+
+	// Catch tag races where the registry served a different manifest than the one pinned
+	utils.VerifyReleaseDigest(cCtx, preflightCtx.Caller, appID, release, imageRef)
+
+	// Record the release inputs if requested (non-blocking - the upgrade already succeeded)
+	if manifestPath := cCtx.String(common.ManifestOutFlag.Name); manifestPath != "" {
+		err := utils.WriteReleaseManifest(cCtx, manifestPath, utils.ReleaseManifestInput{
+			EnvironmentConfig: preflightCtx.EnvironmentConfig,
+			AppID:             appID,
+			Release:           release,
+			PrivateEnv:        privateEnv,
+			InstanceType:      instanceType,
+			LogRedirect:       logRedirect,
+			PublicLogs:        publicLogs,
+			GitCommit:         gitCommit,
+			TxHash:            txHash,
+		})
+		if err != nil {
+			common.LoggerFromContext(cCtx).Warn("Failed to write release manifest: %s", err.Error())
+		}
+	}
 
 	// 13. Watch until upgrade completes
 	return utils.WatchUntilTransitionComplete(cCtx, appID, common.AppStatusUpgrading)
@@ -111,7 +213,7 @@ func getCurrentInstanceType(cCtx *cli.Context, appID ethcommon.Address) string {
 		return "" // API client creation failed, skip default
 	}
 
-	infos, err := userApiClient.GetInfos(cCtx, []ethcommon.Address{appID}, 1)
+	infos, err := userApiClient.GetInfos(cCtx, []ethcommon.Address{appID}, 1, utils.AddressChainsAll)
 	if err != nil {
 		return "" // API call failed, skip default
 	}
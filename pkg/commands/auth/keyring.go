@@ -7,6 +7,7 @@ import (
 
 	"github.com/Layr-Labs/eigenx-cli/pkg/commands/utils"
 	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common/logger"
 	"github.com/urfave/cli/v2"
 )
 
@@ -28,23 +29,50 @@ func ListStoredKeys() (map[string]string, error) {
 	return result, nil
 }
 
+// Kinds of private key source, in order of precedence
+const (
+	keySourceFlag    = "flag"
+	keySourceEnvVar  = "env"
+	keySourceKeyring = "keyring"
+)
+
 // GetPrivateKeyWithSource tries to get private key from multiple sources and returns the source
 func GetPrivateKeyWithSource(cCtx *cli.Context) (string, string, error) {
+	privateKey, kind, environment, err := resolvePrivateKey(cCtx)
+	if err != nil {
+		return "", "", err
+	}
+	// Keep the key out of --log-file output
+	logger.RegisterSecret(privateKey)
+
+	switch kind {
+	case keySourceFlag:
+		return privateKey, "command flag", nil
+	case keySourceEnvVar:
+		return privateKey, "environment variable", nil
+	default:
+		return privateKey, fmt.Sprintf("stored credentials (%s)", environment), nil
+	}
+}
+
+// resolvePrivateKey returns the private key commands use, the kind of source it came from and,
+// for stored keys, the environment it is stored for
+func resolvePrivateKey(cCtx *cli.Context) (string, string, string, error) {
 	// 1. Check flag first
 	if privateKey := cCtx.String(common.PrivateKeyFlag.Name); privateKey != "" {
-		return privateKey, "command flag", nil
+		return privateKey, keySourceFlag, "", nil
 	}
 
 	// 2. Check environment variable
 	if privateKey := os.Getenv(common.EigenXPrivateKeyEnvVar); privateKey != "" {
-		return privateKey, "environment variable", nil
+		return privateKey, keySourceEnvVar, "", nil
 	}
 
 	// 3. Try current environment
 	var keyringErrors []error
 	if environmentConfig, err := utils.GetEnvironmentConfig(cCtx); err == nil {
 		if privateKey, err := common.GetPrivateKey(environmentConfig.Name); err == nil {
-			return privateKey, fmt.Sprintf("stored credentials (%s)", environmentConfig.Name), nil
+			return privateKey, keySourceKeyring, environmentConfig.Name, nil
 		} else if !errors.Is(err, common.ErrKeyNotFound) {
 			keyringErrors = append(keyringErrors, fmt.Errorf("keyring error for %s: %w", environmentConfig.Name, err))
 		}
@@ -57,8 +85,8 @@ func GetPrivateKeyWithSource(cCtx *cli.Context) (string, string, error) {
   • Environment: export EIGENX_PRIVATE_KEY=YOUR_KEY`
 
 	if len(keyringErrors) > 0 {
-		return "", "", fmt.Errorf("%s\n\nKeyring issues detected:\n%v", baseMsg, keyringErrors)
+		return "", "", "", fmt.Errorf("%s\n\nKeyring issues detected:\n%v", baseMsg, keyringErrors)
 	}
 
-	return "", "", fmt.Errorf("%s", baseMsg)
+	return "", "", "", fmt.Errorf("%s", baseMsg)
 }
@@ -1,20 +1,42 @@
 package auth
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
 
+	"github.com/Layr-Labs/eigenx-cli/pkg/commands/utils"
 	"github.com/Layr-Labs/eigenx-cli/pkg/common"
 	"github.com/urfave/cli/v2"
 )
 
 var ListCommand = &cli.Command{
-	Name:   "list",
-	Usage:  "List all stored private keys by deployment environment",
-	Flags:  common.GlobalFlags,
+	Name:  "list",
+	Usage: "List all stored private keys by deployment environment",
+	Flags: append(common.GlobalFlags, []cli.Flag{
+		common.EnvironmentFlag,
+		common.PrivateKeyFlag,
+		common.JSONFlag,
+	}...),
 	Action: listAction,
 }
 
+// storedKeyInfo is a key stored in the keyring, as output by auth list --json
+type storedKeyInfo struct {
+	Environment string `json:"environment"`
+	Address     string `json:"address"`
+	Active      bool   `json:"active"`
+}
+
+// keyListInfo is the output of auth list --json. ActiveSource is where the key used in the
+// active environment comes from ("flag", "env" or "keyring"), empty when there is none.
+type keyListInfo struct {
+	ActiveEnvironment string          `json:"activeEnvironment"`
+	ActiveSource      string          `json:"activeSource"`
+	ActiveAddress     string          `json:"activeAddress"`
+	Keys              []storedKeyInfo `json:"keys"`
+}
+
 func listAction(cCtx *cli.Context) error {
 	// Get all stored keys
 	keys, err := ListStoredKeys()
@@ -22,30 +44,42 @@ func listAction(cCtx *cli.Context) error {
 		return fmt.Errorf("failed to list stored keys: %w", err)
 	}
 
+	info, err := newKeyListInfo(cCtx, keys)
+	if err != nil {
+		return err
+	}
+
+	if cCtx.Bool(common.JSONFlag.Name) {
+		encoded, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		fmt.Println(string(encoded))
+		return nil
+	}
+
 	if len(keys) == 0 {
 		fmt.Println("No keys stored in keyring")
+		printKeyOverride(info)
 		fmt.Println("")
 		fmt.Println("To store a key, use:")
 		fmt.Println("  eigenx auth login")
 		return nil
 	}
 
-	// Sort keys by name for consistent output
-	var sortedNames []string
-	for name := range keys {
-		sortedNames = append(sortedNames, name)
-	}
-	sort.Strings(sortedNames)
-
 	// Display header
 	fmt.Println("Stored private keys:")
 	fmt.Println("")
 
 	// Display each key
-	for _, name := range sortedNames {
-		address := keys[name]
-		fmt.Printf("  %-12s %s\n", name, address)
+	for _, key := range info.Keys {
+		marker := ""
+		if key.Active {
+			marker = " (active)"
+		}
+		fmt.Printf("  %-12s %s%s\n", key.Environment, key.Address, marker)
 	}
+	printKeyOverride(info)
 
 	// Show help text
 	fmt.Println("")
@@ -56,3 +90,52 @@ func listAction(cCtx *cli.Context) error {
 
 	return nil
 }
+
+// newKeyListInfo describes the stored keys, sorted by environment, and the key commands use
+func newKeyListInfo(cCtx *cli.Context, keys map[string]string) (*keyListInfo, error) {
+	environmentConfig, err := utils.GetEnvironmentConfig(cCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active deployment environment: %w", err)
+	}
+
+	info := &keyListInfo{
+		ActiveEnvironment: environmentConfig.Name,
+		Keys:              []storedKeyInfo{},
+	}
+
+	// A missing key is not an error here, the listing just has no active key
+	if privateKey, source, _, err := resolvePrivateKey(cCtx); err == nil {
+		address, err := common.GetAddressFromPrivateKey(privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid private key from %s: %w", source, err)
+		}
+		info.ActiveSource = source
+		info.ActiveAddress = address
+	}
+
+	// Sort keys by name for consistent output. A stored key is only active when commands use
+	// it, not when --private-key or the environment variable overrides it.
+	var sortedNames []string
+	for name := range keys {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+	for _, name := range sortedNames {
+		info.Keys = append(info.Keys, storedKeyInfo{
+			Environment: name,
+			Address:     keys[name],
+			Active:      name == environmentConfig.Name && info.ActiveSource == keySourceKeyring,
+		})
+	}
+	return info, nil
+}
+
+// printKeyOverride notes when a flag or environment variable key takes precedence over the keyring
+func printKeyOverride(info *keyListInfo) {
+	switch info.ActiveSource {
+	case keySourceFlag:
+		fmt.Printf("\nUsing %s from --%s, which takes precedence over stored keys\n", info.ActiveAddress, common.PrivateKeyFlag.Name)
+	case keySourceEnvVar:
+		fmt.Printf("\nUsing %s from %s, which takes precedence over stored keys\n", info.ActiveAddress, common.EigenXPrivateKeyEnvVar)
+	}
+}
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -56,7 +57,46 @@ func TestListAction(t *testing.T) {
 	t.Run("command structure", func(t *testing.T) {
 		assert.Equal(t, "list", ListCommand.Name)
 		assert.Equal(t, "List all stored private keys by deployment environment", ListCommand.Usage)
-		assert.Equal(t, common.GlobalFlags, ListCommand.Flags)
+		assert.Len(t, ListCommand.Flags, len(common.GlobalFlags)+3)
 		assert.NotNil(t, ListCommand.Action)
 	})
 }
+
+func TestListActionJSON(t *testing.T) {
+	mock := testutils.SetupMockKeyring(t)
+	require.NoError(t, mock.StorePrivateKey("sepolia", "0x1234567890123456789012345678901234567890123456789012345678901234"))
+
+	run := func(args ...string) keyListInfo {
+		app, _ := testutils.CreateTestAppWithNoopLoggerAndAccess("test-app", ListCommand.Flags, func(cCtx *cli.Context) error {
+			return listAction(cCtx)
+		})
+
+		stdout, _ := testutils.CaptureOutput(func() {
+			err := app.Run(append([]string{"test-app", "--json", "--environment", "sepolia"}, args...))
+			require.NoError(t, err)
+		})
+
+		var info keyListInfo
+		require.NoError(t, json.Unmarshal([]byte(stdout), &info))
+		return info
+	}
+
+	t.Run("stored key", func(t *testing.T) {
+		t.Setenv(common.EigenXPrivateKeyEnvVar, "")
+
+		info := run()
+		assert.Equal(t, "sepolia", info.ActiveEnvironment)
+		assert.Equal(t, "keyring", info.ActiveSource)
+		assert.Equal(t, "0x2e988A386a799F506693793c6A5AF6B54dfAaBfB", info.ActiveAddress)
+		assert.Equal(t, []storedKeyInfo{{Environment: "sepolia", Address: "0x2e988A386a799F506693793c6A5AF6B54dfAaBfB", Active: true}}, info.Keys)
+	})
+
+	t.Run("environment variable takes precedence", func(t *testing.T) {
+		t.Setenv(common.EigenXPrivateKeyEnvVar, "0x1234567890123456789012345678901234567890123456789012345678901234")
+
+		info := run()
+		assert.Equal(t, "env", info.ActiveSource)
+		assert.Equal(t, "0x2e988A386a799F506693793c6A5AF6B54dfAaBfB", info.ActiveAddress)
+		assert.Equal(t, []storedKeyInfo{{Environment: "sepolia", Address: "0x2e988A386a799F506693793c6A5AF6B54dfAaBfB", Active: false}}, info.Keys)
+	})
+}
@@ -12,5 +12,6 @@ var BillingCommand = &cli.Command{
 		billing.SubscribeCommand,
 		billing.CancelCommand,
 		billing.StatusCommand,
+		billing.UnsuspendCommand,
 	},
 }
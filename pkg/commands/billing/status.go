@@ -149,7 +149,7 @@ var StatusCommand = &cli.Command{
 
 // isSubscriptionActive returns true if the subscription status allows deploying apps
 func isSubscriptionActive(status utils.SubscriptionStatus) bool {
-	return status == utils.StatusActive || status == utils.StatusTrialing
+	return status.IsActive()
 }
 
 func formatStatus(status utils.SubscriptionStatus) string {
@@ -0,0 +1,68 @@
+package billing
+
+import (
+	"fmt"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/commands/utils"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+// UnsuspendCommand restores a suspended account. The AppController only accepts
+// quota changes from its admin, so this command is hidden from regular help output.
+var UnsuspendCommand = &cli.Command{
+	Name:      "unsuspend",
+	Usage:     "Restore an account's app quota and restart its suspended apps (admin only)",
+	ArgsUsage: "<account-address>",
+	Hidden:    true,
+	Flags: append(common.GlobalFlags, []cli.Flag{
+		common.EnvironmentFlag,
+		common.RpcUrlFlag,
+		common.PrivateKeyFlag,
+		&cli.UintFlag{
+			Name:     "max-apps",
+			Usage:    "Maximum number of active apps to allow for the account",
+			Required: true,
+		},
+	}...),
+	Action: func(cCtx *cli.Context) error {
+		logger := common.LoggerFromContext(cCtx)
+
+		if cCtx.NArg() != 1 {
+			return fmt.Errorf("expected exactly one account address")
+		}
+		accountArg := cCtx.Args().First()
+		if !ethcommon.IsHexAddress(accountArg) {
+			return fmt.Errorf("invalid account address: %s", accountArg)
+		}
+		account := ethcommon.HexToAddress(accountArg)
+
+		maxApps := cCtx.Uint("max-apps")
+		if maxApps == 0 {
+			return fmt.Errorf("--max-apps must be greater than 0")
+		}
+
+		preflightCtx, err := utils.DoPreflightChecks(cCtx)
+		if err != nil {
+			return err
+		}
+
+		restarted, err := preflightCtx.Caller.Unsuspend(cCtx.Context, account, uint32(maxApps))
+		if err != nil {
+			return fmt.Errorf("failed to unsuspend account: %w", err)
+		}
+
+		logger.Info("✓ Quota for %s restored to %d", account.Hex(), maxApps)
+		if len(restarted) == 0 {
+			logger.Info("No suspended apps to restart")
+			return nil
+		}
+
+		logger.Info("Restarted %d app(s):", len(restarted))
+		for _, app := range restarted {
+			logger.Info("  • %s", common.FormatAppDisplay(preflightCtx.EnvironmentConfig.Name, app, ""))
+		}
+		return nil
+	},
+}
@@ -0,0 +1,288 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/commands/auth"
+	"github.com/Layr-Labs/eigenx-cli/pkg/commands/utils"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/docker/docker/client"
+	"github.com/urfave/cli/v2"
+)
+
+const doctorCheckTimeout = 5 * time.Second
+
+// DoctorCheckStatus is the outcome of a single doctor check
+type DoctorCheckStatus string
+
+const (
+	DoctorCheckPass DoctorCheckStatus = "pass"
+	DoctorCheckWarn DoctorCheckStatus = "warn"
+	DoctorCheckFail DoctorCheckStatus = "fail"
+)
+
+// DoctorCheckResult is the result of a single doctor check, as emitted by --output json
+type DoctorCheckResult struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Status      DoctorCheckStatus `json:"status"`
+	Critical    bool              `json:"critical"`
+	Detail      string            `json:"detail"`
+	Remediation string            `json:"remediation,omitempty"`
+}
+
+// DoctorReport is the full set of check results
+type DoctorReport struct {
+	Checks []DoctorCheckResult `json:"checks"`
+	OK     bool                `json:"ok"`
+}
+
+// doctorCheck describes a prerequisite check. run returns the status, a detail
+// message and, for non-passing checks, a remediation hint.
+type doctorCheck struct {
+	id       string
+	name     string
+	critical bool
+	run      func(cCtx *cli.Context) (DoctorCheckStatus, string, string)
+}
+
+// DoctorCommand checks that the local environment is ready to build and deploy apps
+var DoctorCommand = &cli.Command{
+	Name:  "doctor",
+	Usage: "Diagnose environment and prerequisites",
+	Flags: append(common.GlobalFlags, []cli.Flag{
+		common.EnvironmentFlag,
+		common.RpcUrlFlag,
+		common.PrivateKeyFlag,
+		common.RegistryAuthFileFlag,
+		common.JSONFlagWithUsage("Output each check's id, status, detail and remediation as JSON"),
+	}...),
+	Action: doctorAction,
+}
+
+func doctorAction(cCtx *cli.Context) error {
+	report := runDoctorChecks(cCtx, doctorChecks())
+
+	if cCtx.Bool(common.JSONFlag.Name) {
+		encoded, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode doctor report: %w", err)
+		}
+		fmt.Println(string(encoded))
+	} else {
+		printDoctorReport(report)
+	}
+
+	if !report.OK {
+		return fmt.Errorf("one or more critical checks failed")
+	}
+	return nil
+}
+
+// doctorChecks returns the checks run by the doctor command, in display order
+func doctorChecks() []doctorCheck {
+	return []doctorCheck{
+		{id: "docker-daemon", name: "Docker daemon reachable", critical: true, run: checkDockerDaemon},
+		{id: "docker-buildx", name: "Docker buildx available", critical: true, run: checkDockerBuildx},
+		{id: "docker-platform", name: "Docker builds " + utils.TEEPlatform.String(), critical: false, run: checkDockerPlatform},
+		{id: "registry-auth", name: "Container registry authenticated", critical: true, run: checkRegistryAuth},
+		{id: "private-key", name: "Private key configured", critical: true, run: checkPrivateKey},
+		{id: "rpc", name: "RPC endpoint reachable", critical: true, run: checkRPC},
+		{id: "kms-keys", name: "KMS keys available", critical: true, run: checkKMSKeys},
+		{id: "user-api", name: "EigenX API reachable", critical: true, run: checkUserAPI},
+	}
+}
+
+// runDoctorChecks runs every check and marks the report as failed if any critical check fails
+func runDoctorChecks(cCtx *cli.Context, checks []doctorCheck) DoctorReport {
+	report := DoctorReport{OK: true}
+	for _, check := range checks {
+		status, detail, remediation := check.run(cCtx)
+		if status == DoctorCheckPass {
+			remediation = ""
+		}
+		report.Checks = append(report.Checks, DoctorCheckResult{
+			ID:          check.id,
+			Name:        check.name,
+			Status:      status,
+			Critical:    check.critical,
+			Detail:      detail,
+			Remediation: remediation,
+		})
+		if check.critical && status == DoctorCheckFail {
+			report.OK = false
+		}
+	}
+	return report
+}
+
+func printDoctorReport(report DoctorReport) {
+	fmt.Println()
+	for _, check := range report.Checks {
+		icon := "✅"
+		switch check.Status {
+		case DoctorCheckWarn:
+			icon = "⚠️ "
+		case DoctorCheckFail:
+			icon = "❌"
+		}
+		fmt.Printf("%s %s: %s\n", icon, check.Name, check.Detail)
+		if check.Remediation != "" {
+			fmt.Printf("   → %s\n", check.Remediation)
+		}
+	}
+	fmt.Println()
+}
+
+func checkDockerDaemon(cCtx *cli.Context) (DoctorCheckStatus, string, string) {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return DoctorCheckFail, "docker is not installed", "Install Docker Desktop from https://www.docker.com/products/docker-desktop"
+	}
+
+	dockerClient, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return DoctorCheckFail, fmt.Sprintf("failed to create docker client: %v", err), "Check your DOCKER_HOST configuration"
+	}
+	defer dockerClient.Close()
+
+	ctx, cancel := context.WithTimeout(cCtx.Context, doctorCheckTimeout)
+	defer cancel()
+
+	ping, err := dockerClient.Ping(ctx)
+	if err != nil {
+		return DoctorCheckFail, fmt.Sprintf("docker daemon not reachable: %v", err), "Start Docker Desktop (or the docker service) and try again"
+	}
+	return DoctorCheckPass, fmt.Sprintf("API version %s", ping.APIVersion), ""
+}
+
+func checkDockerBuildx(cCtx *cli.Context) (DoctorCheckStatus, string, string) {
+	ctx, cancel := context.WithTimeout(cCtx.Context, doctorCheckTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "docker", "buildx", "version").Output()
+	if err != nil {
+		return DoctorCheckFail, "docker buildx is not available", "Install the buildx plugin: https://docs.docker.com/build/install-buildx/"
+	}
+	return DoctorCheckPass, strings.TrimSpace(string(out)), ""
+}
+
+func checkDockerPlatform(cCtx *cli.Context) (DoctorCheckStatus, string, string) {
+	dockerClient, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	if err != nil {
+		return DoctorCheckWarn, fmt.Sprintf("failed to create docker client: %v", err), "Check your DOCKER_HOST configuration"
+	}
+	defer dockerClient.Close()
+
+	ctx, cancel := context.WithTimeout(cCtx.Context, doctorCheckTimeout)
+	defer cancel()
+
+	info, err := dockerClient.Info(ctx)
+	if err != nil {
+		return DoctorCheckWarn, fmt.Sprintf("failed to query docker daemon: %v", err), "Start Docker Desktop (or the docker service) and try again"
+	}
+	platform := daemonPlatform(info.OSType, info.Architecture)
+	if !platform.Matches(utils.TEEPlatform) {
+		return DoctorCheckWarn, fmt.Sprintf("daemon runs %s; apps are built for %s under emulation", platform, utils.TEEPlatform), fmt.Sprintf("Expect slower builds; check 'docker buildx ls' lists %s for your builder", utils.TEEPlatform)
+	}
+	return DoctorCheckPass, platform.String(), ""
+}
+
+// daemonPlatform returns the platform of a Docker daemon, whose info reports the kernel's
+// architecture name (e.g. x86_64) rather than the OCI one (amd64)
+func daemonPlatform(osType, arch string) utils.Platform {
+	switch arch {
+	case "x86_64":
+		arch = utils.AMD64Arch
+	case "aarch64":
+		arch = utils.ARM64Arch
+	}
+	return utils.Platform{OS: osType, Arch: arch}
+}
+
+func checkRegistryAuth(cCtx *cli.Context) (DoctorCheckStatus, string, string) {
+	registries, err := utils.AuthenticatedRegistries(cCtx.String(common.RegistryAuthFileFlag.Name))
+	if err != nil {
+		return DoctorCheckFail, fmt.Sprintf("failed to read docker credentials: %v", err), "Check ~/.docker/config.json (or --registry-auth-file) is valid JSON"
+	}
+	if len(registries) == 0 {
+		return DoctorCheckFail, "no registry credentials found", "Log in to the registry you deploy to, e.g. 'docker login' or 'docker login ghcr.io'"
+	}
+	return DoctorCheckPass, strings.Join(registries, ", "), ""
+}
+
+func checkPrivateKey(cCtx *cli.Context) (DoctorCheckStatus, string, string) {
+	privateKey, source, err := auth.GetPrivateKeyWithSource(cCtx)
+	if err != nil {
+		return DoctorCheckFail, "no private key found", fmt.Sprintf("Run 'eigenx auth login', pass --private-key, or set %s", common.EigenXPrivateKeyEnvVar)
+	}
+
+	address, err := common.GetAddressFromPrivateKey(privateKey)
+	if err != nil {
+		return DoctorCheckFail, fmt.Sprintf("private key from %s is invalid", source), "Run 'eigenx auth login' to store a valid key"
+	}
+	return DoctorCheckPass, fmt.Sprintf("%s (from %s)", address, source), ""
+}
+
+func checkRPC(cCtx *cli.Context) (DoctorCheckStatus, string, string) {
+	environmentConfig, err := utils.GetEnvironmentConfig(cCtx)
+	if err != nil {
+		return DoctorCheckFail, fmt.Sprintf("failed to resolve environment: %v", err), "Run 'eigenx environment set <env>' to choose an environment"
+	}
+
+	ethClient, err := utils.DialRPC(cCtx, &environmentConfig)
+	if err != nil {
+		return DoctorCheckFail, err.Error(), "Pass --rpc-url or set EIGENX_RPC_URL"
+	}
+	defer ethClient.Close()
+
+	ctx, cancel := context.WithTimeout(cCtx.Context, doctorCheckTimeout)
+	defer cancel()
+
+	// Every URL is tried in order; the error is the last endpoint's
+	chainID, err := ethClient.ChainID(ctx)
+	if err != nil {
+		return DoctorCheckFail, fmt.Sprintf("failed to query chain ID from %s: %v", ethClient.Endpoint(), err), "Check your --rpc-url or network connection, and that it is a working Ethereum JSON-RPC URL"
+	}
+	return DoctorCheckPass, fmt.Sprintf("%s (chain ID %s, environment %s)", ethClient.Endpoint(), chainID, environmentConfig.Name), ""
+}
+
+func checkKMSKeys(cCtx *cli.Context) (DoctorCheckStatus, string, string) {
+	environmentConfig, err := utils.GetEnvironmentConfig(cCtx)
+	if err != nil {
+		return DoctorCheckFail, fmt.Sprintf("failed to resolve environment: %v", err), "Run 'eigenx environment set <env>' to choose an environment"
+	}
+
+	// The KMS server is only reachable from inside the TEE; the CLI encrypts with bundled public keys
+	if err := utils.CheckKMSKeys(environmentConfig.Name); err != nil {
+		return DoctorCheckFail, fmt.Sprintf("no KMS keys for environment %s in this %s build", environmentConfig.Name, common.Build), "Switch environments with 'eigenx environment set <env>' or install the matching eigenx build"
+	}
+	return DoctorCheckPass, fmt.Sprintf("environment %s", environmentConfig.Name), ""
+}
+
+func checkUserAPI(cCtx *cli.Context) (DoctorCheckStatus, string, string) {
+	environmentConfig, err := utils.GetEnvironmentConfig(cCtx)
+	if err != nil {
+		return DoctorCheckFail, fmt.Sprintf("failed to resolve environment: %v", err), "Run 'eigenx environment set <env>' to choose an environment"
+	}
+
+	ctx, cancel := context.WithTimeout(cCtx.Context, doctorCheckTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, environmentConfig.UserApiServerURL, nil)
+	if err != nil {
+		return DoctorCheckFail, fmt.Sprintf("invalid API URL %s: %v", environmentConfig.UserApiServerURL, err), "Run 'eigenx environment set <env>' to choose a valid environment"
+	}
+	// Any HTTP response means the server is reachable; only transport errors fail the check
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return DoctorCheckFail, fmt.Sprintf("failed to reach %s: %v", environmentConfig.UserApiServerURL, err), "Check your network connection, proxy or firewall settings"
+	}
+	resp.Body.Close()
+	return DoctorCheckPass, environmentConfig.UserApiServerURL, ""
+}
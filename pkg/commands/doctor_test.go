@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/commands/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func stubCheck(id string, critical bool, status DoctorCheckStatus) doctorCheck {
+	return doctorCheck{
+		id:       id,
+		name:     id,
+		critical: critical,
+		run: func(cCtx *cli.Context) (DoctorCheckStatus, string, string) {
+			return status, "detail for " + id, "fix " + id
+		},
+	}
+}
+
+func TestRunDoctorChecks(t *testing.T) {
+	tests := []struct {
+		name   string
+		checks []doctorCheck
+		wantOK bool
+	}{
+		{
+			name:   "all pass",
+			checks: []doctorCheck{stubCheck("a", true, DoctorCheckPass), stubCheck("b", false, DoctorCheckPass)},
+			wantOK: true,
+		},
+		{
+			name:   "non-critical failure and warning",
+			checks: []doctorCheck{stubCheck("a", false, DoctorCheckFail), stubCheck("b", true, DoctorCheckWarn)},
+			wantOK: true,
+		},
+		{
+			name:   "critical failure",
+			checks: []doctorCheck{stubCheck("a", true, DoctorCheckPass), stubCheck("b", true, DoctorCheckFail)},
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := runDoctorChecks(nil, tt.checks)
+			assert.Equal(t, tt.wantOK, report.OK)
+			assert.Len(t, report.Checks, len(tt.checks))
+		})
+	}
+}
+
+func TestRunDoctorChecks_JSONShape(t *testing.T) {
+	report := runDoctorChecks(nil, []doctorCheck{
+		stubCheck("docker-daemon", true, DoctorCheckPass),
+		stubCheck("rpc", true, DoctorCheckFail),
+	})
+
+	encoded, err := json.Marshal(report)
+	require.NoError(t, err)
+
+	var decoded struct {
+		OK     bool `json:"ok"`
+		Checks []map[string]any
+	}
+	require.NoError(t, json.Unmarshal(encoded, &decoded))
+
+	assert.False(t, decoded.OK)
+	require.Len(t, decoded.Checks, 2)
+
+	assert.Equal(t, "docker-daemon", decoded.Checks[0]["id"])
+	assert.Equal(t, "pass", decoded.Checks[0]["status"])
+	assert.NotContains(t, decoded.Checks[0], "remediation", "passing checks omit remediation")
+
+	assert.Equal(t, "rpc", decoded.Checks[1]["id"])
+	assert.Equal(t, "fail", decoded.Checks[1]["status"])
+	assert.Equal(t, "detail for rpc", decoded.Checks[1]["detail"])
+	assert.Equal(t, "fix rpc", decoded.Checks[1]["remediation"])
+	assert.Equal(t, true, decoded.Checks[1]["critical"])
+}
+
+func TestDoctorChecks(t *testing.T) {
+	var ids []string
+	critical := map[string]bool{}
+	for _, check := range doctorChecks() {
+		ids = append(ids, check.id)
+		critical[check.id] = check.critical
+	}
+
+	assert.Equal(t, []string{"docker-daemon", "docker-buildx", "docker-platform", "registry-auth", "private-key", "rpc", "kms-keys", "user-api"}, ids)
+	assert.False(t, critical["docker-platform"], "emulated builds still work, so the platform check only warns")
+	assert.True(t, critical["registry-auth"])
+	assert.True(t, critical["user-api"])
+}
+
+func TestDaemonPlatform(t *testing.T) {
+	assert.True(t, daemonPlatform("linux", "x86_64").Matches(utils.TEEPlatform))
+	assert.True(t, daemonPlatform("linux", "amd64").Matches(utils.TEEPlatform))
+	assert.Equal(t, "linux/arm64", daemonPlatform("linux", "aarch64").String())
+	assert.False(t, daemonPlatform("windows", "x86_64").Matches(utils.TEEPlatform))
+}
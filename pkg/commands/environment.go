@@ -11,7 +11,9 @@ var EnvironmentCommand = &cli.Command{
 	Usage:   "Manage deployment environment",
 	Subcommands: []*cli.Command{
 		environment.SetCommand,
+		environment.SetRPCCommand,
 		environment.ListCommand,
 		environment.ShowCommand,
+		environment.CurrentCommand,
 	},
 }
@@ -0,0 +1,90 @@
+package environment
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/commands/utils"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/urfave/cli/v2"
+)
+
+// environmentInfo is the machine-readable form of an environment config
+type environmentInfo struct {
+	Name                        string `json:"name"`
+	Description                 string `json:"description"`
+	ChainID                     uint64 `json:"chainId,omitempty"`
+	Active                      bool   `json:"active"`
+	AppControllerAddress        string `json:"appControllerAddress"`
+	PermissionControllerAddress string `json:"permissionControllerAddress"`
+	ERC7702DelegatorAddress     string `json:"erc7702DelegatorAddress"`
+	KMSServerURL                string `json:"kmsServerUrl"`
+	UserApiServerURL            string `json:"userApiServerUrl"`
+	DefaultRPCURL               string `json:"defaultRpcUrl"`
+	SavedRPCURL                 string `json:"savedRpcUrl,omitempty"`
+}
+
+func newEnvironmentInfo(config common.EnvironmentConfig, active bool) environmentInfo {
+	chainID, _ := common.ChainIDForEnvironment(config.Name)
+	return environmentInfo{
+		Name:                        config.Name,
+		Description:                 utils.GetEnvironmentDescription(config.Name, config.Name, false),
+		ChainID:                     chainID,
+		Active:                      active,
+		AppControllerAddress:        config.AppControllerAddress.Hex(),
+		PermissionControllerAddress: config.PermissionControllerAddress.Hex(),
+		ERC7702DelegatorAddress:     config.ERC7702DelegatorAddress.Hex(),
+		KMSServerURL:                config.KMSServerURL,
+		UserApiServerURL:            config.UserApiServerURL,
+		DefaultRPCURL:               config.DefaultRPCURL,
+		SavedRPCURL:                 common.GetRPCURLOverride(config.Name),
+	}
+}
+
+func printJSON(v any) error {
+	encoded, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	fmt.Println(string(encoded))
+	return nil
+}
+
+var CurrentCommand = &cli.Command{
+	Name:  "current",
+	Usage: "Show the resolved configuration of the active deployment environment",
+	Flags: []cli.Flag{
+		common.EnvironmentFlag,
+		common.RpcUrlFlag,
+		common.JSONFlag,
+	},
+	Action: func(cCtx *cli.Context) error {
+		logger := common.LoggerFromContext(cCtx)
+
+		envConfig, err := utils.GetEnvironmentConfig(cCtx)
+		if err != nil {
+			return fmt.Errorf("failed to get environment config: %w", err)
+		}
+		info := newEnvironmentInfo(envConfig, true)
+
+		if cCtx.Bool(common.JSONFlag.Name) {
+			return printJSON(info)
+		}
+
+		logger.Info("Environment:           %s (%s)", info.Name, info.Description)
+		if info.ChainID != 0 {
+			logger.Info("Chain ID:              %d", info.ChainID)
+		}
+		logger.Info("AppController:         %s", info.AppControllerAddress)
+		logger.Info("PermissionController:  %s", info.PermissionControllerAddress)
+		logger.Info("ERC7702 Delegator:     %s", info.ERC7702DelegatorAddress)
+		logger.Info("User API:              %s", info.UserApiServerURL)
+		logger.Info("KMS:                   %s", info.KMSServerURL)
+		logger.Info("Default RPC:           %s", info.DefaultRPCURL)
+		if info.SavedRPCURL != "" {
+			logger.Info("Saved RPC:             %s (used unless --rpc-url is given)", info.SavedRPCURL)
+		}
+
+		return nil
+	},
+}
@@ -11,6 +11,9 @@ import (
 var ListCommand = &cli.Command{
 	Name:  "list",
 	Usage: "List available deployment environments",
+	Flags: []cli.Flag{
+		common.JSONFlagWithUsage("Output all environment configurations as JSON"),
+	},
 	Action: func(cCtx *cli.Context) error {
 		logger := common.LoggerFromContext(cCtx)
 
@@ -20,10 +23,21 @@ var ListCommand = &cli.Command{
 			return fmt.Errorf("failed to get active deployment environment: %w", err)
 		}
 
+		names := common.EnvironmentNames()
+
+		if cCtx.Bool(common.JSONFlag.Name) {
+			infos := make([]environmentInfo, 0, len(names))
+			for _, name := range names {
+				infos = append(infos, newEnvironmentInfo(common.EnvironmentConfigs[name], name == currentEnvConfig.Name))
+			}
+			return printJSON(infos)
+		}
+
 		logger.Info("Available deployment environments:")
 
 		// List all available deployment environments from EnvironmentConfigs
-		for name, config := range common.EnvironmentConfigs {
+		for _, name := range names {
+			config := common.EnvironmentConfigs[name]
 			marker := ""
 			if name == currentEnvConfig.Name {
 				marker = " (active)"
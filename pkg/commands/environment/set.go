@@ -1,13 +1,18 @@
 package environment
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/Layr-Labs/eigenx-cli/pkg/commands/utils"
 	"github.com/Layr-Labs/eigenx-cli/pkg/common"
 	"github.com/urfave/cli/v2"
 )
 
+// environmentProbeTimeout bounds the --validate connectivity check
+const environmentProbeTimeout = 10 * time.Second
+
 var SetCommand = &cli.Command{
 	Name:      "set",
 	Usage:     "Set deployment environment",
@@ -17,6 +22,11 @@ var SetCommand = &cli.Command{
 			Name:  "yes",
 			Usage: "Skip confirmation prompts (for automation)",
 		},
+		&cli.BoolFlag{
+			Name:  "validate",
+			Usage: "Check that the environment's RPC endpoint is reachable and on the expected chain before saving",
+		},
+		common.RpcUrlFlag,
 	},
 	Action: func(cCtx *cli.Context) error {
 		logger := common.LoggerFromContext(cCtx)
@@ -31,8 +41,25 @@ var SetCommand = &cli.Command{
 		}
 
 		// Validate that the environment exists
-		if _, exists := common.EnvironmentConfigs[newEnv]; !exists {
-			return fmt.Errorf("unknown environment: %s\nRun 'eigenx environment list' to see available environments", newEnv)
+		if err := common.ValidateEnvironmentName(newEnv); err != nil {
+			return fmt.Errorf("%w\nRun 'eigenx environment list' to see available environments", err)
+		}
+
+		// Optionally make sure the environment is usable before saving it
+		if cCtx.Bool("validate") {
+			envConfig := common.EnvironmentConfigs[newEnv]
+			rpcURL, err := utils.GetRPCURL(cCtx, &envConfig)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(cCtx.Context, environmentProbeTimeout)
+			defer cancel()
+
+			logger.Info("Checking %s...", rpcURL)
+			if err := utils.ProbeEnvironment(ctx, envConfig, rpcURL); err != nil {
+				return fmt.Errorf("environment %s failed validation: %w", newEnv, err)
+			}
 		}
 
 		// Check if this is mainnet and requires confirmation
@@ -0,0 +1,93 @@
+package environment
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/commands/utils"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/urfave/cli/v2"
+)
+
+var SetRPCCommand = &cli.Command{
+	Name:      "set-rpc",
+	Usage:     "Save the RPC URL to use for an environment when --rpc-url is not given",
+	ArgsUsage: "<environment> [rpc-url]",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "clear",
+			Usage: "Remove the saved RPC URL and go back to the environment default",
+		},
+		&cli.BoolFlag{
+			Name:  "validate",
+			Usage: "Check that the RPC endpoint is reachable and on the expected chain before saving",
+		},
+	},
+	Action: func(cCtx *cli.Context) error {
+		logger := common.LoggerFromContext(cCtx)
+
+		env := cCtx.Args().Get(0)
+		if env == "" {
+			return fmt.Errorf("environment required. Usage: eigenx environment set-rpc <environment> <rpc-url>")
+		}
+		if err := common.ValidateEnvironmentName(env); err != nil {
+			return fmt.Errorf("%w\nRun 'eigenx environment list' to see available environments", err)
+		}
+
+		if cCtx.Bool("clear") {
+			if cCtx.NArg() > 1 {
+				return fmt.Errorf("--clear does not take an RPC URL")
+			}
+			if err := common.SetRPCURLOverride(env, ""); err != nil {
+				return fmt.Errorf("failed to clear RPC URL: %w", err)
+			}
+			logger.Info("✅ Cleared the saved RPC URL for %s; using %s", env, common.EnvironmentConfigs[env].DefaultRPCURL)
+			return nil
+		}
+
+		if cCtx.NArg() != 2 {
+			return fmt.Errorf("expected an environment and an RPC URL. Usage: eigenx environment set-rpc <environment> <rpc-url>")
+		}
+		rpcURL := cCtx.Args().Get(1)
+		if err := validateRPCURLs(rpcURL); err != nil {
+			return err
+		}
+
+		if cCtx.Bool("validate") {
+			ctx, cancel := context.WithTimeout(cCtx.Context, environmentProbeTimeout)
+			defer cancel()
+
+			firstURL := strings.TrimSpace(strings.Split(rpcURL, ",")[0])
+			logger.Info("Checking %s...", firstURL)
+			if err := utils.ProbeEnvironment(ctx, common.EnvironmentConfigs[env], firstURL); err != nil {
+				return fmt.Errorf("RPC URL failed validation for %s: %w", env, err)
+			}
+		}
+
+		if err := common.SetRPCURLOverride(env, rpcURL); err != nil {
+			return fmt.Errorf("failed to save RPC URL: %w", err)
+		}
+
+		logger.Info("✅ RPC URL for %s set to %s", env, rpcURL)
+		return nil
+	},
+}
+
+// validateRPCURLs checks each comma-separated URL is an absolute http(s) or ws(s) URL
+func validateRPCURLs(value string) error {
+	for _, rpcURL := range strings.Split(value, ",") {
+		rpcURL = strings.TrimSpace(rpcURL)
+		parsed, err := url.Parse(rpcURL)
+		if err != nil || parsed.Host == "" {
+			return fmt.Errorf("invalid RPC URL %q: expected an absolute URL such as https://rpc.example.com", rpcURL)
+		}
+		switch parsed.Scheme {
+		case "http", "https", "ws", "wss":
+		default:
+			return fmt.Errorf("invalid RPC URL %q: scheme must be http, https, ws or wss", rpcURL)
+		}
+	}
+	return nil
+}
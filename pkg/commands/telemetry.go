@@ -26,6 +26,9 @@ var TelemetryCommand = &cli.Command{
 			Usage: "Show current telemetry status",
 		},
 	},
+	Subcommands: []*cli.Command{
+		TelemetryStatusCommand,
+	},
 	Action: func(cCtx *cli.Context) error {
 		logger := common.LoggerFromContext(cCtx)
 
@@ -39,7 +42,7 @@ var TelemetryCommand = &cli.Command{
 		}
 
 		if status {
-			return showTelemetryStatus(logger)
+			return showTelemetryStatus(cCtx, logger)
 		}
 
 		if enable {
@@ -71,8 +74,34 @@ func displayGlobalTelemetryStatus(logger iface.Logger, prefix string) error {
 	return nil
 }
 
-func showTelemetryStatus(logger iface.Logger) error {
-	return displayGlobalTelemetryStatus(logger, "Telemetry")
+// TelemetryStatusCommand shows the telemetry preference and the stored user ID
+var TelemetryStatusCommand = &cli.Command{
+	Name:  "status",
+	Usage: "Show whether telemetry is enabled and the stored user ID",
+	Action: func(cCtx *cli.Context) error {
+		return showTelemetryStatus(cCtx, common.LoggerFromContext(cCtx))
+	},
+}
+
+func showTelemetryStatus(cCtx *cli.Context, logger iface.Logger) error {
+	if err := displayGlobalTelemetryStatus(logger, "Telemetry"); err != nil {
+		return err
+	}
+
+	globalConfig, err := common.LoadGlobalConfig()
+	if err != nil {
+		return fmt.Errorf("failed to load global config: %w", err)
+	}
+	if globalConfig != nil && globalConfig.UserUUID != "" {
+		logger.Info("User ID: %s", globalConfig.UserUUID)
+	} else {
+		logger.Info("User ID: Not set")
+	}
+
+	if cCtx.Bool("no-telemetry") {
+		logger.Info("Disabled for this invocation (--no-telemetry)")
+	}
+	return nil
 }
 
 func enableTelemetry(logger iface.Logger) error {
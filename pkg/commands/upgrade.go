@@ -5,6 +5,9 @@ import (
 	"archive/zip"
 	"bytes"
 	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -30,12 +33,26 @@ var UpgradeCommand = &cli.Command{
 			Usage: "Version to upgrade to (e.g. v0.0.8)",
 			Value: "latest",
 		},
+		&cli.BoolFlag{
+			Name:  "skip-checksum",
+			Usage: "Install without verifying the archive against its published SHA-256 checksum (emergencies only)",
+		},
+		&cli.BoolFlag{
+			Name:  "rollback",
+			Usage: "Swap the binary saved by the last upgrade (" + backupBinaryName + ") back into place",
+		},
 	}, common.GlobalFlags...),
 	Action: func(cCtx *cli.Context) error {
+		if cCtx.Bool("rollback") {
+			return RollbackEigenX(cCtx)
+		}
 		return UpgradeEigenX(cCtx)
 	},
 }
 
+// backupBinaryName is the file, next to the installed binary, that upgrades save the previous binary to
+const backupBinaryName = "eigenx.bak"
+
 // UpgradeEigenX resolves the latest version if needed and invokes PerformUpgrade to install the new version
 func UpgradeEigenX(cCtx *cli.Context) error {
 	logger := common.LoggerFromContext(cCtx)
@@ -70,7 +87,35 @@ func UpgradeEigenX(cCtx *cli.Context) error {
 	}
 
 	// Determine install location
+	binDir, err := locateBinDir()
+	if err != nil {
+		return err
+	}
+
+	// Perform the upgrade and source
+	return PerformUpgrade(targetVersion, binDir, logger, cCtx.Bool("skip-checksum"))
+}
+
+// RollbackEigenX swaps the binary saved by the last upgrade back into place
+func RollbackEigenX(cCtx *cli.Context) error {
+	logger := common.LoggerFromContext(cCtx)
+
+	binDir, err := locateBinDir()
+	if err != nil {
+		return err
+	}
+	if err := rollbackBinary(binDir); err != nil {
+		return err
+	}
+
+	logger.Info("Rolled back %s to the previous version; the replaced binary is now %s", filepath.Join(binDir, binaryName()), backupBinaryName)
+	return nil
+}
+
+// locateBinDir returns the directory of the eigenx binary on PATH
+func locateBinDir() (string, error) {
 	var path string
+	var err error
 
 	// Try to locate the current eigenx binary, considering Windows .exe extension
 	if runtime.GOOS == "windows" {
@@ -84,17 +129,28 @@ func UpgradeEigenX(cCtx *cli.Context) error {
 	}
 
 	if err != nil {
-		return fmt.Errorf("could not locate current eigenx binary: %w", err)
+		return "", fmt.Errorf("could not locate current eigenx binary: %w", err)
 	}
-	binDir := filepath.Dir(path)
+	return filepath.Dir(path), nil
+}
 
-	// Perform the upgrade and source
-	return PerformUpgrade(targetVersion, binDir, logger)
+// binaryName is the file name of the eigenx binary on this platform
+func binaryName() string {
+	if runtime.GOOS == "windows" {
+		return "eigenx.exe"
+	}
+	return "eigenx"
 }
 
+// errChecksumNotPublished is returned by fetchChecksum when a release has no checksum file,
+// as is the case for releases published before checksums were added
+var errChecksumNotPublished = errors.New("no checksum is published")
+
 // PerformUpgrade downloads and installs the target version of the eigenx binary.
-// It supports both .tar.gz and raw .tar archive formats.
-func PerformUpgrade(version, binDir string, logger iface.Logger) error {
+// It supports both .tar.gz and raw .tar archive formats. The archive is checked against the
+// SHA-256 checksum published next to it before anything is installed, unless skipChecksum is set.
+// A release without a published checksum is installed with a warning.
+func PerformUpgrade(version, binDir string, logger iface.Logger, skipChecksum bool) error {
 	arch := strings.ToLower(runtime.GOARCH)
 	distro := strings.ToLower(runtime.GOOS)
 
@@ -114,12 +170,204 @@ func PerformUpgrade(version, binDir string, logger iface.Logger) error {
 		return fmt.Errorf("bad response from server: %s", resp.Status)
 	}
 
+	// Read the whole archive so a truncated or tampered download is caught before extracting
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to download archive: %w", err)
+	}
+
+	if skipChecksum {
+		logger.Warn("Skipping checksum verification of %s", url)
+	} else {
+		filename := url[strings.LastIndex(url, "/")+1:]
+		expected, err := fetchChecksum(common.BuildChecksumURL(url), filename)
+		switch {
+		case errors.Is(err, errChecksumNotPublished):
+			logger.Warn("No SHA-256 checksum is published for %s, installing without verifying it", url)
+		case err != nil:
+			return fmt.Errorf("%w (pass --skip-checksum to install without verifying)", err)
+		default:
+			if err := verifyChecksum(data, expected); err != nil {
+				return err
+			}
+			logger.Info("Verified SHA-256 checksum: %s", expected)
+		}
+	}
+
+	// Keep the current binary so a failed extraction or a broken release can be rolled back
+	backedUp, err := backupBinary(binDir)
+	if err != nil {
+		return err
+	}
+
 	// Extract archive based on format
 	if strings.HasSuffix(url, ".zip") || strings.Contains(resp.Header.Get("Content-Type"), "application/zip") {
-		return extractZipArchive(resp.Body, binDir, logger)
+		err = extractZipArchive(bytes.NewReader(data), binDir, logger)
+	} else {
+		err = extractTarArchive(bytes.NewReader(data), resp.Header.Get("Content-Type"), binDir, logger)
+	}
+	if err != nil && backedUp {
+		if restoreErr := restoreBinary(binDir); restoreErr != nil {
+			return fmt.Errorf("%w (also failed to restore the previous binary from %s: %v)", err, backupBinaryName, restoreErr)
+		}
+		return fmt.Errorf("%w (restored the previous binary)", err)
+	}
+	if err == nil && backedUp {
+		logger.Info("Previous binary saved as %s, run `eigenx upgrade --rollback` to restore it", filepath.Join(binDir, backupBinaryName))
+	}
+	return err
+}
+
+// backupBinary copies the installed binary in binDir to backupBinaryName, reporting whether
+// there was one to back up
+func backupBinary(binDir string) (bool, error) {
+	current := filepath.Join(binDir, binaryName())
+	if _, err := os.Stat(current); os.IsNotExist(err) {
+		return false, nil
+	}
+	if err := copyFile(current, filepath.Join(binDir, backupBinaryName)); err != nil {
+		return false, fmt.Errorf("failed to back up current binary: %w", err)
+	}
+	return true, nil
+}
+
+// restoreBinary puts the backup in binDir back in place of the installed binary, keeping the backup
+func restoreBinary(binDir string) error {
+	target := filepath.Join(binDir, binaryName())
+	staged := target + ".new"
+	if err := copyFile(filepath.Join(binDir, backupBinaryName), staged); err != nil {
+		return err
+	}
+	return replaceFile(staged, target)
+}
+
+// rollbackBinary swaps the installed binary in binDir and its backup
+func rollbackBinary(binDir string) error {
+	target := filepath.Join(binDir, binaryName())
+	backup := filepath.Join(binDir, backupBinaryName)
+	if _, err := os.Stat(backup); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no previous binary to roll back to: %s does not exist", backup)
+		}
+		return fmt.Errorf("failed to read %s: %w", backup, err)
+	}
+
+	// Stage both copies first so a failure leaves the installed binary untouched
+	staged := target + ".new"
+	if err := copyFile(backup, staged); err != nil {
+		return fmt.Errorf("failed to stage %s: %w", backup, err)
+	}
+	stagedBackup := backup + ".new"
+	if err := copyFile(target, stagedBackup); err != nil {
+		os.Remove(staged)
+		return fmt.Errorf("failed to save current binary: %w", err)
+	}
+
+	if err := replaceFile(staged, target); err != nil {
+		os.Remove(stagedBackup)
+		return fmt.Errorf("failed to restore %s: %w", backup, err)
+	}
+	if err := os.Rename(stagedBackup, backup); err != nil {
+		return fmt.Errorf("failed to save replaced binary as %s: %w", backup, err)
+	}
+	return nil
+}
+
+// copyFile copies src to dst as an executable, replacing dst
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("error opening %s: %w", src, err)
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", dst, err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return fmt.Errorf("error copying %s: %w", src, err)
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(dst)
+		return fmt.Errorf("error writing %s: %w", dst, err)
+	}
+	return os.Chmod(dst, 0755)
+}
+
+// replaceFile moves staged over target. Windows can't replace a running executable, but it can
+// rename it, so there the current file is first moved aside to <target>.old, which the next
+// replacement cleans up.
+func replaceFile(staged, target string) error {
+	if runtime.GOOS == "windows" {
+		old := target + ".old"
+		_ = os.Remove(old)
+		if err := os.Rename(target, old); err != nil && !os.IsNotExist(err) {
+			os.Remove(staged)
+			return fmt.Errorf("error moving %s aside: %w", target, err)
+		}
+	}
+	if err := os.Rename(staged, target); err != nil {
+		os.Remove(staged)
+		return fmt.Errorf("error replacing %s: %w", target, err)
+	}
+	return nil
+}
+
+// fetchChecksum downloads a published checksum file and returns the SHA-256 for filename. Both a
+// bare hash and sha256sum's "<hash>  <filename>" lines (one or several, as in SHA256SUMS) are accepted.
+func fetchChecksum(url, filename string) (string, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return "", fmt.Errorf("failed to download checksum: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// S3 answers 403 rather than 404 for a missing object in a bucket that can't be listed
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusForbidden {
+		return "", fmt.Errorf("%w at %s: %s", errChecksumNotPublished, url, resp.Status)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download checksum from %s: %s", url, resp.Status)
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to download checksum: %w", err)
 	}
-	return extractTarArchive(resp.Body, resp.Header.Get("Content-Type"), binDir, logger)
+	return parseChecksum(string(content), filename)
+}
 
+// parseChecksum finds the SHA-256 for filename in the content of a checksum file
+func parseChecksum(content, filename string) (string, error) {
+	lines := strings.FieldsFunc(content, func(r rune) bool { return r == '\n' || r == '\r' })
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		// A line without a filename applies to the archive it was published next to
+		if len(fields) == 1 || strings.TrimPrefix(fields[1], "*") == filename {
+			hash := strings.ToLower(fields[0])
+			if decoded, err := hex.DecodeString(hash); err != nil || len(decoded) != sha256.Size {
+				return "", fmt.Errorf("invalid SHA-256 checksum for %s: %q", filename, fields[0])
+			}
+			return hash, nil
+		}
+	}
+	return "", fmt.Errorf("no SHA-256 checksum published for %s", filename)
+}
+
+// verifyChecksum returns an error naming both hashes when data does not hash to expected
+func verifyChecksum(data []byte, expected string) error {
+	sum := sha256.Sum256(data)
+	actual := hex.EncodeToString(sum[:])
+	if actual != expected {
+		return fmt.Errorf("checksum mismatch for downloaded archive: expected sha256 %s, got %s; refusing to install", expected, actual)
+	}
+	return nil
 }
 
 // extractZipArchive extracts a ZIP archive to the specified directory
@@ -231,22 +479,35 @@ func validateAndResolvePath(archivePath, binDir string) (string, error) {
 	return absTargetPath, nil
 }
 
-// writeFileWithPermissions writes data from a reader to a file with executable permissions
+// writeFileWithPermissions writes data from a reader to a file with executable permissions.
+// The data is staged next to targetPath and renamed into place, so a failed write leaves the
+// existing file intact and a running binary can be replaced.
 func writeFileWithPermissions(src io.Reader, targetPath string, logger iface.Logger) error {
-	outFile, err := os.Create(targetPath)
+	staged := targetPath + ".new"
+	outFile, err := os.Create(staged)
 	if err != nil {
 		return fmt.Errorf("error creating file: %w", err)
 	}
-	defer outFile.Close()
 
 	if _, err := io.Copy(outFile, src); err != nil {
+		outFile.Close()
+		os.Remove(staged)
+		return fmt.Errorf("error writing file: %w", err)
+	}
+	if err := outFile.Close(); err != nil {
+		os.Remove(staged)
 		return fmt.Errorf("error writing file: %w", err)
 	}
 
-	if err := os.Chmod(targetPath, 0755); err != nil {
+	if err := os.Chmod(staged, 0755); err != nil {
+		os.Remove(staged)
 		return fmt.Errorf("error setting permissions: %w", err)
 	}
 
+	if err := replaceFile(staged, targetPath); err != nil {
+		return err
+	}
+
 	logger.Info("Installed: %s", targetPath)
 	return nil
 }
@@ -4,6 +4,8 @@ import (
 	"archive/tar"
 	"bytes"
 	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -14,6 +16,7 @@ import (
 	"github.com/Layr-Labs/eigenx-cli/pkg/common"
 	"github.com/Layr-Labs/eigenx-cli/pkg/common/logger"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUpgrade_PerformUpgrade(t *testing.T) {
@@ -116,3 +119,163 @@ func TestUpgrade_GetLatestVersionFromS3(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "not found")
 }
+
+func TestUpgrade_PerformUpgradeChecksum(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	content := []byte("#!/bin/sh\necho EigenX CLI upgraded\n")
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "eigenx", Mode: 0755, Size: int64(len(content))}))
+	_, err := tw.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	archive := buf.Bytes()
+
+	sum := sha256.Sum256(archive)
+	checksum := hex.EncodeToString(sum[:])
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/v0.9.0/") && strings.HasSuffix(r.URL.Path, ".sha256"):
+			// Released before checksums were published
+			w.WriteHeader(http.StatusNotFound)
+		case strings.HasSuffix(r.URL.Path, ".sha256"):
+			_, _ = w.Write([]byte(checksum + "  " + strings.TrimSuffix(filepath.Base(r.URL.Path), ".sha256") + "\n"))
+		case strings.HasPrefix(r.URL.Path, "/v1.0.0/"), strings.HasPrefix(r.URL.Path, "/v0.9.0/"):
+			w.Header().Set("Content-Type", "application/gzip")
+			_, _ = w.Write(archive)
+		case strings.HasPrefix(r.URL.Path, "/v6.6.6/"):
+			// Truncated download
+			w.Header().Set("Content-Type", "application/gzip")
+			_, _ = w.Write(archive[:len(archive)/2])
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	origBuildURL := common.BuildDownloadURL
+	common.BuildDownloadURL = func(version, arch, distro string) string {
+		return ts.URL + "/" + version + "/eigenx-cli-" + distro + "-" + arch + "-" + version + ".tar.gz"
+	}
+	defer func() { common.BuildDownloadURL = origBuildURL }()
+
+	log := logger.NewNoopLogger()
+
+	tmpDir := t.TempDir()
+	require.NoError(t, PerformUpgrade("v1.0.0", tmpDir, log, false))
+	data, err := os.ReadFile(filepath.Join(tmpDir, "eigenx"))
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+
+	// A release without a checksum is installed unverified
+	tmpDir = t.TempDir()
+	require.NoError(t, PerformUpgrade("v0.9.0", tmpDir, log, false))
+	data, err = os.ReadFile(filepath.Join(tmpDir, "eigenx"))
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+
+	tmpDir = t.TempDir()
+	err = PerformUpgrade("v6.6.6", tmpDir, log, false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "checksum mismatch for downloaded archive: expected sha256 "+checksum+", got ")
+	files, err := os.ReadDir(tmpDir)
+	require.NoError(t, err)
+	assert.Empty(t, files, "nothing should be installed on mismatch")
+}
+
+func TestUpgrade_ParseChecksum(t *testing.T) {
+	hash := strings.Repeat("ab", sha256.Size)
+	other := strings.Repeat("cd", sha256.Size)
+
+	got, err := parseChecksum(hash+"\n", "eigenx.tar.gz")
+	require.NoError(t, err)
+	assert.Equal(t, hash, got)
+
+	got, err = parseChecksum(other+"  other.tar.gz\r\n"+strings.ToUpper(hash)+" *eigenx.tar.gz\n", "eigenx.tar.gz")
+	require.NoError(t, err)
+	assert.Equal(t, hash, got)
+
+	_, err = parseChecksum(other+"  other.tar.gz\n", "eigenx.tar.gz")
+	assert.ErrorContains(t, err, "no SHA-256 checksum published for eigenx.tar.gz")
+
+	_, err = parseChecksum("not-a-hash\n", "eigenx.tar.gz")
+	assert.ErrorContains(t, err, "invalid SHA-256 checksum")
+}
+
+func TestUpgrade_BackupAndRollback(t *testing.T) {
+	binDir := t.TempDir()
+	target := filepath.Join(binDir, binaryName())
+	backup := filepath.Join(binDir, backupBinaryName)
+	log := logger.NewNoopLogger()
+
+	// Nothing to back up or roll back to on a fresh install
+	backedUp, err := backupBinary(binDir)
+	require.NoError(t, err)
+	assert.False(t, backedUp)
+	assert.ErrorContains(t, rollbackBinary(binDir), "no previous binary to roll back to")
+
+	require.NoError(t, os.WriteFile(target, []byte("old"), 0755))
+	backedUp, err = backupBinary(binDir)
+	require.NoError(t, err)
+	assert.True(t, backedUp)
+
+	require.NoError(t, writeFileWithPermissions(strings.NewReader("new"), target, log))
+	assertFile(t, target, "new")
+	assertFile(t, backup, "old")
+
+	// Rolling back swaps the two, so rolling back again undoes it
+	require.NoError(t, rollbackBinary(binDir))
+	assertFile(t, target, "old")
+	assertFile(t, backup, "new")
+	require.NoError(t, rollbackBinary(binDir))
+	assertFile(t, target, "new")
+
+	require.NoError(t, restoreBinary(binDir))
+	assertFile(t, target, "old")
+	assertFile(t, backup, "old")
+
+	files, err := os.ReadDir(binDir)
+	require.NoError(t, err)
+	assert.Len(t, files, 2, "no staged files should be left behind")
+}
+
+func TestUpgrade_PerformUpgradeRestoresOnFailure(t *testing.T) {
+	// A corrupt archive published with a matching checksum fails during extraction
+	archive := []byte("not a gzip archive")
+	sum := sha256.Sum256(archive)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ".sha256") {
+			_, _ = w.Write([]byte(hex.EncodeToString(sum[:])))
+			return
+		}
+		w.Header().Set("Content-Type", "application/gzip")
+		_, _ = w.Write(archive)
+	}))
+	defer ts.Close()
+
+	origBuildURL := common.BuildDownloadURL
+	common.BuildDownloadURL = func(version, arch, distro string) string {
+		return ts.URL + "/" + version + "/eigenx-cli-" + distro + "-" + arch + "-" + version + ".tar.gz"
+	}
+	defer func() { common.BuildDownloadURL = origBuildURL }()
+
+	binDir := t.TempDir()
+	target := filepath.Join(binDir, binaryName())
+	require.NoError(t, os.WriteFile(target, []byte("old"), 0755))
+
+	err := PerformUpgrade("v1.0.0", binDir, logger.NewNoopLogger(), false)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "restored the previous binary")
+	assertFile(t, target, "old")
+	assertFile(t, filepath.Join(binDir, backupBinaryName), "old")
+}
+
+func assertFile(t *testing.T, path, want string) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, want, string(data))
+}
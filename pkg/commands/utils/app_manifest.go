@@ -0,0 +1,128 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"gopkg.in/yaml.v3"
+)
+
+// AppManifestVersion is the schema version written to app manifests
+const AppManifestVersion = 1
+
+// AppManifest describes a deployed app well enough to deploy it again with app deploy --manifest.
+// Private env is never exported: it is encrypted for the source app and supplied separately.
+type AppManifest struct {
+	Version      int               `yaml:"version"`
+	Environment  string            `yaml:"environment"`
+	Source       string            `yaml:"source,omitempty"`
+	Name         string            `yaml:"name,omitempty"`
+	Image        string            `yaml:"image"`
+	InstanceType string            `yaml:"instance_type"`
+	PublicLogs   bool              `yaml:"public_logs"`
+	PublicEnv    map[string]string `yaml:"public_env"`
+}
+
+// NewAppManifest builds the manifest of an app's deployed release. The instance type is kept in
+// its own field rather than in the public env; a public DOMAIN stays in the public env.
+func NewAppManifest(environment string, appID gethcommon.Address, name string, deployed *DeployedRelease, instanceType string, publicLogs bool) *AppManifest {
+	publicEnv := map[string]string{}
+	for key, value := range deployed.PublicEnv {
+		publicEnv[key] = value
+	}
+	delete(publicEnv, common.EigenMachineTypeEnvVar)
+
+	return &AppManifest{
+		Version:      AppManifestVersion,
+		Environment:  environment,
+		Source:       appID.Hex(),
+		Name:         name,
+		Image:        deployed.ImageRef(),
+		InstanceType: instanceType,
+		PublicLogs:   publicLogs,
+		PublicEnv:    publicEnv,
+	}
+}
+
+// Release returns the digest-pinned image and public env the manifest deploys
+func (m *AppManifest) Release() (*DeployedRelease, error) {
+	registry, digestHex, ok := strings.Cut(m.Image, "@")
+	if !ok || registry == "" || !strings.HasPrefix(digestHex, SHA256Prefix) {
+		return nil, fmt.Errorf("image %q must be pinned by digest (registry/image@sha256:...)", m.Image)
+	}
+	digest, err := hexStringToBytes32(digestHex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image digest in %q: %w", m.Image, err)
+	}
+
+	publicEnv := map[string]string{}
+	for key, value := range m.PublicEnv {
+		publicEnv[key] = value
+	}
+	publicEnv[common.EigenMachineTypeEnvVar] = m.InstanceType
+
+	return &DeployedRelease{
+		Digest:    digest,
+		Registry:  registry,
+		PublicEnv: publicEnv,
+	}, nil
+}
+
+// Validate checks the manifest version and the fields needed to deploy it
+func (m *AppManifest) Validate() error {
+	if m.Version != AppManifestVersion {
+		return fmt.Errorf("unsupported manifest version %d (expected %d)", m.Version, AppManifestVersion)
+	}
+	if m.Environment == "" {
+		return fmt.Errorf("manifest has no environment")
+	}
+	if m.InstanceType == "" {
+		return fmt.Errorf("manifest has no instance_type")
+	}
+	if _, reserved := m.PublicEnv[common.EigenMachineTypeEnvVar]; reserved {
+		return fmt.Errorf("set the instance type with instance_type, not %s in public_env", common.EigenMachineTypeEnvVar)
+	}
+	_, err := m.Release()
+	return err
+}
+
+// WriteAppManifest writes the manifest as YAML to path, or to stdout when path is empty
+func WriteAppManifest(manifest *AppManifest, path string) error {
+	data, err := yaml.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("failed to encode app manifest: %w", err)
+	}
+	if path == "" {
+		_, err := os.Stdout.Write(data)
+		return err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write app manifest %s: %w", path, err)
+	}
+	return nil
+}
+
+// ReadAppManifest reads and validates an app manifest. Unknown fields are rejected so typos
+// don't silently drop settings.
+func ReadAppManifest(path string) (*AppManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read app manifest %s: %w", path, err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var manifest AppManifest
+	if err := decoder.Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse app manifest %s: %w", path, err)
+	}
+	if err := manifest.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid app manifest %s: %w", path, err)
+	}
+	return &manifest, nil
+}
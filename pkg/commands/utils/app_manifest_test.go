@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppManifest_RoundTrip(t *testing.T) {
+	deployed := &DeployedRelease{
+		Digest:   [32]byte{0xab, 0xcd},
+		Registry: "ghcr.io/acme/app",
+		PublicEnv: map[string]string{
+			"PORT_PUBLIC":                 "8080",
+			"DOMAIN":                      "app.example.com",
+			common.EigenMachineTypeEnvVar: "g1-standard-4t",
+		},
+	}
+	appID := gethcommon.HexToAddress("0x1234")
+
+	manifest := NewAppManifest("sepolia", appID, "my-app", deployed, "g1-standard-4t", true)
+	assert.Equal(t, "app.example.com", manifest.PublicEnv["DOMAIN"])
+	assert.NotContains(t, manifest.PublicEnv, common.EigenMachineTypeEnvVar)
+	assert.Contains(t, deployed.PublicEnv, common.EigenMachineTypeEnvVar, "the deployed release is not modified")
+
+	path := filepath.Join(t.TempDir(), "app.yaml")
+	require.NoError(t, WriteAppManifest(manifest, path))
+
+	read, err := ReadAppManifest(path)
+	require.NoError(t, err)
+	assert.Equal(t, manifest, read)
+
+	// Deploying the manifest reproduces the same image digest and public env
+	release, err := read.Release()
+	require.NoError(t, err)
+	assert.Equal(t, deployed, release)
+	assert.Equal(t, deployed.ImageRef(), release.ImageRef())
+}
+
+func TestReadAppManifest_Invalid(t *testing.T) {
+	valid := `version: 1
+environment: sepolia
+image: ghcr.io/acme/app@sha256:abcd000000000000000000000000000000000000000000000000000000000000
+instance_type: g1-standard-4t
+public_logs: false
+public_env:
+  PORT_PUBLIC: "8080"
+`
+	tests := []struct {
+		name     string
+		manifest string
+		wantErr  string
+	}{
+		{name: "valid", manifest: valid},
+		{name: "unknown field", manifest: valid + "privat_env: {}\n", wantErr: "privat_env"},
+		{name: "unsupported version", manifest: "version: 2\n", wantErr: "unsupported manifest version"},
+		{name: "image pinned by tag", manifest: `version: 1
+environment: sepolia
+image: ghcr.io/acme/app:latest
+instance_type: g1-standard-4t
+`, wantErr: "must be pinned by digest"},
+		{name: "missing instance type", manifest: `version: 1
+environment: sepolia
+image: ghcr.io/acme/app@sha256:abcd000000000000000000000000000000000000000000000000000000000000
+`, wantErr: "no instance_type"},
+		{name: "instance type in public env", manifest: valid + "  " + common.EigenMachineTypeEnvVar + ": g1-standard-4t\n", wantErr: "instance_type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "app.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.manifest), 0644))
+
+			_, err := ReadAppManifest(path)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package utils
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	kmstypes "github.com/Layr-Labs/eigenx-kms/pkg/types"
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// BatchManifestVersion is the schema version of batch deploy manifests
+const BatchManifestVersion = 1
+
+// BatchManifest lists the apps app deploy --batch builds concurrently and then creates or
+// upgrades in a single transaction
+type BatchManifest struct {
+	Version     int        `yaml:"version"`
+	Environment string     `yaml:"environment"`
+	Apps        []BatchApp `yaml:"apps"`
+}
+
+// BatchApp is one app of a batch manifest. Dir is relative to the manifest, and the Dockerfile
+// (default Dockerfile) and env file are relative to Dir. BuildArgs and Target are passed to the
+// app's build like --build-arg and --dockerfile-target.
+type BatchApp struct {
+	Name string `yaml:"name"`
+	// App is the name or ID of an existing app to upgrade; a new app is deployed when it is empty
+	App           string   `yaml:"app,omitempty"`
+	Dir           string   `yaml:"dir,omitempty"`
+	Dockerfile    string   `yaml:"dockerfile,omitempty"`
+	Image         string   `yaml:"image"`
+	EnvFile       string   `yaml:"env_file,omitempty"`
+	InstanceType  string   `yaml:"instance_type"`
+	LogVisibility string   `yaml:"log_visibility,omitempty"`
+	BuildArgs     []string `yaml:"build_args,omitempty"`
+	Target        string   `yaml:"target,omitempty"`
+}
+
+// LogSettings returns the log redirection and public logs settings of the app, private by default
+func (a BatchApp) LogSettings() (logRedirect string, publicLogs bool, err error) {
+	visibility := a.LogVisibility
+	if visibility == "" {
+		visibility = "private"
+	}
+	logRedirect, publicLogs, err = LogSettingsForVisibility(visibility)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid log_visibility: %w", err)
+	}
+	return logRedirect, publicLogs, nil
+}
+
+// Validate checks the manifest version and the fields needed to build and release every app.
+// Apps may not share a name, a Dockerfile or a target image, as their builds run concurrently.
+func (m *BatchManifest) Validate() error {
+	if m.Version != BatchManifestVersion {
+		return fmt.Errorf("unsupported manifest version %d (expected %d)", m.Version, BatchManifestVersion)
+	}
+	if m.Environment == "" {
+		return fmt.Errorf("manifest has no environment")
+	}
+	if len(m.Apps) == 0 {
+		return fmt.Errorf("manifest lists no apps")
+	}
+
+	names := map[string]bool{}
+	dockerfiles := map[string]string{}
+	images := map[string]string{}
+	for i, app := range m.Apps {
+		if app.Name == "" {
+			return fmt.Errorf("app %d has no name", i+1)
+		}
+		if names[app.Name] {
+			return fmt.Errorf("app name %s is listed more than once", app.Name)
+		}
+		names[app.Name] = true
+
+		if app.Image == "" {
+			return fmt.Errorf("app %s has no image", app.Name)
+		}
+		if strings.Contains(app.Image, "@") {
+			return fmt.Errorf("app %s: image %s is the push target and cannot be pinned by digest", app.Name, app.Image)
+		}
+		if app.InstanceType == "" {
+			return fmt.Errorf("app %s has no instance_type", app.Name)
+		}
+		if _, _, err := app.LogSettings(); err != nil {
+			return fmt.Errorf("app %s: %w", app.Name, err)
+		}
+
+		dockerfile := filepath.Clean(app.dockerfilePath())
+		if other, ok := dockerfiles[dockerfile]; ok {
+			return fmt.Errorf("apps %s and %s both build %s", other, app.Name, dockerfile)
+		}
+		dockerfiles[dockerfile] = app.Name
+		if other, ok := images[app.Image]; ok {
+			return fmt.Errorf("apps %s and %s both push to %s", other, app.Name, app.Image)
+		}
+		images[app.Image] = app.Name
+	}
+	return nil
+}
+
+// dockerfilePath returns the app's Dockerfile, defaulting to Dockerfile in its directory
+func (a BatchApp) dockerfilePath() string {
+	if a.Dockerfile == "" {
+		return filepath.Join(a.Dir, "Dockerfile")
+	}
+	return joinRelative(a.Dir, a.Dockerfile)
+}
+
+// resolvePaths resolves the directory of every app against baseDir and its Dockerfile and env
+// file against that directory
+func (m *BatchManifest) resolvePaths(baseDir string) {
+	for i := range m.Apps {
+		app := &m.Apps[i]
+		app.Dir = joinRelative(baseDir, app.Dir)
+		app.Dockerfile = app.dockerfilePath()
+		if app.EnvFile != "" {
+			app.EnvFile = joinRelative(app.Dir, app.EnvFile)
+		}
+	}
+}
+
+// joinRelative joins path to baseDir unless it is absolute
+func joinRelative(baseDir, path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(baseDir, path)
+}
+
+// ReadBatchManifest reads and validates a batch manifest, resolving app paths against the
+// manifest's directory. Unknown fields are rejected so typos don't silently drop settings.
+func ReadBatchManifest(path string) (*BatchManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read batch manifest %s: %w", path, err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var manifest BatchManifest
+	if err := decoder.Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse batch manifest %s: %w", path, err)
+	}
+	if err := manifest.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid batch manifest %s: %w", path, err)
+	}
+	manifest.resolvePaths(filepath.Dir(path))
+	return &manifest, nil
+}
+
+// ParseBatchAppEnv reads the public and private variables of a batch app from its env file
+func ParseBatchAppEnv(cCtx *cli.Context, app BatchApp) (kmstypes.Env, kmstypes.Env, error) {
+	publicEnv, privateEnv, err := ParseEnvFromContext(cCtx, app.EnvFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("app %s: %w", app.Name, err)
+	}
+	return publicEnv, privateEnv, nil
+}
+
+// BatchImage is the pushed image of a batch app
+type BatchImage struct {
+	ImageRef string
+	Digest   [32]byte
+	Registry string
+}
+
+// BuildBatchImages builds, layers and pushes the image of every app, running at most parallel
+// builds at once. Build output is suppressed (a failed build prints its log) and the result of
+// each app is logged as it finishes. The error names every app whose image failed.
+func BuildBatchImages(cCtx *cli.Context, environmentConfig *common.EnvironmentConfig, apps []BatchApp, parallel int) ([]BatchImage, error) {
+	logger := common.LoggerFromContext(cCtx)
+
+	logger.Info("Building %d image(s), %d at a time...", len(apps), max(parallel, 1))
+	images := make([]BatchImage, len(apps))
+	errs := common.ParallelEach(len(apps), parallel, func(i int) error {
+		image, err := buildBatchImage(cCtx, environmentConfig, apps[i])
+		if err != nil {
+			logger.Error("✗ %s: %v", apps[i].Name, err)
+			return err
+		}
+		logger.Info("✓ %s: %s", apps[i].Name, image.ImageRef)
+		images[i] = image
+		return nil
+	})
+
+	var failed []string
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, apps[i].Name)
+		}
+	}
+	if len(failed) > 0 {
+		return nil, fmt.Errorf("failed to build and push %d of %d image(s): %s", len(failed), len(apps), strings.Join(failed, ", "))
+	}
+	return images, nil
+}
+
+// buildBatchImage builds and pushes the layered image of one app and resolves its digest.
+// Interleaved build logs and progress bars are unreadable, so each build gets a quiet child
+// context carrying the app's build args and target.
+func buildBatchImage(cCtx *cli.Context, environmentConfig *common.EnvironmentConfig, app BatchApp) (BatchImage, error) {
+	logRedirect, _, err := app.LogSettings()
+	if err != nil {
+		return BatchImage{}, err
+	}
+	cCtx, err = app.buildContext(cCtx)
+	if err != nil {
+		return BatchImage{}, err
+	}
+
+	imageRef, err := buildAndPushLayeredImage(cCtx, *environmentConfig, app.Dir, app.Dockerfile, app.Image, logRedirect, app.EnvFile)
+	if err != nil {
+		return BatchImage{}, fmt.Errorf("failed to build and push layered image: %w", err)
+	}
+	waitForRegistryPropagation(cCtx, imageRef)
+
+	digest, registry, err := getImageDigestAndName(cCtx.Context, DigestResolverFromContext(cCtx), imageRef)
+	if err != nil {
+		return BatchImage{}, fmt.Errorf("failed to get image digest and name: %w", err)
+	}
+	return BatchImage{ImageRef: imageRef, Digest: digest, Registry: registry}, nil
+}
+
+// buildContext returns a quiet child of cCtx whose --build-arg and --dockerfile-target are the
+// app's. The build args are set as the flag's value rather than parsed, so commas in a value are
+// kept as is.
+func (a BatchApp) buildContext(cCtx *cli.Context) (*cli.Context, error) {
+	set := flag.NewFlagSet(a.Name, flag.ContinueOnError)
+	buildArgs := &cli.StringSliceFlag{Name: common.BuildArgFlag.Name, Value: cli.NewStringSlice(a.BuildArgs...)}
+	if err := buildArgs.Apply(set); err != nil {
+		return nil, err
+	}
+	if a.Target != "" {
+		target := &cli.StringFlag{Name: common.DockerfileTargetFlag.Name}
+		if err := target.Apply(set); err != nil {
+			return nil, err
+		}
+		if err := set.Set(common.DockerfileTargetFlag.Name, a.Target); err != nil {
+			return nil, err
+		}
+	}
+
+	child := cli.NewContext(cCtx.App, set, cCtx)
+	applyQuietOutput(child)
+	return child, nil
+}
@@ -0,0 +1,139 @@
+package utils
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestReadBatchManifest(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "apps.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`version: 1
+environment: sepolia
+apps:
+  - name: api
+    dir: services/api
+    image: ghcr.io/acme/api:latest
+    env_file: .env
+    instance_type: g1-standard-4t
+  - name: worker
+    app: my-worker
+    dir: services/worker
+    dockerfile: docker/Dockerfile.prod
+    image: ghcr.io/acme/worker:latest
+    instance_type: g1-standard-4t
+    log_visibility: public
+    build_args: [VERSION=1.2.3, "HOSTS=a,b"]
+    target: production
+`), 0644))
+
+	manifest, err := ReadBatchManifest(path)
+	require.NoError(t, err)
+	require.Len(t, manifest.Apps, 2)
+
+	api := manifest.Apps[0]
+	assert.Equal(t, filepath.Join(dir, "services/api"), api.Dir)
+	assert.Equal(t, filepath.Join(dir, "services/api/Dockerfile"), api.Dockerfile)
+	assert.Equal(t, filepath.Join(dir, "services/api/.env"), api.EnvFile)
+	logRedirect, publicLogs, err := api.LogSettings()
+	require.NoError(t, err)
+	assert.Equal(t, "always", logRedirect)
+	assert.False(t, publicLogs, "logs are private by default")
+
+	worker := manifest.Apps[1]
+	assert.Equal(t, "my-worker", worker.App)
+	assert.Equal(t, filepath.Join(dir, "services/worker/docker/Dockerfile.prod"), worker.Dockerfile)
+	assert.Empty(t, worker.EnvFile)
+	_, publicLogs, err = worker.LogSettings()
+	require.NoError(t, err)
+	assert.True(t, publicLogs)
+	assert.Equal(t, []string{"VERSION=1.2.3", "HOSTS=a,b"}, worker.BuildArgs)
+	assert.Equal(t, "production", worker.Target)
+}
+
+func TestBatchApp_BuildContext(t *testing.T) {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	for _, f := range []cli.Flag{common.BuildSecretFlag, common.BuildArgFlag, common.DockerfileTargetFlag} {
+		require.NoError(t, f.Apply(set))
+	}
+	cCtx := cli.NewContext(&cli.App{}, set, nil)
+
+	app := BatchApp{Name: "worker", BuildArgs: []string{"VERSION=1.2.3", "HOSTS=a,b"}, Target: "production"}
+	buildCtx, err := app.buildContext(cCtx)
+	require.NoError(t, err)
+	assert.True(t, common.IsQuietOutput(buildCtx.Context))
+
+	args, err := userBuildArguments(buildCtx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"--build-arg", "VERSION=1.2.3", "--build-arg", "HOSTS=a,b", "--target", "production"}, args)
+
+	buildCtx, err = BatchApp{Name: "api"}.buildContext(cCtx)
+	require.NoError(t, err)
+	args, err = userBuildArguments(buildCtx)
+	require.NoError(t, err)
+	assert.Empty(t, args)
+}
+
+func TestBatchManifest_Validate(t *testing.T) {
+	app := func(name, dir, image string) BatchApp {
+		return BatchApp{Name: name, Dir: dir, Image: image, InstanceType: "g1-standard-4t"}
+	}
+	tests := []struct {
+		name    string
+		apps    []BatchApp
+		wantErr string
+	}{
+		{name: "valid", apps: []BatchApp{app("api", "api", "acme/api"), app("worker", "worker", "acme/worker")}},
+		{name: "no apps", wantErr: "lists no apps"},
+		{name: "missing name", apps: []BatchApp{app("", "api", "acme/api")}, wantErr: "app 1 has no name"},
+		{name: "duplicate name", apps: []BatchApp{app("api", "api", "acme/api"), app("api", "worker", "acme/worker")}, wantErr: "listed more than once"},
+		{name: "missing image", apps: []BatchApp{app("api", "api", "")}, wantErr: "has no image"},
+		{name: "pinned image", apps: []BatchApp{app("api", "api", "acme/api@sha256:abcd")}, wantErr: "cannot be pinned by digest"},
+		{name: "same dockerfile", apps: []BatchApp{app("api", "api", "acme/api"), app("worker", "api/", "acme/worker")}, wantErr: "both build"},
+		{name: "same image", apps: []BatchApp{app("api", "api", "acme/app"), app("worker", "worker", "acme/app")}, wantErr: "both push to acme/app"},
+		{
+			name:    "missing instance type",
+			apps:    []BatchApp{{Name: "api", Image: "acme/api"}},
+			wantErr: "has no instance_type",
+		},
+		{
+			name:    "invalid log visibility",
+			apps:    []BatchApp{{Name: "api", Image: "acme/api", InstanceType: "g1-standard-4t", LogVisibility: "secret"}},
+			wantErr: "invalid log_visibility",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			manifest := &BatchManifest{Version: BatchManifestVersion, Environment: "sepolia", Apps: tt.apps}
+			err := manifest.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadBatchManifest_UnknownField(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "apps.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`version: 1
+environment: sepolia
+apps:
+  - name: api
+    image: acme/api
+    instance_type: g1-standard-4t
+    env-file: .env
+`), 0644))
+
+	_, err := ReadBatchManifest(path)
+	assert.ErrorContains(t, err, "env-file")
+}
@@ -0,0 +1,297 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common/output"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	DockerignoreFileName = ".dockerignore"
+
+	// BuildContextSizeWarningBytes is the build context size above which the user is warned before building
+	BuildContextSizeWarningBytes int64 = 500 * 1024 * 1024
+)
+
+// dockerignorePattern is a single compiled .dockerignore rule
+type dockerignorePattern struct {
+	regexp *regexp.Regexp
+	negate bool
+}
+
+// dockerignoreMatcher evaluates paths against .dockerignore rules. As with Docker,
+// the last matching rule wins and a rule matching a directory excludes its contents.
+type dockerignoreMatcher struct {
+	patterns    []dockerignorePattern
+	hasNegation bool
+}
+
+// buildContextSize summarizes the files that would be sent to the Docker daemon
+type buildContextSize struct {
+	TotalBytes int64
+	// TopLevelBytes maps each top-level entry of the context to the bytes it contributes
+	TopLevelBytes map[string]int64
+}
+
+// loadDockerignore reads the ignore file that buildx would use for the given context
+// and Dockerfile. A Dockerfile-specific "<Dockerfile>.dockerignore" takes precedence
+// over the context's .dockerignore. A missing file yields a matcher that excludes nothing.
+func loadDockerignore(buildContext, dockerfilePath string) (*dockerignoreMatcher, error) {
+	candidates := []string{}
+	if dockerfilePath != "" {
+		candidates = append(candidates, dockerfilePath+DockerignoreFileName)
+	}
+	candidates = append(candidates, filepath.Join(buildContext, DockerignoreFileName))
+
+	for _, candidate := range candidates {
+		file, err := os.Open(candidate)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", candidate, err)
+		}
+		defer file.Close()
+
+		var lines []string
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", candidate, err)
+		}
+		return newDockerignoreMatcher(lines)
+	}
+
+	return &dockerignoreMatcher{}, nil
+}
+
+// newDockerignoreMatcher compiles .dockerignore lines into a matcher
+func newDockerignoreMatcher(lines []string) (*dockerignoreMatcher, error) {
+	matcher := &dockerignoreMatcher{}
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		negate := false
+		if strings.HasPrefix(line, "!") {
+			negate = true
+			line = strings.TrimSpace(line[1:])
+		}
+
+		// Patterns are relative to the context root; leading slashes and ./ are ignored
+		line = filepath.ToSlash(filepath.Clean(line))
+		line = strings.TrimPrefix(line, "/")
+		if line == "" || line == "." {
+			continue
+		}
+
+		re, err := dockerignorePatternToRegexp(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid .dockerignore pattern %q: %w", line, err)
+		}
+		matcher.patterns = append(matcher.patterns, dockerignorePattern{regexp: re, negate: negate})
+		if negate {
+			matcher.hasNegation = true
+		}
+	}
+	return matcher, nil
+}
+
+// dockerignorePatternToRegexp converts a .dockerignore glob into a regular expression.
+// "**" matches any number of directories, "*" and "?" never cross a path separator.
+// The resulting expression also matches anything beneath a matched directory.
+func dockerignorePatternToRegexp(pattern string) (*regexp.Regexp, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+
+	for i := 0; i < len(pattern); i++ {
+		ch := pattern[i]
+		switch ch {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					i++
+					sb.WriteString("(.*/)?")
+				} else {
+					sb.WriteString(".*")
+				}
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(pattern[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated character class")
+			}
+			class := pattern[i+1 : i+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + class + "]")
+			i += end
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+				sb.WriteString(regexp.QuoteMeta(string(pattern[i])))
+			}
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+
+	sb.WriteString("(/.*)?$")
+	return regexp.Compile(sb.String())
+}
+
+// Excluded reports whether a slash-separated path relative to the context root is ignored
+func (m *dockerignoreMatcher) Excluded(relPath string) bool {
+	excluded := false
+	for _, p := range m.patterns {
+		if p.regexp.MatchString(relPath) {
+			excluded = !p.negate
+		}
+	}
+	return excluded
+}
+
+// computeBuildContextSize walks the build context and sums the sizes of all regular
+// files that are not excluded by the matcher
+func computeBuildContextSize(buildContext string, matcher *dockerignoreMatcher) (*buildContextSize, error) {
+	result := &buildContextSize{TopLevelBytes: map[string]int64{}}
+
+	err := filepath.WalkDir(buildContext, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Unreadable entries are skipped, docker will report them if they matter
+			if d != nil && d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(buildContext, path)
+		if err != nil || rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+
+		if matcher.Excluded(rel) {
+			// Without negations nothing beneath an excluded directory can be re-included
+			if d.IsDir() && !matcher.hasNegation {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+
+		topLevel := strings.SplitN(rel, "/", 2)[0]
+		result.TotalBytes += info.Size()
+		result.TopLevelBytes[topLevel] += info.Size()
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk build context: %w", err)
+	}
+
+	return result, nil
+}
+
+// largestEntries returns up to n top-level entries ordered by their contribution to the context
+func (s *buildContextSize) largestEntries(n int) []string {
+	entries := make([]string, 0, len(s.TopLevelBytes))
+	for name := range s.TopLevelBytes {
+		entries = append(entries, name)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if s.TopLevelBytes[entries[i]] == s.TopLevelBytes[entries[j]] {
+			return entries[i] < entries[j]
+		}
+		return s.TopLevelBytes[entries[i]] > s.TopLevelBytes[entries[j]]
+	})
+	if len(entries) > n {
+		entries = entries[:n]
+	}
+	return entries
+}
+
+// CheckBuildContextSize warns when the effective build context (after applying
+// .dockerignore) is larger than BuildContextSizeWarningBytes, and asks the user
+// whether to continue. With --force the warning is logged and the build proceeds.
+func CheckBuildContextSize(cCtx *cli.Context, buildContext, dockerfilePath string) error {
+	logger := common.LoggerFromContext(cCtx)
+
+	matcher, err := loadDockerignore(buildContext, dockerfilePath)
+	if err != nil {
+		// A broken .dockerignore will also break the build, let docker report it
+		logger.Debug("Skipping build context size check: %v", err)
+		return nil
+	}
+
+	size, err := computeBuildContextSize(buildContext, matcher)
+	if err != nil {
+		logger.Debug("Skipping build context size check: %v", err)
+		return nil
+	}
+	logger.Debug("Build context size: %s", formatBytes(size.TotalBytes))
+
+	if size.TotalBytes <= BuildContextSizeWarningBytes {
+		return nil
+	}
+
+	logger.Warn("Build context is %s, which may take a long time to send to Docker.", formatBytes(size.TotalBytes))
+	logger.Info("Largest entries in the build context:")
+	for _, entry := range size.largestEntries(5) {
+		logger.Info("  • %s (%s)", entry, formatBytes(size.TopLevelBytes[entry]))
+	}
+	logger.Info("Consider adding large or unneeded paths (e.g. node_modules, .git) to %s", DockerignoreFileName)
+
+	if cCtx.Bool(common.ForceFlag.Name) {
+		return nil
+	}
+
+	confirmed, err := output.ConfirmWithDefault("Continue building with this context?", true)
+	if err != nil {
+		return fmt.Errorf("failed to get confirmation: %w", err)
+	}
+	if !confirmed {
+		return fmt.Errorf("build cancelled by user")
+	}
+	return nil
+}
+
+// formatBytes renders a byte count using binary units
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDockerignoreMatcher(t *testing.T) {
+	matcher, err := newDockerignoreMatcher([]string{
+		"# comment",
+		"node_modules",
+		"/.git",
+		"**/*.log",
+		"build/*",
+		"!build/keep.txt",
+		"",
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		path     string
+		excluded bool
+	}{
+		{"node_modules", true},
+		{"node_modules/pkg/index.js", true},
+		{".git/HEAD", true},
+		{"app.log", true},
+		{"logs/deep/app.log", true},
+		{"build/out.bin", true},
+		{"build/keep.txt", false},
+		{"src/main.go", false},
+		{"src/node_modules_helper.go", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.excluded, matcher.Excluded(tt.path))
+		})
+	}
+}
+
+func TestComputeBuildContextSize(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile := func(rel string, size int) {
+		path := filepath.Join(dir, rel)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, make([]byte, size), 0644))
+	}
+
+	writeFile("main.go", 100)
+	writeFile("src/lib.go", 50)
+	writeFile("node_modules/big/blob", 10000)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, DockerignoreFileName), []byte("node_modules\n"), 0644))
+
+	matcher, err := loadDockerignore(dir, "")
+	require.NoError(t, err)
+
+	size, err := computeBuildContextSize(dir, matcher)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(100+50+13), size.TotalBytes)
+	assert.NotContains(t, size.TopLevelBytes, "node_modules")
+	assert.Equal(t, []string{"main.go", "src"}, size.largestEntries(2))
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "512 B", formatBytes(512))
+	assert.Equal(t, "1.5 KiB", formatBytes(1536))
+	assert.Equal(t, "500.0 MiB", formatBytes(BuildContextSizeWarningBytes))
+}
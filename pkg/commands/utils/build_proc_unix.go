@@ -0,0 +1,18 @@
+//go:build !windows
+
+package utils
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// configureBuildProcess runs the command in its own process group so that cancelling
+// the context terminates buildx along with any helper processes it spawned
+func configureBuildProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	cmd.WaitDelay = BuildProcessWaitDelay
+}
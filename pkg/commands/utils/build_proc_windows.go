@@ -0,0 +1,11 @@
+//go:build windows
+
+package utils
+
+import "os/exec"
+
+// configureBuildProcess relies on the default exec.CommandContext behaviour of
+// killing the process when the context is cancelled
+func configureBuildProcess(cmd *exec.Cmd) {
+	cmd.WaitDelay = BuildProcessWaitDelay
+}
@@ -4,13 +4,17 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net/mail"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 	"text/template"
 
@@ -18,6 +22,7 @@ import (
 	"github.com/Layr-Labs/eigenx-cli/internal/version"
 	"github.com/Layr-Labs/eigenx-cli/pkg/common"
 	dockercommand "github.com/docker/cli/cli/command"
+	"github.com/docker/cli/cli/config/configfile"
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/client"
 	"github.com/joho/godotenv"
@@ -42,6 +47,12 @@ func (e *PushPermissionError) Unwrap() error {
 	return e.Err
 }
 
+// TokenExpired reports whether the registry rejected the credentials as expired or no longer
+// valid, as opposed to valid credentials lacking access to the repository
+func (e *PushPermissionError) TokenExpired() bool {
+	return e.Err != nil && isExpiredTokenError(e.Err.Error())
+}
+
 // IsPushPermissionError checks if an error is a push permission error
 func IsPushPermissionError(err error) bool {
 	var pushErr *PushPermissionError
@@ -104,6 +115,10 @@ func setupLayeredBuildDirectory(environmentConfig common.EnvironmentConfig, laye
 	}
 
 	// Copy kms-client binary
+	if err := verifyEmbeddedBinary(KMSClientBinaryName, project.RawKmsClient, project.KmsClientSHA256); err != nil {
+		os.RemoveAll(tempDir)
+		return "", err
+	}
 	kmsClientPath := filepath.Join(tempDir, KMSClientBinaryName)
 	err = os.WriteFile(kmsClientPath, project.RawKmsClient, 0755)
 	if err != nil {
@@ -114,6 +129,10 @@ func setupLayeredBuildDirectory(environmentConfig common.EnvironmentConfig, laye
 	// Only include TLS components if requested
 	if includeTLS {
 		// Copy tls-keygen binary
+		if err := verifyEmbeddedBinary(TlsKeygenBinaryName, project.RawTlsKeygenBinary, project.TlsKeygenSHA256); err != nil {
+			os.RemoveAll(tempDir)
+			return "", err
+		}
 		tlsKeygenPath := filepath.Join(tempDir, TlsKeygenBinaryName)
 		err = os.WriteFile(tlsKeygenPath, project.RawTlsKeygenBinary, 0755)
 		if err != nil {
@@ -145,18 +164,72 @@ func setupLayeredBuildDirectory(environmentConfig common.EnvironmentConfig, laye
 	return tempDir, nil
 }
 
-func extractImageConfig(dockerClient *client.Client, ctx context.Context, imageTag string) ([]string, string, error) {
+// verifyEmbeddedBinary checks that an embedded helper binary matches its recorded SHA256 checksum,
+// so a corrupted binary fails the build instead of failing opaquely inside the TEE
+func verifyEmbeddedBinary(name string, data []byte, expectedSHA256 string) error {
+	sum := sha256.Sum256(data)
+	if actual := hex.EncodeToString(sum[:]); actual != expectedSHA256 {
+		return fmt.Errorf("embedded %s binary is corrupted: sha256 %s does not match expected %s; reinstall eigenx", name, actual, expectedSHA256)
+	}
+	return nil
+}
+
+// imageRunConfig is what a base image runs: its ENTRYPOINT, default CMD arguments and USER
+type imageRunConfig struct {
+	Entrypoint []string
+	Cmd        []string
+	User       string
+}
+
+// command is the full command a container of the image runs without overrides
+func (c imageRunConfig) command() []string {
+	command := make([]string, 0, len(c.Entrypoint)+len(c.Cmd))
+	command = append(command, c.Entrypoint...)
+	return append(command, c.Cmd...)
+}
+
+func extractImageConfig(dockerClient *client.Client, ctx context.Context, imageTag string) (imageRunConfig, error) {
 	inspectResp, err := dockerClient.ImageInspect(ctx, imageTag)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to inspect base image: %w", err)
+		return imageRunConfig{}, fmt.Errorf("failed to inspect base image: %w", err)
 	}
 
-	originalCmd := inspectResp.Config.Cmd
-	if len(originalCmd) == 0 && len(inspectResp.Config.Entrypoint) > 0 {
-		originalCmd = inspectResp.Config.Entrypoint
+	return imageRunConfig{
+		Entrypoint: inspectResp.Config.Entrypoint,
+		Cmd:        inspectResp.Config.Cmd,
+		User:       inspectResp.Config.User,
+	}, nil
+}
+
+// layeredEntrypoint puts the wrapper script in front of the base image's ENTRYPOINT, keeping
+// the base CMD as default arguments that can still be overridden on their own
+func layeredEntrypoint(original imageRunConfig) []string {
+	return append([]string{LayeredEntrypoint}, original.Entrypoint...)
+}
+
+// verifyLayeredCommand inspects the built layered image and checks that it still runs the
+// base image's command behind the wrapper script
+func verifyLayeredCommand(dockerClient *client.Client, ctx context.Context, imageTag string, original imageRunConfig) error {
+	inspectResp, err := dockerClient.ImageInspect(ctx, imageTag)
+	if err != nil {
+		return fmt.Errorf("failed to inspect layered image: %w", err)
 	}
+	return checkLayeredCommand(inspectResp.Config.Entrypoint, inspectResp.Config.Cmd, original)
+}
 
-	return originalCmd, inspectResp.Config.User, nil
+// checkLayeredCommand compares the ENTRYPOINT and CMD of a layered image with those of the
+// base image
+func checkLayeredCommand(entrypoint, cmd []string, original imageRunConfig) error {
+	if strings.TrimSpace(strings.Join(original.command(), "")) == "" {
+		return fmt.Errorf("base image has no CMD or ENTRYPOINT, so the app would have nothing to run; set one in your Dockerfile")
+	}
+	if expected := layeredEntrypoint(original); !slices.Equal(entrypoint, expected) {
+		return fmt.Errorf("layered image has ENTRYPOINT %q, expected %q", entrypoint, expected)
+	}
+	if !slices.Equal(cmd, original.Cmd) {
+		return fmt.Errorf("layered image has CMD %q instead of the base image's %q", cmd, original.Cmd)
+	}
+	return nil
 }
 
 // extractDigestFromRepoDigest extracts the sha256 digest from a Docker repo digest string
@@ -177,10 +250,10 @@ func extractDigestFromRepoDigest(repoDigest string) *[32]byte {
 	return &digest
 }
 
-func checkIfImageAlreadyLayeredForEigenX(dockerClient *client.Client, ctx context.Context, imageRef string) (bool, error) {
+func checkIfImageAlreadyLayeredForEigenX(dockerClient *client.Client, ctx context.Context, imageRef string, resolver DigestResolver) (bool, error) {
 	// First get the remote image digest to ensure we're working with the latest
-	// This also validates that the image exists and supports linux/amd64 platform
-	remoteDigest, _, err := getImageDigestAndName(ctx, imageRef)
+	// This also validates that the image exists and supports the target platform
+	remoteDigest, _, err := getImageDigestAndName(ctx, resolver, imageRef)
 	if err != nil {
 		return false, err
 	}
@@ -206,11 +279,12 @@ func checkIfImageAlreadyLayeredForEigenX(dockerClient *client.Client, ctx contex
 
 	if needToPull {
 		// Pull the image with the required platform
+		platform := TargetPlatform(ctx).String()
 		resp, pullErr := dockerClient.ImagePull(ctx, imageRef, image.PullOptions{
-			Platform: DockerPlatform, // linux/amd64
+			Platform: platform,
 		})
 		if pullErr != nil {
-			return false, fmt.Errorf("failed to pull image %s for platform %s: %w", imageRef, DockerPlatform, pullErr)
+			return false, fmt.Errorf("failed to pull image %s for platform %s: %w", imageRef, platform, pullErr)
 		}
 		defer resp.Close()
 
@@ -235,7 +309,9 @@ func checkIfImageAlreadyLayeredForEigenX(dockerClient *client.Client, ctx contex
 // Image Building and Pushing
 // ============================================================================
 
-func buildAndPushLayeredImage(cCtx *cli.Context, environmentConfig common.EnvironmentConfig, dockerfilePath, targetImageRef, logRedirect, envFilePath string) (string, error) {
+// buildAndPushLayeredImage builds dockerfilePath in buildContext, layers the EigenX components on
+// top and pushes the result to targetImageRef. Callers check the build context size first.
+func buildAndPushLayeredImage(cCtx *cli.Context, environmentConfig common.EnvironmentConfig, buildContext, dockerfilePath, targetImageRef, logRedirect, envFilePath string) (string, error) {
 	logger := common.LoggerFromContext(cCtx)
 
 	dockerClient, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
@@ -245,53 +321,229 @@ func buildAndPushLayeredImage(cCtx *cli.Context, environmentConfig common.Enviro
 	defer dockerClient.Close()
 
 	// Build base image from user's Dockerfile
-	baseImageTag := fmt.Sprintf("%s%s", TempImagePrefix, strings.ToLower(dockerfilePath))
+	baseImageTag := baseImageTagForDockerfile(dockerfilePath)
 	logger.Info("Building base image from %s...", dockerfilePath)
 
-	err = buildDockerImage(".", dockerfilePath, baseImageTag)
+	// Build secrets and args only apply to the user's Dockerfile, never to the EigenX layering build
+	userBuildArgs, err := userBuildArguments(cCtx)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := withBuildTimeout(cCtx)
+	defer cancel()
+
+	ReportStage(cCtx, StageBuild, 0, "Building base image")
+	err = buildDockerImage(ctx, buildContext, dockerfilePath, baseImageTag, userBuildArgs...)
+	if err != nil {
+		// buildx prints the reason above, e.g. that the target stage could not be found
+		if target := cCtx.String(common.DockerfileTargetFlag.Name); target != "" && ctx.Err() == nil {
+			err = fmt.Errorf("%w (check that stage %q exists in %s)", err, strings.TrimSpace(target), dockerfilePath)
+		}
+		return "", annotateBuildTimeout(cCtx, fmt.Errorf("failed to build base image: %w", err))
+	}
+
+	// Label the image with the commit it was built from, when the build context is a git checkout
+	gitCommit := ""
+	if tree, err := readGitWorkingTree(ctx, buildContext); err == nil {
+		gitCommit = tree.Revision()
+	}
+
+	layeredImageRef, err := layerLocalImage(cCtx, ctx, dockerClient, environmentConfig, baseImageTag, targetImageRef, logRedirect, envFilePath, gitCommit)
 	if err != nil {
-		return "", fmt.Errorf("failed to build base image: %w", err)
+		return "", annotateBuildTimeout(cCtx, err)
+	}
+
+	// The image is in the registry now, so the local copies are only kept on request
+	if cCtx.Bool(common.KeepBaseImageFlag.Name) {
+		logger.Info("Keeping local images %s and %s (--%s)", baseImageTag, targetImageRef, common.KeepBaseImageFlag.Name)
+	} else {
+		removeLocalImages(cCtx, dockerClient, targetImageRef, baseImageTag)
+	}
+	return layeredImageRef, nil
+}
+
+// removeLocalImages removes the given local images, logging failures instead of returning them
+func removeLocalImages(cCtx *cli.Context, dockerClient *client.Client, imageRefs ...string) {
+	logger := common.LoggerFromContext(cCtx)
+
+	removed := 0
+	for _, ref := range imageRefs {
+		if _, err := dockerClient.ImageInspect(cCtx.Context, ref); err != nil {
+			logger.Debug("Skipping cleanup of %s: %v", ref, err)
+			continue
+		}
+
+		if _, err := dockerClient.ImageRemove(cCtx.Context, ref, image.RemoveOptions{PruneChildren: true}); err != nil {
+			logger.Warn("Failed to remove local image %s: %v", ref, err)
+			continue
+		}
+		removed++
+	}
+
+	if removed > 0 {
+		logger.Info("Removed %d intermediate local image(s) (keep them with --%s)", removed, common.KeepBaseImageFlag.Name)
+	}
+}
+
+// baseImageTagForDockerfile derives a valid local image name for the base build of a Dockerfile.
+// Docker repository names only allow lowercase alphanumerics separated by '.', '_' or '-', so the
+// path is reduced to those characters and suffixed with a hash of its resolved absolute path to keep
+// different Dockerfiles (including symlinks and relative spellings of the same file) apart.
+func baseImageTagForDockerfile(dockerfilePath string) string {
+	resolved := dockerfilePath
+	if abs, err := filepath.Abs(dockerfilePath); err == nil {
+		resolved = abs
+	}
+	if real, err := filepath.EvalSymlinks(resolved); err == nil {
+		resolved = real
+	}
+	sum := sha256.Sum256([]byte(resolved))
+
+	var sb strings.Builder
+	lastDash := true
+	for _, r := range strings.ToLower(filepath.ToSlash(dockerfilePath)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			sb.WriteRune(r)
+			lastDash = false
+		} else if !lastDash {
+			sb.WriteByte('-')
+			lastDash = true
+		}
+	}
+	name := strings.Trim(sb.String(), "-")
+	if len(name) > maxBaseImageNameLength {
+		name = strings.Trim(name[len(name)-maxBaseImageNameLength:], "-")
+	}
+	if name == "" {
+		name = "dockerfile"
+	}
+
+	return fmt.Sprintf("%s%s-%s", TempImagePrefix, name, hex.EncodeToString(sum[:])[:12])
+}
+
+// userBuildArguments converts --build-secret, --build-arg and --dockerfile-target flags into
+// buildx arguments. Only secret ids and build-arg names are logged, never their values.
+func userBuildArguments(cCtx *cli.Context) ([]string, error) {
+	logger := common.LoggerFromContext(cCtx)
+	var args []string
+
+	for _, secret := range cCtx.StringSlice(common.BuildSecretFlag.Name) {
+		id, err := validateBuildSecret(secret)
+		if err != nil {
+			return nil, err
+		}
+		logger.Debug("Using build secret %s", id)
+		args = append(args, "--secret", secret)
+	}
+
+	for _, buildArg := range cCtx.StringSlice(common.BuildArgFlag.Name) {
+		name, _, _ := strings.Cut(buildArg, "=")
+		if name == "" {
+			return nil, fmt.Errorf("invalid --build-arg: expected NAME=VALUE or NAME")
+		}
+		logger.Debug("Using build arg %s", name)
+		args = append(args, "--build-arg", buildArg)
+	}
+
+	if cCtx.IsSet(common.DockerfileTargetFlag.Name) {
+		target := strings.TrimSpace(cCtx.String(common.DockerfileTargetFlag.Name))
+		if target == "" {
+			return nil, fmt.Errorf("--%s must not be empty", common.DockerfileTargetFlag.Name)
+		}
+		logger.Debug("Building Dockerfile target %s", target)
+		args = append(args, "--target", target)
+	}
+
+	return args, nil
+}
+
+// validateBuildSecret checks a buildx secret spec (id=NAME,src=PATH or id=NAME,env=VAR)
+// and returns its id. File sources must exist so failures surface before the build starts.
+func validateBuildSecret(spec string) (string, error) {
+	fields := map[string]string{}
+	for _, part := range strings.Split(spec, ",") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return "", fmt.Errorf("invalid --build-secret: expected id=NAME,src=PATH")
+		}
+		fields[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	id := fields["id"]
+	if id == "" {
+		return "", fmt.Errorf("invalid --build-secret: missing id")
+	}
+
+	src := fields["src"]
+	if src == "" {
+		src = fields["source"]
+	}
+	switch {
+	case src != "":
+		if _, err := os.Stat(src); err != nil {
+			return "", fmt.Errorf("build secret %s: source file not found: %w", id, err)
+		}
+	case fields["env"] == "":
+		return "", fmt.Errorf("build secret %s: specify src=PATH or env=VAR", id)
+	}
+
+	return id, nil
+}
+
+// withBuildTimeout derives the context used for docker build and push operations,
+// bounded by --build-timeout when it is set
+func withBuildTimeout(cCtx *cli.Context) (context.Context, context.CancelFunc) {
+	if timeout := cCtx.Duration(common.BuildTimeoutFlag.Name); timeout > 0 {
+		return context.WithTimeout(cCtx.Context, timeout)
 	}
+	return context.WithCancel(cCtx.Context)
+}
 
-	return layerLocalImage(cCtx, dockerClient, environmentConfig, baseImageTag, targetImageRef, logRedirect, envFilePath)
+// annotateBuildTimeout adds a hint about --build-timeout to errors caused by the build deadline
+func annotateBuildTimeout(cCtx *cli.Context, err error) error {
+	if err == nil || !errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	return fmt.Errorf("build did not complete within --build-timeout of %s: %w", cCtx.Duration(common.BuildTimeoutFlag.Name), err)
 }
 
-func layerLocalImage(cCtx *cli.Context, dockerClient *client.Client, environmentConfig common.EnvironmentConfig, sourceImageRef, targetImageRef, logRedirect, envFilePath string) (string, error) {
+func layerLocalImage(cCtx *cli.Context, ctx context.Context, dockerClient *client.Client, environmentConfig common.EnvironmentConfig, sourceImageRef, targetImageRef, logRedirect, envFilePath, gitCommit string) (string, error) {
 	logger := common.LoggerFromContext(cCtx)
 
-	// Extract original command and user from source image
-	originalCmd, originalUser, err := extractImageConfig(dockerClient, cCtx.Context, sourceImageRef)
+	// Extract original entrypoint, command and user from source image
+	original, err := extractImageConfig(dockerClient, ctx, sourceImageRef)
 	if err != nil {
 		return "", fmt.Errorf("failed to extract image config: %w", err)
 	}
 
-	// Check if user has DOMAIN configured in env file
-	includeTLS := false
-	if _, err := os.Stat(envFilePath); err == nil {
-		// Parse env file using godotenv
-		envMap, err := godotenv.Read(envFilePath)
-		if err == nil {
-			if domain, exists := envMap["DOMAIN"]; exists && domain != "" && domain != "localhost" {
-				includeTLS = true
-				logger.Debug("Found DOMAIN=%s in %s, including TLS components", domain, envFilePath)
-			}
-		}
+	includeTLS := resolveIncludeTLS(cCtx, envFilePath)
+	if !includeTLS && (cCtx.String(common.TLSEmailFlag.Name) != "" || cCtx.Bool(common.TLSStagingFlag.Name)) {
+		logger.Warn("--tls-email/--tls-staging ignored: TLS components are not included (set DOMAIN in %s or pass --tls)", envFilePath)
 	}
 	logger.Debug("Adding EigenX components to %s (TLS disabled for published images)", sourceImageRef)
 
 	// Generate template content
-	originalCmdStr, err := formatCmdForDockerfile(originalCmd)
+	entrypointStr, err := formatCmdForDockerfile(layeredEntrypoint(original))
+	if err != nil {
+		return "", fmt.Errorf("failed to format entrypoint: %w", err)
+	}
+	originalCmdStr, err := formatCmdForDockerfile(original.Cmd)
 	if err != nil {
 		return "", fmt.Errorf("failed to format original command: %w", err)
 	}
 
 	layeredDockerfileContent, err := processTemplate(LayeredDockerfilePath, LayeredDockerfileTemplateData{
 		BaseImage:        sourceImageRef,
+		Entrypoint:       entrypointStr,
 		OriginalCmd:      originalCmdStr,
-		OriginalUser:     originalUser,
+		OriginalUser:     original.User,
 		LogRedirect:      logRedirect,
 		IncludeTLS:       includeTLS,
+		TLSEmail:         cCtx.String(common.TLSEmailFlag.Name),
+		TLSStaging:       cCtx.Bool(common.TLSStagingFlag.Name),
 		EigenXCLIVersion: version.GetVersion(),
+		GitCommit:        gitCommit,
 	})
 	if err != nil {
 		return "", fmt.Errorf("failed to process dockerfile template: %w", err)
@@ -315,55 +567,205 @@ func layerLocalImage(cCtx *cli.Context, dockerClient *client.Client, environment
 	// Build layered image
 	logger.Info("Building updated image with EigenX components for %s...", sourceImageRef)
 	layeredDockerfilePath := filepath.Join(tempDir, LayeredDockerfileName)
-	err = buildDockerImage(tempDir, layeredDockerfilePath, targetImageRef)
+	ReportStage(cCtx, StageBuild, 50, "Building image with EigenX components")
+	err = buildDockerImage(ctx, tempDir, layeredDockerfilePath, targetImageRef)
 	if err != nil {
 		return "", fmt.Errorf("failed to build layered image: %w", err)
 	}
+	if err := verifyLayeredCommand(dockerClient, ctx, targetImageRef, original); err != nil {
+		return "", fmt.Errorf("layered image does not preserve the command of %s: %w", sourceImageRef, err)
+	}
+	ReportStage(cCtx, StageBuild, 100, "Image built")
 
 	// Push to registry
 	logger.Info("Publishing updated image to %s...", targetImageRef)
-	err = pushDockerImage(dockerClient, targetImageRef)
+	ReportStage(cCtx, StagePush, 0, "Pushing "+targetImageRef)
+	err = pushDockerImage(ctx, dockerClient, targetImageRef, cCtx.String(common.RegistryAuthFileFlag.Name))
 	if err != nil {
 		return "", fmt.Errorf("failed to push layered image: %w", err)
 	}
+	ReportStage(cCtx, StagePush, 100, "Pushed "+targetImageRef)
 
 	logger.Info("Successfully published updated image: %s", targetImageRef)
 	return targetImageRef, nil
 }
 
+// resolveIncludeTLS decides whether TLS components should be layered into the image.
+// The --tls and --no-tls flags take precedence; otherwise TLS is included when the
+// env file sets a DOMAIN other than localhost.
+func resolveIncludeTLS(cCtx *cli.Context, envFilePath string) bool {
+	logger := common.LoggerFromContext(cCtx)
+
+	if cCtx.Bool(common.NoTLSFlag.Name) {
+		logger.Debug("--no-tls set, skipping TLS components")
+		return false
+	}
+	if cCtx.Bool(common.TLSFlag.Name) {
+		logger.Debug("--tls set, including TLS components")
+		return true
+	}
+
+	// Check if user has DOMAIN configured in the env file(s)
+	if domain := configuredDomain(cCtx, envFilePath); domain != "" {
+		logger.Debug("Found DOMAIN=%s, including TLS components", domain)
+		return true
+	}
+	return false
+}
+
+// configuredDomain returns the DOMAIN set in the env file(s), or "" when it is unset or localhost
+func configuredDomain(cCtx *cli.Context, envFilePath string) string {
+	return domainFromEnv(configuredEnv(cCtx, envFilePath))
+}
+
+// configuredEnv returns the variables set in the env file(s). A value set in an earlier file
+// wins, and files that are missing or can't be parsed are skipped.
+func configuredEnv(cCtx *cli.Context, envFilePath string) map[string]string {
+	envFilePaths := []string{envFilePath}
+	if HasExplicitEnvFiles(cCtx) {
+		envFilePaths = []string{cCtx.String(common.PublicEnvFileFlag.Name), cCtx.String(common.PrivateEnvFileFlag.Name)}
+	}
+	env := map[string]string{}
+	for _, path := range envFilePaths {
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		// Parse env file using godotenv
+		envMap, err := godotenv.Read(path)
+		if err != nil {
+			continue
+		}
+		for key, value := range envMap {
+			if env[key] == "" {
+				env[key] = value
+			}
+		}
+	}
+	return env
+}
+
+// domainFromEnv returns the DOMAIN of env, or "" when it is unset or localhost
+func domainFromEnv(env map[string]string) string {
+	if domain := env["DOMAIN"]; domain != "localhost" {
+		return domain
+	}
+	return ""
+}
+
+// ValidateTLSFlags ensures the TLS-related flags form a consistent combination
+func ValidateTLSFlags(cCtx *cli.Context) error {
+	if cCtx.Bool(common.TLSFlag.Name) && cCtx.Bool(common.NoTLSFlag.Name) {
+		return fmt.Errorf("--tls and --no-tls cannot be used together")
+	}
+
+	tlsEmail := cCtx.String(common.TLSEmailFlag.Name)
+	tlsStaging := cCtx.Bool(common.TLSStagingFlag.Name)
+	if cCtx.Bool(common.NoTLSFlag.Name) && (tlsEmail != "" || tlsStaging) {
+		return fmt.Errorf("--tls-email and --tls-staging cannot be used with --no-tls")
+	}
+	if tlsEmail != "" {
+		if err := validateACMEEmail(tlsEmail); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateACMEEmail checks that the value is a bare email address that can be
+// written into the layered Dockerfile unquoted
+func validateACMEEmail(email string) error {
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email || strings.ContainsAny(email, " \t\"'\\") {
+		return fmt.Errorf("invalid --tls-email %q: must be a plain email address like admin@example.com", email)
+	}
+	return nil
+}
+
 // ============================================================================
 // Docker Operations
 // ============================================================================
 
-func buildDockerImage(buildContext, dockerfilePath, tag string) error {
-	cmd := exec.Command("docker", "buildx", "build",
-		"--platform", DockerPlatform,
+// buildDockerImage runs buildx for the given context. extraArgs are passed through
+// before the context argument, e.g. --secret and --build-arg for the user's build.
+func buildDockerImage(ctx context.Context, buildContext, dockerfilePath, tag string, extraArgs ...string) error {
+	args := []string{"buildx", "build",
+		"--platform", TargetPlatform(ctx).String(),
 		"-t", tag,
 		"-f", dockerfilePath,
 		"--progress=plain",
-		buildContext,
-	)
+	}
+	args = append(args, extraArgs...)
+	args = append(args, buildContext)
 
-	// Inherit stdout and stderr for real-time output
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	cmd := exec.CommandContext(ctx, "docker", args...)
+
+	// Inherit stdout and stderr for real-time output. With quiet output the
+	// build log is kept and only shown if the build fails.
+	var buildLog bytes.Buffer
+	if common.IsQuietOutput(ctx) {
+		cmd.Stdout = &buildLog
+		cmd.Stderr = &buildLog
+	} else {
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+	}
+
+	// Kill the whole buildx process group if the build is cancelled or times out
+	configureBuildProcess(cmd)
 
 	if err := cmd.Run(); err != nil {
+		os.Stderr.Write(buildLog.Bytes())
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return fmt.Errorf("buildx command aborted: %w", ctxErr)
+		}
 		return fmt.Errorf("buildx command failed: %w", err)
 	}
 
 	return nil
 }
 
-func pushDockerImage(dockerClient *client.Client, imageRef string) error {
-	ctx := context.Background()
+// ValidateRegistryAuthFile checks that the --registry-auth-file, if set, is a readable Docker config file
+func ValidateRegistryAuthFile(cCtx *cli.Context) error {
+	path := cCtx.String(common.RegistryAuthFileFlag.Name)
+	if path == "" {
+		return nil
+	}
+	if _, err := loadDockerConfigFile(path); err != nil {
+		return fmt.Errorf("invalid --%s: %w", common.RegistryAuthFileFlag.Name, err)
+	}
+	return nil
+}
 
-	// Use empty auth config - Docker client will use system auth
-	dockerCli, err := dockercommand.NewDockerCli()
+// loadDockerConfigFile returns the Docker CLI config holding registry credentials. An empty
+// registryAuthFile uses the default config (~/.docker/config.json or $DOCKER_CONFIG).
+func loadDockerConfigFile(registryAuthFile string) (*configfile.ConfigFile, error) {
+	if registryAuthFile == "" {
+		dockerCli, err := dockercommand.NewDockerCli()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create docker cli: %w", err)
+		}
+		return dockerCli.ConfigFile(), nil
+	}
+
+	file, err := os.Open(registryAuthFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open registry auth file: %w", err)
+	}
+	defer file.Close()
+
+	configFile := configfile.New(registryAuthFile)
+	if err := configFile.LoadFromReader(file); err != nil {
+		return nil, fmt.Errorf("failed to parse registry auth file %s: %w", registryAuthFile, err)
+	}
+	return configFile, nil
+}
+
+func pushDockerImage(ctx context.Context, dockerClient *client.Client, imageRef, registryAuthFile string) error {
+	configFile, err := loadDockerConfigFile(registryAuthFile)
 	if err != nil {
-		return fmt.Errorf("failed to create docker cli: %w", err)
+		return err
 	}
-	encodedAuth, err := dockercommand.RetrieveAuthTokenFromImage(dockerCli.ConfigFile(), imageRef)
+	encodedAuth, err := dockercommand.RetrieveAuthTokenFromImage(configFile, imageRef)
 	if err != nil {
 		return fmt.Errorf("failed to retrieve auth token: %w", err)
 	}
@@ -384,7 +786,11 @@ func pushDockerImage(dockerClient *client.Client, imageRef string) error {
 	defer resp.Close()
 
 	// Parse and display push output
-	err = parseBuildOutput(resp)
+	pushLog := io.Writer(os.Stdout)
+	if common.IsQuietOutput(ctx) {
+		pushLog = io.Discard
+	}
+	err = parseBuildOutput(resp, pushLog)
 	if err != nil {
 		// Check if the error from parsing output is a permission error
 		if isPermissionError(err.Error()) {
@@ -397,7 +803,7 @@ func pushDockerImage(dockerClient *client.Client, imageRef string) error {
 	return err
 }
 
-func parseBuildOutput(output io.Reader) error {
+func parseBuildOutput(output io.Reader, w io.Writer) error {
 	scanner := bufio.NewScanner(output)
 	layerStatus := make(map[string]string)
 
@@ -407,14 +813,14 @@ func parseBuildOutput(output io.Reader) error {
 		var msg map[string]any
 		if err := json.Unmarshal([]byte(line), &msg); err != nil {
 			// If not JSON, print as-is
-			fmt.Println(line)
+			fmt.Fprintln(w, line)
 			continue
 		}
 
 		// Extract and print stream content (build steps)
 		if stream, ok := msg["stream"].(string); ok {
 			if stream != "" {
-				fmt.Print(stream)
+				fmt.Fprint(w, stream)
 			}
 		}
 
@@ -424,10 +830,10 @@ func parseBuildOutput(output io.Reader) error {
 				// Only print if status changed for this layer
 				if layerStatus[id] != status {
 					layerStatus[id] = status
-					fmt.Printf("%s: %s\n", id, status)
+					fmt.Fprintf(w, "%s: %s\n", id, status)
 				}
 			} else {
-				fmt.Println(status)
+				fmt.Fprintln(w, status)
 			}
 		}
 
@@ -462,9 +868,33 @@ func isPermissionError(errMsg string) bool {
 	return false
 }
 
+// isExpiredTokenError checks a registry error message for an expired or revoked token, such as
+// GHCR's response to an expired personal access token
+func isExpiredTokenError(errMsg string) bool {
+	errLower := strings.ToLower(errMsg)
+	expiredKeywords := []string{
+		"token expired",
+		"token has expired",
+		"expired token",
+		"invalid_token",
+		"unauthenticated",
+		"cannot be authenticated with the token provided",
+		"bad credentials",
+	}
+
+	for _, keyword := range expiredKeywords {
+		if strings.Contains(errLower, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatCmdForDockerfile renders cmd in exec form, or "" when it is empty so the template can
+// leave the instruction out
 func formatCmdForDockerfile(cmd []string) (string, error) {
 	if len(cmd) == 0 {
-		return `[""]`, nil
+		return "", nil
 	}
 
 	jsonBytes, err := json.Marshal(cmd)
@@ -0,0 +1,190 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	project "github.com/Layr-Labs/eigenx-cli"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+// dockerRepositoryName matches a valid single-component local Docker repository name
+var dockerRepositoryName = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|[-]+)[a-z0-9]+)*$`)
+
+func TestBaseImageTagForDockerfile(t *testing.T) {
+	inputs := []string{
+		"Dockerfile",
+		"docker/Dockerfile.prod",
+		"./Dockerfile",
+		"/home/user/My Project/Dockerfile",
+		`C:\Users\dev\app\Dockerfile`,
+		"../services/API_v2/Dockerfile.dev",
+		"---",
+		strings.Repeat("nested/", 40) + "Dockerfile",
+	}
+
+	for _, input := range inputs {
+		t.Run(input, func(t *testing.T) {
+			tag := baseImageTagForDockerfile(input)
+			assert.True(t, strings.HasPrefix(tag, TempImagePrefix))
+			assert.Regexp(t, dockerRepositoryName, tag)
+			assert.LessOrEqual(t, len(tag), len(TempImagePrefix)+maxBaseImageNameLength+13)
+		})
+	}
+}
+
+func TestBaseImageTagForDockerfile_Readable(t *testing.T) {
+	tag := baseImageTagForDockerfile("docker/Dockerfile.prod")
+	assert.True(t, strings.HasPrefix(tag, TempImagePrefix+"docker-dockerfile-prod-"))
+}
+
+func TestBaseImageTagForDockerfile_ResolvesPaths(t *testing.T) {
+	dir := t.TempDir()
+	dockerfile := filepath.Join(dir, "Dockerfile")
+	require.NoError(t, os.WriteFile(dockerfile, []byte("FROM scratch\n"), 0644))
+
+	link := filepath.Join(dir, "Dockerfile.link")
+	require.NoError(t, os.Symlink(dockerfile, link))
+
+	other := filepath.Join(dir, "other")
+	require.NoError(t, os.MkdirAll(other, 0755))
+	otherDockerfile := filepath.Join(other, "Dockerfile")
+	require.NoError(t, os.WriteFile(otherDockerfile, []byte("FROM scratch\n"), 0644))
+
+	hashOf := func(tag string) string { return tag[len(tag)-12:] }
+
+	// A symlink and its target share the same hash suffix
+	assert.Equal(t, hashOf(baseImageTagForDockerfile(dockerfile)), hashOf(baseImageTagForDockerfile(link)))
+	// Different files with the same base name do not collide
+	assert.NotEqual(t, baseImageTagForDockerfile(dockerfile), baseImageTagForDockerfile(otherDockerfile))
+}
+
+func TestLoadDockerConfigFile(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := loadDockerConfigFile(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+
+	invalid := filepath.Join(dir, "invalid.json")
+	require.NoError(t, os.WriteFile(invalid, []byte("{not json"), 0600))
+	_, err = loadDockerConfigFile(invalid)
+	assert.Error(t, err)
+
+	// "dXNlcjpwYXNz" is base64 for "user:pass"
+	valid := filepath.Join(dir, "config.json")
+	require.NoError(t, os.WriteFile(valid, []byte(`{"auths":{"ghcr.io":{"auth":"dXNlcjpwYXNz"}}}`), 0600))
+	configFile, err := loadDockerConfigFile(valid)
+	require.NoError(t, err)
+
+	authConfig, err := configFile.GetAuthConfig("ghcr.io")
+	require.NoError(t, err)
+	assert.Equal(t, "user", authConfig.Username)
+}
+
+func TestVerifyEmbeddedBinary(t *testing.T) {
+	data := []byte("binary")
+	sum := sha256.Sum256(data)
+
+	assert.NoError(t, verifyEmbeddedBinary("kms-client", data, hex.EncodeToString(sum[:])))
+
+	err := verifyEmbeddedBinary("kms-client", []byte("corrupted"), hex.EncodeToString(sum[:]))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "kms-client")
+}
+
+// TestEmbeddedBinaryChecksums fails when a binary in tools/ is rebuilt without updating its checksum
+func TestEmbeddedBinaryChecksums(t *testing.T) {
+	assert.NoError(t, verifyEmbeddedBinary(KMSClientBinaryName, project.RawKmsClient, project.KmsClientSHA256))
+	assert.NoError(t, verifyEmbeddedBinary(TlsKeygenBinaryName, project.RawTlsKeygenBinary, project.TlsKeygenSHA256))
+}
+
+func TestPushPermissionErrorTokenExpired(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  string
+		want bool
+	}{
+		{name: "ghcr expired PAT", msg: "unauthorized: unauthenticated: User cannot be authenticated with the token provided.", want: true},
+		{name: "expired token", msg: "denied: token has expired", want: true},
+		{name: "invalid token", msg: "error parsing HTTP 401 response body: invalid_token", want: true},
+		{name: "missing scope", msg: "denied: permission_denied: The token provided does not match expected scopes.", want: false},
+		{name: "repository access", msg: "denied: requested access to the resource is denied", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := &PushPermissionError{ImageRef: "ghcr.io/acme/app:latest", Err: errors.New(tt.msg)}
+			assert.Equal(t, tt.want, err.TokenExpired())
+		})
+	}
+	assert.False(t, (&PushPermissionError{ImageRef: "ghcr.io/acme/app:latest"}).TokenExpired())
+}
+
+func TestLayeredEntrypoint(t *testing.T) {
+	original := imageRunConfig{Entrypoint: []string{"server"}, Cmd: []string{"--port", "8080"}}
+	assert.Equal(t, []string{LayeredEntrypoint, "server"}, layeredEntrypoint(original))
+	assert.Equal(t, []string{"server", "--port", "8080"}, original.command())
+
+	cmdOnly := imageRunConfig{Cmd: []string{"node", "server.js"}}
+	assert.Equal(t, []string{LayeredEntrypoint}, layeredEntrypoint(cmdOnly))
+	assert.Equal(t, []string{"node", "server.js"}, cmdOnly.command())
+}
+
+func TestFormatCmdForDockerfile(t *testing.T) {
+	formatted, err := formatCmdForDockerfile([]string{"/bin/sh", "-c", `echo "hi"`})
+	require.NoError(t, err)
+	assert.Equal(t, `["/bin/sh","-c","echo \"hi\""]`, formatted)
+
+	formatted, err = formatCmdForDockerfile(nil)
+	require.NoError(t, err)
+	assert.Empty(t, formatted, "an empty CMD is left out of the Dockerfile")
+}
+
+func TestCheckLayeredCommand(t *testing.T) {
+	original := imageRunConfig{Entrypoint: []string{"server"}, Cmd: []string{"--port", "8080"}}
+	entrypoint := []string{LayeredEntrypoint, "server"}
+
+	assert.NoError(t, checkLayeredCommand(entrypoint, original.Cmd, original))
+	assert.NoError(t, checkLayeredCommand([]string{LayeredEntrypoint}, []string{"node", "server.js"}, imageRunConfig{Cmd: []string{"node", "server.js"}}))
+
+	err := checkLayeredCommand([]string{LayeredEntrypoint}, original.Cmd, original)
+	assert.ErrorContains(t, err, "ENTRYPOINT", "the base ENTRYPOINT was dropped")
+
+	err = checkLayeredCommand(entrypoint, nil, original)
+	assert.ErrorContains(t, err, "CMD", "the default arguments were dropped")
+
+	err = checkLayeredCommand([]string{LayeredEntrypoint}, nil, imageRunConfig{})
+	assert.ErrorContains(t, err, "no CMD or ENTRYPOINT")
+}
+
+func TestUserBuildArgumentsTarget(t *testing.T) {
+	newContext := func(args ...string) *cli.Context {
+		set := flag.NewFlagSet("test", flag.ContinueOnError)
+		for _, f := range []cli.Flag{common.BuildSecretFlag, common.BuildArgFlag, common.DockerfileTargetFlag} {
+			require.NoError(t, f.Apply(set))
+		}
+		require.NoError(t, set.Parse(args))
+		return cli.NewContext(&cli.App{}, set, nil)
+	}
+
+	args, err := userBuildArguments(newContext("--build-arg", "VERSION=1.2.3", "--dockerfile-target", "production"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"--build-arg", "VERSION=1.2.3", "--target", "production"}, args)
+
+	args, err = userBuildArguments(newContext())
+	require.NoError(t, err)
+	assert.Empty(t, args)
+
+	_, err = userBuildArguments(newContext("--dockerfile-target", " "))
+	assert.ErrorContains(t, err, "--dockerfile-target must not be empty")
+}
@@ -2,6 +2,8 @@ package utils
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"math/big"
 	"slices"
@@ -10,6 +12,8 @@ import (
 
 	"github.com/Layr-Labs/eigenx-cli/pkg/common"
 	"github.com/Layr-Labs/eigenx-cli/pkg/common/iface"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common/output"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common/progress"
 	"github.com/Layr-Labs/eigenx-contracts/pkg/bindings/v1/AppController"
 	"github.com/Layr-Labs/eigenx-contracts/pkg/bindings/v2/IPermissionController"
 	"github.com/Layr-Labs/eigenx-kms/pkg/types"
@@ -17,7 +21,6 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/fatih/color"
 	"github.com/urfave/cli/v2"
 )
@@ -26,48 +29,75 @@ import (
 func GetAppID(cCtx *cli.Context, argIndex int) (ethcommon.Address, error) {
 	// Check if app_id provided as argument
 	if cCtx.Args().Len() > argIndex {
-		nameOrID := cCtx.Args().Get(argIndex)
+		return ResolveAppIDOrName(cCtx, cCtx.Args().Get(argIndex))
+	}
 
-		// Get environment config for context
-		environmentConfig, err := GetEnvironmentConfig(cCtx)
-		if err != nil {
-			return ethcommon.Address{}, fmt.Errorf("failed to get environment config: %w", err)
-		}
+	return ethcommon.Address{}, fmt.Errorf("app id or name required. Provide as argument or ensure you're in a project directory with deployment info")
+}
 
-		// First try to resolve as a name from the registry
-		resolvedID, err := common.ResolveAppID(environmentConfig.Name, nameOrID)
-		if err == nil {
-			return ethcommon.HexToAddress(resolvedID), nil
-		}
+// ResolveAppIDOrName resolves an app name from the registry of the current environment, or parses an app id
+func ResolveAppIDOrName(cCtx *cli.Context, nameOrID string) (ethcommon.Address, error) {
+	// Get environment config for context
+	environmentConfig, err := GetEnvironmentConfig(cCtx)
+	if err != nil {
+		return ethcommon.Address{}, fmt.Errorf("failed to get environment config: %w", err)
+	}
 
-		// If not a name, check if it's a valid hex address
-		if ethcommon.IsHexAddress(nameOrID) {
-			return ethcommon.HexToAddress(nameOrID), nil
-		}
+	// First try to resolve as a name from the registry
+	resolvedID, err := common.ResolveAppID(environmentConfig.Name, nameOrID)
+	if err == nil {
+		return ethcommon.HexToAddress(resolvedID), nil
+	}
 
-		return ethcommon.Address{}, fmt.Errorf("invalid app id or name: %s", nameOrID)
+	// If not a name, check if it's a valid hex address
+	if ethcommon.IsHexAddress(nameOrID) {
+		return ethcommon.HexToAddress(nameOrID), nil
 	}
 
-	return ethcommon.Address{}, fmt.Errorf("app id or name required. Provide as argument or ensure you're in a project directory with deployment info")
+	return ethcommon.Address{}, fmt.Errorf("invalid app id or name: %s", nameOrID)
+}
+
+// RPCContext derives the context for a single RPC call, bounded by --rpc-timeout
+func RPCContext(cCtx *cli.Context) (context.Context, context.CancelFunc) {
+	return common.RPCContext(cCtx.Context, cCtx.Duration(common.RpcTimeoutFlag.Name))
+}
+
+// DeploySalt returns the salt for a new app: derived from --salt when set, so the app id is
+// predictable, otherwise random
+func DeploySalt(cCtx *cli.Context) ([32]byte, error) {
+	if value := cCtx.String(common.SaltFlag.Name); value != "" {
+		return ParseSalt(value), nil
+	}
+
+	salt := [32]byte{}
+	if _, err := rand.Read(salt[:]); err != nil {
+		return salt, fmt.Errorf("failed to generate random salt: %w", err)
+	}
+	return salt, nil
 }
 
-func GetAppControllerBinding(cCtx *cli.Context) (*ethclient.Client, *AppController.AppController, error) {
+// ParseSalt uses a 0x-prefixed 32-byte hex value as the salt directly and hashes any other
+// value with keccak256
+func ParseSalt(value string) [32]byte {
+	if len(value) == 66 && strings.HasPrefix(value, "0x") {
+		if decoded, err := hex.DecodeString(value[2:]); err == nil {
+			return [32]byte(decoded)
+		}
+	}
+	return [32]byte(ethcrypto.Keccak256([]byte(value)))
+}
+
+func GetAppControllerBinding(cCtx *cli.Context) (*common.FailoverClient, *AppController.AppController, error) {
 	environmentConfig, err := GetEnvironmentConfig(cCtx)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to get environment config: %w", err)
 	}
 
-	// Get RPC URL from flag or use environment default
-	rpcURL, err := getRPCURL(cCtx, &environmentConfig)
+	client, err := DialRPC(cCtx, &environmentConfig)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	client, err := ethclient.Dial(rpcURL)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to connect to RPC endpoint %s: %w", rpcURL, err)
-	}
-
 	appController, err := AppController.NewAppController(environmentConfig.AppControllerAddress, client)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create AppController: %w", err)
@@ -76,6 +106,38 @@ func GetAppControllerBinding(cCtx *cli.Context) (*ethclient.Client, *AppControll
 	return client, appController, nil
 }
 
+// DialRPC connects to the RPC URLs of the environment, failing over between them in order
+func DialRPC(cCtx *cli.Context, environmentConfig *common.EnvironmentConfig) (*common.FailoverClient, error) {
+	rpcURLs, err := GetRPCURLs(cCtx, environmentConfig)
+	if err != nil {
+		return nil, err
+	}
+	return common.NewFailoverClient(rpcURLs, common.LoggerFromContext(cCtx))
+}
+
+// appsPageSize is the number of apps requested per GetAppsByDeveloper call
+const appsPageSize = 50
+
+// GetAllAppsByDeveloper pages through GetAppsByDeveloper and returns every app of the developer
+// with its config
+func GetAllAppsByDeveloper(cCtx *cli.Context, appController *AppController.AppController, developer ethcommon.Address) ([]ethcommon.Address, []AppController.IAppControllerAppConfig, error) {
+	var apps []ethcommon.Address
+	var configs []AppController.IAppControllerAppConfig
+	for offset := int64(0); ; offset += appsPageSize {
+		rpcCtx, cancel := RPCContext(cCtx)
+		page, err := appController.GetAppsByDeveloper(&bind.CallOpts{Context: rpcCtx}, developer, big.NewInt(offset), big.NewInt(appsPageSize))
+		cancel()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list apps: %w", err)
+		}
+		apps = append(apps, page.Apps...)
+		configs = append(configs, page.AppConfigsMem...)
+		if len(page.Apps) < appsPageSize {
+			return apps, configs, nil
+		}
+	}
+}
+
 // GetContractCaller creates a contract caller from the CLI context
 func GetContractCaller(cCtx *cli.Context) (*common.ContractCaller, error) {
 	logger := common.LoggerFromContext(cCtx)
@@ -86,14 +148,20 @@ func GetContractCaller(cCtx *cli.Context) (*common.ContractCaller, error) {
 		return nil, fmt.Errorf("failed to get environment config: %w", err)
 	}
 
-	// Get RPC URL from flag or environment default
-	rpcURL, err := getRPCURL(cCtx, &environmentConfig)
+	// Get RPC URLs from flag or environment default
+	rpcURLs, err := GetRPCURLs(cCtx, &environmentConfig)
 	if err != nil {
 		return nil, err
 	}
 
-	if rpcURL == environmentConfig.DefaultRPCURL {
-		logger.Debug("Using default RPC URL for environment %s: %s", environmentConfig.Name, rpcURL)
+	if rpcURLs[0] == environmentConfig.DefaultRPCURL {
+		logger.Debug("Using default RPC URL for environment %s: %s", environmentConfig.Name, rpcURLs[0])
+	} else if !cCtx.IsSet(common.RpcUrlFlag.Name) {
+		logger.Debug("Using saved RPC URL for environment %s: %s", environmentConfig.Name, rpcURLs[0])
+	}
+
+	if cCtx.IsSet(common.ConfirmationsFlag.Name) && cCtx.Int(common.ConfirmationsFlag.Name) < 1 {
+		return nil, fmt.Errorf("--%s must be at least 1", common.ConfirmationsFlag.Name)
 	}
 
 	// Get private key from flag or environment
@@ -102,14 +170,16 @@ func GetContractCaller(cCtx *cli.Context) (*common.ContractCaller, error) {
 		return nil, fmt.Errorf("failed to get private key: %w", err)
 	}
 
-	// Connect to RPC endpoint
-	client, err := ethclient.Dial(rpcURL)
+	// Connect to the RPC endpoints, failing over between them
+	client, err := common.NewFailoverClient(rpcURLs, logger)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to RPC endpoint %s: %w", rpcURL, err)
+		return nil, err
 	}
 
 	// Get chain ID from the client
-	chainID, err := client.ChainID(cCtx.Context)
+	rpcCtx, cancel := RPCContext(cCtx)
+	chainID, err := client.ChainID(rpcCtx)
+	cancel()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get chain ID: %w", err)
 	}
@@ -125,10 +195,38 @@ func GetContractCaller(cCtx *cli.Context) (*common.ContractCaller, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create contract caller: %w", err)
 	}
+	contractCaller.SetRPCTimeout(cCtx.Duration(common.RpcTimeoutFlag.Name))
+	contractCaller.SetReuseDelegationCheck(cCtx.Bool(common.ReuseDelegationCheckFlag.Name))
+	contractCaller.SetConfirmations(cCtx.Int(common.ConfirmationsFlag.Name))
+	contractCaller.SetReplacePending(func() (bool, error) { return replacePendingChoice(cCtx) })
 
 	return contractCaller, nil
 }
 
+const (
+	pendingTxProceedOption = "Proceed: send this transaction after the pending ones"
+	pendingTxSpeedUpOption = "Speed up the oldest pending transaction by re-sending it at a higher gas price"
+)
+
+// replacePendingChoice decides whether to speed up the oldest pending transaction before sending
+// the next one: always with --replace-pending, never with --force or without a terminal, and
+// otherwise as the user chooses
+func replacePendingChoice(cCtx *cli.Context) (bool, error) {
+	if cCtx.Bool(common.ReplacePendingFlag.Name) {
+		return true, nil
+	}
+	if cCtx.Bool(common.ForceFlag.Name) || !progress.IsTTY() {
+		common.LoggerFromContext(cCtx).Warn("Pass --%s to speed up the oldest one by re-sending it at a higher gas price", common.ReplacePendingFlag.Name)
+		return false, nil
+	}
+
+	choice, err := output.SelectString("How do you want to continue?", []string{pendingTxProceedOption, pendingTxSpeedUpOption})
+	if err != nil {
+		return false, fmt.Errorf("failed to get selection: %w", err)
+	}
+	return choice == pendingTxSpeedUpOption, nil
+}
+
 // CalculateAndSignApiPermissionDigest calculates the API permission digest using the contract
 // and signs it with the user's private key
 func CalculateAndSignApiPermissionDigest(
@@ -156,7 +254,10 @@ func CalculateAndSignApiPermissionDigest(
 	defer client.Close()
 
 	// Call the contract to calculate the digest hash
-	digestHash, err := appController.CalculateApiPermissionDigestHash(&bind.CallOpts{Context: cCtx.Context}, permission, expiry)
+	rpcCtx, cancel := RPCContext(cCtx)
+	defer cancel()
+
+	digestHash, err := appController.CalculateApiPermissionDigestHash(&bind.CallOpts{Context: rpcCtx}, permission, expiry)
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate digest hash: %w", err)
 	}
@@ -184,7 +285,7 @@ func GetAppProfileName(cCtx *cli.Context, appID ethcommon.Address) string {
 		return ""
 	}
 
-	info, err := userApiClient.GetInfos(cCtx, []ethcommon.Address{appID}, 1)
+	info, err := userApiClient.GetInfos(cCtx, []ethcommon.Address{appID}, 1, AddressChainsAll)
 	if err == nil && len(info.Apps) > 0 && info.Apps[0].Profile != nil {
 		return info.Apps[0].Profile.Name
 	}
@@ -194,16 +295,28 @@ func GetAppProfileName(cCtx *cli.Context, appID ethcommon.Address) string {
 func GetAndPrintAppInfo(cCtx *cli.Context, appID ethcommon.Address, statusOverride ...string) error {
 	logger := common.LoggerFromContext(cCtx)
 
+	chains, err := AddressChainsFromContext(cCtx)
+	if err != nil {
+		return err
+	}
+
 	client, appController, err := GetAppControllerBinding(cCtx)
 	if err != nil {
 		return fmt.Errorf("failed to get contract caller: %w", err)
 	}
 
-	// Get app status and release block number concurrently
-	status, releaseBlockNumber, err := common.Parallel(
-		func() (uint8, error) { return appController.GetAppStatus(&bind.CallOpts{Context: cCtx.Context}, appID) },
-		func() (uint32, error) {
-			return appController.GetAppLatestReleaseBlockNumber(&bind.CallOpts{Context: cCtx.Context}, appID)
+	// Get app status and release block number concurrently, stopping the other call if one fails
+	rpcTimeout := cCtx.Duration(common.RpcTimeoutFlag.Name)
+	status, releaseBlockNumber, err := common.ParallelCtx(cCtx.Context,
+		func(ctx context.Context) (uint8, error) {
+			rpcCtx, cancel := common.RPCContext(ctx, rpcTimeout)
+			defer cancel()
+			return appController.GetAppStatus(&bind.CallOpts{Context: rpcCtx}, appID)
+		},
+		func(ctx context.Context) (uint32, error) {
+			rpcCtx, cancel := common.RPCContext(ctx, rpcTimeout)
+			defer cancel()
+			return appController.GetAppLatestReleaseBlockNumber(&bind.CallOpts{Context: rpcCtx}, appID)
 		},
 	)
 	if err != nil {
@@ -231,7 +344,7 @@ func GetAndPrintAppInfo(cCtx *cli.Context, appID ethcommon.Address, statusOverri
 		LatestReleaseBlockNumber: releaseBlockNumber,
 	}
 
-	info, err := userApiClient.GetInfos(cCtx, []ethcommon.Address{appID}, count)
+	info, err := userApiClient.GetInfos(cCtx, []ethcommon.Address{appID}, count, chains)
 	if err != nil {
 		return fmt.Errorf("failed to get info: %w", err)
 	}
@@ -245,7 +358,10 @@ func GetAndPrintAppInfo(cCtx *cli.Context, appID ethcommon.Address, statusOverri
 	if len(statusOverride) > 0 {
 		override = statusOverride[0]
 	}
-	err = PrintAppInfoWithStatus(cCtx.Context, logger, client, appID, config, info.Apps[0], environmentConfig.Name, override)
+	rpcCtx, cancel := RPCContext(cCtx)
+	defer cancel()
+
+	err = PrintAppInfoWithStatus(rpcCtx, logger, client, appID, config, info.Apps[0], environmentConfig.Name, override)
 	if err != nil {
 		return fmt.Errorf("failed to print app info: %w", err)
 	}
@@ -253,11 +369,58 @@ func GetAndPrintAppInfo(cCtx *cli.Context, appID ethcommon.Address, statusOverri
 	return nil
 }
 
-func PrintAppInfo(ctx context.Context, logger iface.Logger, client *ethclient.Client, appID ethcommon.Address, config AppController.IAppControllerAppConfig, info AppInfo, environmentName string) error {
+// AppStatusSummary is the reconciled status of an app with the contract and API statuses it
+// was derived from
+type AppStatusSummary struct {
+	AppID          string `json:"appId"`
+	Status         string `json:"status"`
+	ContractStatus string `json:"contractStatus"`
+	ApiStatus      string `json:"apiStatus,omitempty"`
+}
+
+// GetAppStatusSummary reads an app's status from the AppController and the userApi /status
+// endpoint, which needs no sensitive-info permission. When the API is unreachable, the status
+// falls back to the contract status.
+func GetAppStatusSummary(cCtx *cli.Context, appID ethcommon.Address) (*AppStatusSummary, error) {
+	logger := common.LoggerFromContext(cCtx)
+
+	_, appController, err := GetAppControllerBinding(cCtx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contract caller: %w", err)
+	}
+	rpcCtx, cancel := RPCContext(cCtx)
+	contractStatus, err := appController.GetAppStatus(&bind.CallOpts{Context: rpcCtx}, appID)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get app status: %w", err)
+	}
+
+	apiStatus := ""
+	userApiClient, err := NewUserApiClient(cCtx)
+	if err == nil {
+		var statuses *AppStatusResponse
+		statuses, err = userApiClient.GetStatuses(cCtx, []ethcommon.Address{appID})
+		if err == nil && len(statuses.Apps) > 0 {
+			apiStatus = statuses.Apps[0].Status
+		}
+	}
+	if err != nil {
+		logger.Warn("Failed to get status from the API, showing the onchain status: %v", err)
+	}
+
+	return &AppStatusSummary{
+		AppID:          appID.Hex(),
+		Status:         getDisplayStatus(contractStatus, apiStatus),
+		ContractStatus: contractStatusToString(contractStatus),
+		ApiStatus:      apiStatus,
+	}, nil
+}
+
+func PrintAppInfo(ctx context.Context, logger iface.Logger, client *common.FailoverClient, appID ethcommon.Address, config AppController.IAppControllerAppConfig, info AppInfo, environmentName string) error {
 	return PrintAppInfoWithStatus(ctx, logger, client, appID, config, info, environmentName, "")
 }
 
-func PrintAppInfoWithStatus(ctx context.Context, logger iface.Logger, client *ethclient.Client, appID ethcommon.Address, config AppController.IAppControllerAppConfig, info AppInfo, environmentName string, statusOverride string) error {
+func PrintAppInfoWithStatus(ctx context.Context, logger iface.Logger, client *common.FailoverClient, appID ethcommon.Address, config AppController.IAppControllerAppConfig, info AppInfo, environmentName string, statusOverride string) error {
 	latestReleaseBlockTime := time.Time{}
 	if config.LatestReleaseBlockNumber != 0 {
 		// get timestamp for block number
@@ -345,18 +508,23 @@ func contractStatusToString(status uint8) string {
 	case common.ContractAppStatusNone:
 		return "None"
 	case common.ContractAppStatusStarted:
-		return "Running"
+		return common.AppStatusRunning
 	case common.ContractAppStatusStopped:
-		return "Stopped"
+		return common.AppStatusStopped
 	case common.ContractAppStatusSuspended:
-		return "Suspended"
+		return common.AppStatusSuspended
 	case common.ContractAppStatusTerminated:
-		return "Terminated"
+		return common.AppStatusTerminated
 	default:
 		return "Unknown"
 	}
 }
 
+// DisplayStatus reconciles an app's contract and API statuses into the status shown to users
+func DisplayStatus(config AppController.IAppControllerAppConfig, info AppInfo) string {
+	return getDisplayStatus(config.Status, info.Status)
+}
+
 // getDisplayStatus compares contract and API status and returns appropriate display string
 func getDisplayStatus(contractStatus uint8, apiStatus string, statusOverride ...string) string {
 	// If override provided, use it
@@ -373,6 +541,9 @@ func getDisplayStatus(contractStatus uint8, apiStatus string, statusOverride ...
 	if strings.EqualFold(apiStatus, common.AppStatusExited) {
 		return common.AppStatusExited
 	}
+	if strings.EqualFold(apiStatus, common.AppStatusFailed) {
+		return common.AppStatusFailed
+	}
 
 	contractStatusStr := contractStatusToString(contractStatus)
 
@@ -383,9 +554,9 @@ func getDisplayStatus(contractStatus uint8, apiStatus string, statusOverride ...
 
 	// States differ - check if we're in a transition
 	transitions := map[string]string{
-		"Running":    "Starting",
-		"Stopped":    "Stopping",
-		"Terminated": "Terminating",
+		common.AppStatusRunning:    common.AppStatusStarting,
+		common.AppStatusStopped:    common.AppStatusStopping,
+		common.AppStatusTerminated: common.AppStatusTerminating,
 	}
 
 	if transition, exists := transitions[contractStatusStr]; exists {
@@ -396,16 +567,40 @@ func getDisplayStatus(contractStatus uint8, apiStatus string, statusOverride ...
 	return apiStatus
 }
 
-// getRPCURL gets RPC URL from flag or environment default
-func getRPCURL(cCtx *cli.Context, environmentConfig *common.EnvironmentConfig) (string, error) {
-	rpcURL := cCtx.String(common.RpcUrlFlag.Name)
-	if rpcURL == "" && environmentConfig != nil && environmentConfig.DefaultRPCURL != "" {
-		rpcURL = environmentConfig.DefaultRPCURL
+// GetRPCURL gets RPC URL from flag or environment default
+func GetRPCURL(cCtx *cli.Context, environmentConfig *common.EnvironmentConfig) (string, error) {
+	rpcURLs, err := GetRPCURLs(cCtx, environmentConfig)
+	if err != nil {
+		return "", err
 	}
-	if rpcURL == "" {
-		return "", fmt.Errorf("rpc-url required. Provide via --rpc-url flag or ensure environment has default RPC URL")
+	return rpcURLs[0], nil
+}
+
+// GetRPCURLs returns the RPC URLs from --rpc-url, in failover order, then the URL saved with
+// 'environment set-rpc', then the environment default
+func GetRPCURLs(cCtx *cli.Context, environmentConfig *common.EnvironmentConfig) ([]string, error) {
+	rpcURLs := parseRPCURLs(cCtx.String(common.RpcUrlFlag.Name))
+	if len(rpcURLs) == 0 && environmentConfig != nil {
+		rpcURLs = parseRPCURLs(common.GetRPCURLOverride(environmentConfig.Name))
+	}
+	if len(rpcURLs) == 0 && environmentConfig != nil && environmentConfig.DefaultRPCURL != "" {
+		rpcURLs = []string{environmentConfig.DefaultRPCURL}
+	}
+	if len(rpcURLs) == 0 {
+		return nil, fmt.Errorf("rpc-url required. Provide via --rpc-url flag or ensure environment has default RPC URL")
+	}
+	return rpcURLs, nil
+}
+
+// parseRPCURLs splits a comma-separated --rpc-url value, dropping empty entries
+func parseRPCURLs(value string) []string {
+	var rpcURLs []string
+	for _, rpcURL := range strings.Split(value, ",") {
+		if rpcURL = strings.TrimSpace(rpcURL); rpcURL != "" {
+			rpcURLs = append(rpcURLs, rpcURL)
+		}
 	}
-	return rpcURL, nil
+	return rpcURLs
 }
 
 // CheckAppLogPermission checks if an app currently has public log viewing permissions
@@ -416,15 +611,9 @@ func CheckAppLogPermission(cCtx *cli.Context, appAddress ethcommon.Address) (boo
 		return false, fmt.Errorf("failed to get environment config: %w", err)
 	}
 
-	// Get RPC URL and connect to client
-	rpcURL, err := getRPCURL(cCtx, &environmentConfig)
-	if err != nil {
-		return false, fmt.Errorf("failed to get RPC URL: %w", err)
-	}
-
-	client, err := ethclient.Dial(rpcURL)
+	client, err := DialRPC(cCtx, &environmentConfig)
 	if err != nil {
-		return false, fmt.Errorf("failed to connect to RPC endpoint %s: %w", rpcURL, err)
+		return false, err
 	}
 	defer client.Close()
 
@@ -439,7 +628,10 @@ func CheckAppLogPermission(cCtx *cli.Context, appAddress ethcommon.Address) (boo
 		common.CanViewAppLogsPermission)
 
 	// Call the contract
-	result, err := client.CallContract(cCtx.Context, ethereum.CallMsg{
+	rpcCtx, cancel := RPCContext(cCtx)
+	defer cancel()
+
+	result, err := client.CallContract(rpcCtx, ethereum.CallMsg{
 		To:   &environmentConfig.PermissionControllerAddress,
 		Data: data,
 	}, nil)
@@ -465,8 +657,11 @@ func WatchAppInfoLoop(cCtx *cli.Context, appID ethcommon.Address, stopCondition
 	logger := common.LoggerFromContext(cCtx)
 
 	// Display initial info (with optional status override)
-	if err := GetAndPrintAppInfo(cCtx, appID, statusOverride...); err != nil {
-		return err
+	quiet := common.IsQuietOutput(cCtx.Context)
+	if !quiet {
+		if err := GetAndPrintAppInfo(cCtx, appID, statusOverride...); err != nil {
+			return err
+		}
 	}
 
 	// Track previous state for comparison
@@ -480,17 +675,32 @@ func WatchAppInfoLoop(cCtx *cli.Context, appID ethcommon.Address, stopCondition
 	}
 
 	// Fetch initial state
-	info, err := userApiClient.GetInfos(cCtx, []ethcommon.Address{appID}, 1)
+	info, err := userApiClient.GetInfos(cCtx, []ethcommon.Address{appID}, 1, AddressChainsAll)
 	if err == nil && len(info.Apps) > 0 {
 		prevStatus = info.Apps[0].Status
 		prevIP = info.Apps[0].Ip
 		prevMachineType = info.Apps[0].MachineType
 	}
 
+	// With --wait-interval-backoff the interval grows while nothing changes. A poll interval
+	// suggested by the server takes precedence until the status changes.
+	baseInterval := WatchPollInterval(cCtx)
+	interval := baseInterval
+	backoff := cCtx.Bool(common.WaitIntervalBackoffFlag.Name)
+	if err == nil {
+		if suggested := boundSuggestedInterval(info.PollInterval); suggested > 0 {
+			interval = suggested
+		}
+	}
+
 	// Main watch loop
 	for {
 		// Show countdown
-		ShowCountdown(cCtx.Context, common.WatchPollIntervalSeconds)
+		if quiet {
+			sleepWithContext(cCtx.Context, time.Duration(interval)*time.Second)
+		} else {
+			ShowCountdown(cCtx.Context, interval)
+		}
 
 		select {
 		case <-cCtx.Context.Done():
@@ -498,7 +708,7 @@ func WatchAppInfoLoop(cCtx *cli.Context, appID ethcommon.Address, stopCondition
 			return nil
 		default:
 			// Fetch fresh info
-			info, err := userApiClient.GetInfos(cCtx, []ethcommon.Address{appID}, 1)
+			info, err := userApiClient.GetInfos(cCtx, []ethcommon.Address{appID}, 1, AddressChainsAll)
 			if err != nil {
 				logger.Warn("Failed to fetch app info: %v", err)
 				continue
@@ -512,6 +722,18 @@ func WatchAppInfoLoop(cCtx *cli.Context, appID ethcommon.Address, stopCondition
 			currentIP := info.Apps[0].Ip
 			currentMachineType := info.Apps[0].MachineType
 
+			suggested := boundSuggestedInterval(info.PollInterval)
+			switch {
+			case currentStatus != prevStatus:
+				interval = baseInterval
+			case suggested > 0:
+				interval = suggested
+			case backoff:
+				interval = nextBackoffInterval(interval)
+			default:
+				interval = baseInterval
+			}
+
 			// Print status changes
 			if currentStatus != prevStatus {
 				// Check if we should notify about this status
@@ -567,6 +789,113 @@ func WatchAppInfoLoop(cCtx *cli.Context, appID ethcommon.Address, stopCondition
 	}
 }
 
+// dashboardLogSize bounds how much of the most recent logs the info dashboard shows
+const dashboardLogSize = 2048 // 2KB
+
+// WatchAppInfoDashboard shows the full app info and the tail of its logs every interval seconds
+// until the context is cancelled. On a terminal the screen is redrawn in place, otherwise each
+// refresh is printed after the previous one.
+func WatchAppInfoDashboard(cCtx *cli.Context, appID ethcommon.Address, interval int) error {
+	logger := common.LoggerFromContext(cCtx)
+	gray := color.New(color.FgHiBlack)
+	tty := progress.IsTTY()
+
+	userApiClient, err := NewUserApiClient(cCtx)
+	if err != nil {
+		return fmt.Errorf("failed to get userApi client: %w", err)
+	}
+
+	for {
+		if tty {
+			output.ClearTerminal()
+		} else {
+			fmt.Printf("=== %s ===\n", time.Now().Format(time.DateTime))
+		}
+		if err := GetAndPrintAppInfo(cCtx, appID); err != nil {
+			// Keep the dashboard running through transient API or RPC errors
+			logger.Warn("Failed to fetch app info: %v", err)
+		}
+
+		logger.Info("Recent logs:")
+		if logs, err := userApiClient.GetLogs(cCtx, appID); err != nil {
+			gray.Printf("  Logs unavailable: %v\n", err)
+		} else if tail := strings.TrimRight(LogTail(logs, dashboardLogSize), "\n"); tail == "" {
+			gray.Println("  No logs yet")
+		} else {
+			fmt.Println(common.SanitizeTerminalOutput(tail))
+		}
+		fmt.Println()
+
+		if !tty {
+			sleepWithContext(cCtx.Context, time.Duration(interval)*time.Second)
+		} else {
+			gray.Printf("Last updated %s (Ctrl+C to stop)\n", time.Now().Format(time.TimeOnly))
+			ShowCountdown(cCtx.Context, interval)
+		}
+
+		select {
+		case <-cCtx.Context.Done():
+			fmt.Println("\nStopped watching")
+			return nil
+		default:
+		}
+	}
+}
+
+// LogTail returns the last complete lines of logs, at most maxSize bytes but always at least one line
+func LogTail(logs string, maxSize int) string {
+	if len(logs) <= maxSize {
+		return logs
+	}
+	start := len(logs) - maxSize
+	// Advance to the start of the next line so the tail never begins mid-line
+	if nl := strings.IndexByte(logs[start-1:], '\n'); nl != -1 && start-1+nl+1 < len(logs) {
+		return logs[start-1+nl+1:]
+	}
+	// A single line longer than maxSize: use the whole last line
+	lastLineStart := strings.LastIndexByte(logs[:len(logs)-1], '\n') + 1
+	return logs[lastLineStart:]
+}
+
+// WatchPollInterval returns the watch refresh interval in whole seconds from --poll-interval,
+// falling back to the default for commands without the flag
+func WatchPollInterval(cCtx *cli.Context) int {
+	if interval := cCtx.Duration(common.PollIntervalFlag.Name); interval >= time.Second {
+		return int(interval / time.Second)
+	}
+	return common.WatchPollIntervalSeconds
+}
+
+// nextBackoffInterval grows a poll interval by half, capped at WatchBackoffMaxIntervalSeconds
+func nextBackoffInterval(seconds int) int {
+	next := seconds + (seconds+1)/2
+	if next > common.WatchBackoffMaxIntervalSeconds {
+		return common.WatchBackoffMaxIntervalSeconds
+	}
+	return next
+}
+
+// boundSuggestedInterval converts a server-suggested poll interval to whole seconds between
+// WatchSuggestedMinIntervalSeconds and WatchBackoffMaxIntervalSeconds, or 0 if none was suggested
+func boundSuggestedInterval(suggested time.Duration) int {
+	if suggested <= 0 {
+		return 0
+	}
+	seconds := int((suggested + time.Second - 1) / time.Second)
+	return min(max(seconds, common.WatchSuggestedMinIntervalSeconds), common.WatchBackoffMaxIntervalSeconds)
+}
+
+// SkipWatch reports whether --wait=false was passed. Commands without the flag always wait.
+func SkipWatch(cCtx *cli.Context) bool {
+	return cCtx.IsSet(common.WaitFlag.Name) && !cCtx.Bool(common.WaitFlag.Name)
+}
+
+// PrintSkippedWatchHint prints the app ID and how to check on the app after --wait=false
+func PrintSkippedWatchHint(cCtx *cli.Context, appID ethcommon.Address) {
+	fmt.Printf("App ID: %s\n", appID.Hex())
+	common.LoggerFromContext(cCtx).Info("Not waiting for the app to start. Check its status with: eigenx app info %s", appID.Hex())
+}
+
 // WatchUntilTransitionComplete watches app info until operation completes (deploy, upgrade, start, stop)
 // statusOverride: if provided, indicates the operation type (e.g., "Deploying", "Upgrading", "Resuming", "Stopping")
 func WatchUntilTransitionComplete(cCtx *cli.Context, appID ethcommon.Address, statusOverride ...string) error {
@@ -580,6 +909,15 @@ func WatchUntilTransitionComplete(cCtx *cli.Context, appID ethcommon.Address, st
 	// Check if this is an upgrade operation
 	isUpgrading := len(statusOverride) > 0 && statusOverride[0] == common.AppStatusUpgrading
 
+	// Clear the countdown line before the final message
+	quiet := common.IsQuietOutput(cCtx.Context)
+	endWatch := func() {
+		if !quiet {
+			fmt.Print("\r                              \r")
+			fmt.Println()
+		}
+	}
+
 	// Stop condition: Watch for state transitions
 	stopCondition := func(status, ip string) (bool, error) {
 		// Capture initial state on first call
@@ -588,8 +926,7 @@ func WatchUntilTransitionComplete(cCtx *cli.Context, appID ethcommon.Address, st
 			initialIP = ip
 
 			if isUpgrading && status == common.AppStatusStopped && ip != "" {
-				fmt.Print("\r                              \r")
-				fmt.Println()
+				endWatch()
 				logger.Info("App upgrade complete.")
 				logger.Info("Status: %s", status)
 				logger.Info("To start the app, run `eigenx app start %s`", appID.Hex())
@@ -605,8 +942,7 @@ func WatchUntilTransitionComplete(cCtx *cli.Context, appID ethcommon.Address, st
 
 		// Exit on Running state with IP after seeing a change
 		if status == common.AppStatusRunning && ip != "" && hasChanged {
-			fmt.Print("\r                              \r")
-			fmt.Println()
+			endWatch()
 
 			if initialIP == "" || initialIP == "No IP assigned" {
 				logger.Info("App is now running with IP: %s", ip)
@@ -618,8 +954,7 @@ func WatchUntilTransitionComplete(cCtx *cli.Context, appID ethcommon.Address, st
 
 		// Check for failure states
 		if status == common.AppStatusFailed {
-			fmt.Print("\r                              \r")
-			fmt.Println()
+			endWatch()
 			return true, fmt.Errorf("app entered %s state", status)
 		}
 		return false, nil
@@ -627,11 +962,22 @@ func WatchUntilTransitionComplete(cCtx *cli.Context, appID ethcommon.Address, st
 
 	// Only notify on terminal states (Running or Failed)
 	notifyOnStates := []string{common.AppStatusRunning, common.AppStatusFailed}
-	return WatchAppInfoLoop(cCtx, appID, stopCondition, notifyOnStates, statusOverride...)
+
+	ReportStage(cCtx, StageWatch, 0, "Waiting for the app to start")
+	if err := WatchAppInfoLoop(cCtx, appID, stopCondition, notifyOnStates, statusOverride...); err != nil {
+		return err
+	}
+	ReportStage(cCtx, StageWatch, 100, "Transition complete")
+	return nil
 }
 
-// ShowCountdown displays a countdown timer with gray text
+// ShowCountdown displays a countdown timer with gray text, or just waits in quiet mode
 func ShowCountdown(ctx context.Context, seconds int) {
+	if common.IsQuietOutput(ctx) {
+		sleepWithContext(ctx, time.Duration(seconds)*time.Second)
+		return
+	}
+
 	gray := color.New(color.FgHiBlack)
 
 	for i := seconds; i >= 0; i-- {
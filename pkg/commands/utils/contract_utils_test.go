@@ -0,0 +1,110 @@
+package utils
+
+import (
+	"flag"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestNextBackoffInterval(t *testing.T) {
+	var intervals []int
+	for interval := common.WatchPollIntervalSeconds; interval < common.WatchBackoffMaxIntervalSeconds; {
+		interval = nextBackoffInterval(interval)
+		intervals = append(intervals, interval)
+	}
+	assert.Equal(t, []int{8, 12, 18, 27, 41, 60}, intervals)
+
+	assert.Equal(t, common.WatchBackoffMaxIntervalSeconds, nextBackoffInterval(common.WatchBackoffMaxIntervalSeconds))
+	assert.Equal(t, 2, nextBackoffInterval(1))
+}
+
+func TestBoundSuggestedInterval(t *testing.T) {
+	assert.Equal(t, 0, boundSuggestedInterval(0))
+	assert.Equal(t, common.WatchSuggestedMinIntervalSeconds, boundSuggestedInterval(500*time.Millisecond))
+	assert.Equal(t, 15, boundSuggestedInterval(15*time.Second))
+	assert.Equal(t, 16, boundSuggestedInterval(15*time.Second+time.Millisecond))
+	assert.Equal(t, common.WatchBackoffMaxIntervalSeconds, boundSuggestedInterval(10*time.Minute))
+}
+
+func TestParseRPCURLs(t *testing.T) {
+	assert.Nil(t, parseRPCURLs(""))
+	assert.Equal(t, []string{"https://a.example"}, parseRPCURLs("https://a.example"))
+	assert.Equal(t, []string{"https://a.example", "https://b.example"}, parseRPCURLs(" https://a.example, ,https://b.example,"))
+}
+
+func TestLogTail(t *testing.T) {
+	logs := "one\ntwo\nthree\n"
+
+	assert.Equal(t, logs, LogTail(logs, 100))
+	assert.Equal(t, "three\n", LogTail(logs, 7))
+	assert.Equal(t, "two\nthree\n", LogTail(logs, 10))
+
+	long := strings.Repeat("x", 20) + "\n"
+	assert.Equal(t, long, LogTail("a\n"+long, 5), "a single long line is kept whole")
+}
+
+func TestParseSalt(t *testing.T) {
+	hexSalt := "0x" + strings.Repeat("0a", 32)
+	assert.Equal(t, [32]byte(ethcommon.HexToHash(hexSalt)), ParseSalt(hexSalt))
+
+	// Anything that is not exactly 32 bytes of hex is hashed, including short hex
+	assert.Equal(t, [32]byte(ethcrypto.Keccak256Hash([]byte("my-app"))), ParseSalt("my-app"))
+	assert.Equal(t, [32]byte(ethcrypto.Keccak256Hash([]byte("0x1234"))), ParseSalt("0x1234"))
+	assert.Equal(t, ParseSalt("staging"), ParseSalt("staging"))
+	assert.NotEqual(t, ParseSalt("staging"), ParseSalt("production"))
+}
+
+func TestGetDisplayStatus(t *testing.T) {
+	running := uint8(common.ContractAppStatusStarted)
+	stopped := uint8(common.ContractAppStatusStopped)
+
+	assert.Equal(t, "Running", getDisplayStatus(running, ""))
+	assert.Equal(t, "Running", getDisplayStatus(running, "Running"))
+	assert.Equal(t, "Starting", getDisplayStatus(running, "Deploying"))
+	assert.Equal(t, "Stopping", getDisplayStatus(stopped, "Running"))
+	assert.Equal(t, common.AppStatusExited, getDisplayStatus(running, "exited"))
+	assert.Equal(t, common.AppStatusFailed, getDisplayStatus(running, "Failed"), "a failed app must not look like it is starting")
+	assert.Equal(t, "Upgrading", getDisplayStatus(running, "Running", "Upgrading"))
+}
+
+func TestGetDisplayStatus_IsKnownStatus(t *testing.T) {
+	contractStatuses := []common.AppStatus{
+		common.ContractAppStatusStarted,
+		common.ContractAppStatusStopped,
+		common.ContractAppStatusSuspended,
+		common.ContractAppStatusTerminated,
+	}
+	for _, contractStatus := range contractStatuses {
+		for _, apiStatus := range append([]string{""}, common.AppDisplayStatuses...) {
+			status := getDisplayStatus(uint8(contractStatus), apiStatus)
+			assert.Contains(t, common.AppDisplayStatuses, status, "contract status %d, API status %q", contractStatus, apiStatus)
+		}
+	}
+}
+
+func TestReplacePendingChoice(t *testing.T) {
+	newContext := func(args ...string) *cli.Context {
+		set := flag.NewFlagSet("test", flag.ContinueOnError)
+		require.NoError(t, common.ReplacePendingFlag.Apply(set))
+		require.NoError(t, common.ForceFlag.Apply(set))
+		require.NoError(t, set.Parse(args))
+		return cli.NewContext(&cli.App{}, set, nil)
+	}
+
+	replace, err := replacePendingChoice(newContext("--replace-pending"))
+	require.NoError(t, err)
+	assert.True(t, replace)
+
+	// --force never prompts and leaves pending transactions alone
+	replace, err = replacePendingChoice(newContext("--force"))
+	require.NoError(t, err)
+	assert.False(t, replace)
+}
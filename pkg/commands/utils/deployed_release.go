@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	appcontrollerV1 "github.com/Layr-Labs/eigenx-contracts/pkg/bindings/v1/AppController"
+	appcontrollerV2 "github.com/Layr-Labs/eigenx-contracts/pkg/bindings/v2/AppController"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// DeployedRelease is the image and public env of an app's latest on-chain release
+type DeployedRelease struct {
+	Digest    [32]byte
+	Registry  string
+	PublicEnv map[string]string
+}
+
+// GetDeployedRelease reads the latest release of an app from the AppController
+func GetDeployedRelease(ctx context.Context, caller *common.ContractCaller, appID gethcommon.Address) (*DeployedRelease, error) {
+	release, err := caller.GetLatestRelease(ctx, appID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest release: %w", err)
+	}
+	return newDeployedRelease(release)
+}
+
+// newDeployedRelease extracts the first image artifact and decodes the public env of a release
+func newDeployedRelease(release *appcontrollerV1.IAppControllerRelease) (*DeployedRelease, error) {
+	if len(release.RmsRelease.Artifacts) == 0 {
+		return nil, fmt.Errorf("release has no image artifacts")
+	}
+	artifact := release.RmsRelease.Artifacts[0]
+
+	publicEnv := map[string]string{}
+	if len(release.PublicEnv) > 0 {
+		if err := json.Unmarshal(release.PublicEnv, &publicEnv); err != nil {
+			return nil, fmt.Errorf("failed to decode public env: %w", err)
+		}
+	}
+
+	return &DeployedRelease{
+		Digest:    artifact.Digest,
+		Registry:  artifact.Registry,
+		PublicEnv: publicEnv,
+	}, nil
+}
+
+// ImageRef returns the digest-pinned image reference of the release
+func (r *DeployedRelease) ImageRef() string {
+	return fmt.Sprintf("%s@%s%s", r.Registry, SHA256Prefix, hex.EncodeToString(r.Digest[:]))
+}
+
+// ReleaseUpToDate reports whether the latest release of an app already has the image and public
+// env of release. Apps without releases, including ones not created yet, and terminated apps are
+// never up to date. The private env is encrypted with a fresh key for every release, so changes
+// to it are not detected.
+func ReleaseUpToDate(ctx context.Context, caller *common.ContractCaller, appID gethcommon.Address, release appcontrollerV2.IAppControllerRelease) (bool, error) {
+	status, err := caller.GetAppStatus(ctx, appID)
+	if err != nil {
+		return false, err
+	}
+	if status == common.ContractAppStatusTerminated {
+		return false, nil
+	}
+
+	deployed, err := caller.GetLatestRelease(ctx, appID)
+	if errors.Is(err, common.ErrNoReleases) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get latest release: %w", err)
+	}
+	return releaseMatches(deployed, release)
+}
+
+// releaseMatches reports whether a deployed and a local release have the same image and public env
+func releaseMatches(deployed *appcontrollerV1.IAppControllerRelease, local appcontrollerV2.IAppControllerRelease) (bool, error) {
+	deployedSnapshot, err := newReleaseSnapshot(deployedImage(deployed), deployed.PublicEnv)
+	if err != nil {
+		return false, fmt.Errorf("failed to read deployed release: %w", err)
+	}
+	localSnapshot, err := newReleaseSnapshot(localImage(local), local.PublicEnv)
+	if err != nil {
+		return false, fmt.Errorf("failed to read local release: %w", err)
+	}
+	return deployedSnapshot.Image != "" && len(diffReleaseSnapshots(deployedSnapshot, localSnapshot)) == 0, nil
+}
@@ -0,0 +1,69 @@
+package utils
+
+import (
+	"testing"
+
+	appcontrollerV1 "github.com/Layr-Labs/eigenx-contracts/pkg/bindings/v1/AppController"
+	appcontrollerV2 "github.com/Layr-Labs/eigenx-contracts/pkg/bindings/v2/AppController"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDeployedRelease(t *testing.T) {
+	release := &appcontrollerV1.IAppControllerRelease{
+		RmsRelease: appcontrollerV1.IReleaseManagerTypesRelease{
+			Artifacts: []appcontrollerV1.IReleaseManagerTypesArtifact{
+				{Digest: [32]byte{0xab}, Registry: "docker.io/user/app"},
+			},
+		},
+		PublicEnv: []byte(`{"PORT_PUBLIC":"8080","EIGEN_MACHINE_TYPE_PUBLIC":"g1-standard-4t"}`),
+	}
+
+	deployed, err := newDeployedRelease(release)
+	require.NoError(t, err)
+	assert.Equal(t, "docker.io/user/app", deployed.Registry)
+	assert.Equal(t, map[string]string{"PORT_PUBLIC": "8080", "EIGEN_MACHINE_TYPE_PUBLIC": "g1-standard-4t"}, deployed.PublicEnv)
+	assert.Equal(t, "docker.io/user/app@sha256:ab00000000000000000000000000000000000000000000000000000000000000", deployed.ImageRef())
+
+	_, err = newDeployedRelease(&appcontrollerV1.IAppControllerRelease{})
+	assert.Error(t, err)
+}
+
+func TestReleaseMatches(t *testing.T) {
+	deployed := &appcontrollerV1.IAppControllerRelease{
+		RmsRelease: appcontrollerV1.IReleaseManagerTypesRelease{
+			Artifacts: []appcontrollerV1.IReleaseManagerTypesArtifact{
+				{Digest: [32]byte{0xab}, Registry: "docker.io/user/app"},
+			},
+		},
+		PublicEnv:    []byte(`{"PORT_PUBLIC":"8080","EIGEN_MACHINE_TYPE_PUBLIC":"g1-standard-4t"}`),
+		EncryptedEnv: []byte("old ciphertext"),
+	}
+	local := func(digest byte, publicEnv string) appcontrollerV2.IAppControllerRelease {
+		return appcontrollerV2.IAppControllerRelease{
+			RmsRelease: appcontrollerV2.IReleaseManagerTypesRelease{
+				Artifacts: []appcontrollerV2.IReleaseManagerTypesArtifact{
+					{Digest: [32]byte{digest}, Registry: "docker.io/user/app"},
+				},
+			},
+			PublicEnv:    []byte(publicEnv),
+			EncryptedEnv: []byte("new ciphertext"),
+		}
+	}
+
+	matches, err := releaseMatches(deployed, local(0xab, `{"EIGEN_MACHINE_TYPE_PUBLIC":"g1-standard-4t","PORT_PUBLIC":"8080"}`))
+	require.NoError(t, err)
+	assert.True(t, matches, "key order and encrypted env are ignored")
+
+	matches, err = releaseMatches(deployed, local(0xcd, `{"EIGEN_MACHINE_TYPE_PUBLIC":"g1-standard-4t","PORT_PUBLIC":"8080"}`))
+	require.NoError(t, err)
+	assert.False(t, matches, "different digest")
+
+	matches, err = releaseMatches(deployed, local(0xab, `{"EIGEN_MACHINE_TYPE_PUBLIC":"g1-standard-8t","PORT_PUBLIC":"8080"}`))
+	require.NoError(t, err)
+	assert.False(t, matches, "different public env")
+
+	matches, err = releaseMatches(&appcontrollerV1.IAppControllerRelease{}, appcontrollerV2.IAppControllerRelease{})
+	require.NoError(t, err)
+	assert.False(t, matches, "releases without images never match")
+}
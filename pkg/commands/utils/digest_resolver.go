@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/urfave/cli/v2"
+)
+
+// DigestResolver looks up an image reference. It returns the digest and repository name of the
+// image's variant for TargetPlatform(ctx), with an empty name when there is none, and the
+// platforms found.
+type DigestResolver interface {
+	Resolve(ctx context.Context, imageRef string) ([32]byte, string, []Platform, error)
+}
+
+// registryDigestResolver resolves images against their remote registry
+type registryDigestResolver struct {
+	caCertPath string
+}
+
+// NewRegistryDigestResolver returns the default DigestResolver, which queries the image's
+// registry, trusting the certificates in caCertPath when it is set
+func NewRegistryDigestResolver(caCertPath string) DigestResolver {
+	return &registryDigestResolver{caCertPath: caCertPath}
+}
+
+func (r *registryDigestResolver) Resolve(ctx context.Context, imageRef string) ([32]byte, string, []Platform, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return [32]byte{}, "", nil, fmt.Errorf("failed to parse image reference %s: %w", imageRef, err)
+	}
+
+	opts, err := registryRemoteOptions(ctx, r.caCertPath)
+	if err != nil {
+		return [32]byte{}, "", nil, err
+	}
+
+	desc, err := remote.Get(ref, opts...)
+	if err != nil {
+		return [32]byte{}, "", nil, fmt.Errorf("failed to get image %s: %w", imageRef, err)
+	}
+
+	var result *imageDigestResult
+
+	if desc.MediaType.IsIndex() {
+		idx, err := desc.ImageIndex()
+		if err != nil {
+			return [32]byte{}, "", nil, fmt.Errorf("failed to get image index %s: %w", imageRef, err)
+		}
+
+		result, err = extractDigestFromMultiPlatform(idx, ref, TargetPlatform(ctx))
+		if err != nil {
+			return [32]byte{}, "", nil, fmt.Errorf("failed to process multi-platform image %s: %w", imageRef, err)
+		}
+	} else {
+		img, err := desc.Image()
+		if err != nil {
+			return [32]byte{}, "", nil, fmt.Errorf("failed to get image %s: %w", imageRef, err)
+		}
+
+		result, err = extractDigestFromSinglePlatform(img, ref, TargetPlatform(ctx))
+		if err != nil {
+			return [32]byte{}, "", nil, fmt.Errorf("failed to process single-platform image %s: %w", imageRef, err)
+		}
+	}
+
+	return result.digest, result.name, result.platforms, nil
+}
+
+type digestResolverContextKey struct{}
+
+// WithDigestResolver stores the resolver used to look up image digests in the context
+func WithDigestResolver(ctx context.Context, resolver DigestResolver) context.Context {
+	return context.WithValue(ctx, digestResolverContextKey{}, resolver)
+}
+
+// DigestResolverFromContext returns the resolver stored with WithDigestResolver, falling back
+// to the registry resolver configured by --registry-ca-cert
+func DigestResolverFromContext(cCtx *cli.Context) DigestResolver {
+	if resolver, ok := cCtx.Context.Value(digestResolverContextKey{}).(DigestResolver); ok {
+		return resolver
+	}
+	return NewRegistryDigestResolver(cCtx.String(common.RegistryCACertFlag.Name))
+}
+
+// getImageDigestAndName resolves imageRef to the digest and name of its variant for the target
+// platform, returning a PlatformMismatchError when the image has no such variant
+func getImageDigestAndName(ctx context.Context, resolver DigestResolver, imageRef string) ([32]byte, string, error) {
+	digest, name, platforms, err := resolver.Resolve(ctx, imageRef)
+	if err != nil {
+		return [32]byte{}, "", err
+	}
+
+	// If we found a compatible platform, return success
+	if name != "" {
+		return digest, name, nil
+	}
+
+	// No compatible platform found, return helpful error
+	return [32]byte{}, "", &PlatformMismatchError{ImageRef: imageRef, Platforms: platforms, Target: TargetPlatform(ctx)}
+}
@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+// fakeDigestResolver resolves every image to the same fixed result
+type fakeDigestResolver struct {
+	digest    [32]byte
+	name      string
+	platforms []Platform
+	err       error
+}
+
+func (f *fakeDigestResolver) Resolve(ctx context.Context, imageRef string) ([32]byte, string, []Platform, error) {
+	return f.digest, f.name, f.platforms, f.err
+}
+
+func TestGetImageDigestAndName(t *testing.T) {
+	digest := [32]byte{0xab}
+
+	t.Run("linux/amd64 variant", func(t *testing.T) {
+		resolver := &fakeDigestResolver{digest: digest, name: "docker.io/user/app", platforms: []Platform{{OS: "linux", Arch: "arm64"}, {OS: "linux", Arch: "amd64"}}}
+		gotDigest, gotName, err := getImageDigestAndName(context.Background(), resolver, "user/app:latest")
+		require.NoError(t, err)
+		assert.Equal(t, digest, gotDigest)
+		assert.Equal(t, "docker.io/user/app", gotName)
+	})
+
+	t.Run("no linux/amd64 variant", func(t *testing.T) {
+		resolver := &fakeDigestResolver{platforms: []Platform{{OS: "linux", Arch: "arm64"}, {OS: "windows", Arch: "amd64"}}}
+		_, _, err := getImageDigestAndName(context.Background(), resolver, "user/app:latest")
+
+		var mismatchErr *PlatformMismatchError
+		require.True(t, errors.As(err, &mismatchErr))
+		assert.Equal(t, "user/app:latest", mismatchErr.ImageRef)
+		assert.Contains(t, err.Error(), "Found platform(s): linux/arm64, windows/amd64")
+		assert.Contains(t, err.Error(), "docker build --platform linux/amd64 -t user/app:latest .")
+	})
+
+	t.Run("resolver error", func(t *testing.T) {
+		resolver := &fakeDigestResolver{err: errors.New("unauthorized")}
+		_, _, err := getImageDigestAndName(context.Background(), resolver, "user/app:latest")
+		require.EqualError(t, err, "unauthorized")
+	})
+}
+
+func TestDigestResolverFromContext(t *testing.T) {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	require.NoError(t, common.RegistryCACertFlag.Apply(set))
+	require.NoError(t, set.Parse([]string{"--registry-ca-cert", "ca.pem"}))
+	cCtx := cli.NewContext(&cli.App{}, set, nil)
+
+	assert.Equal(t, &registryDigestResolver{caCertPath: "ca.pem"}, DigestResolverFromContext(cCtx))
+
+	fake := &fakeDigestResolver{}
+	cCtx.Context = WithDigestResolver(cCtx.Context, fake)
+	assert.Same(t, fake, DigestResolverFromContext(cCtx))
+}
+
+func TestExtractDigestFromMultiPlatform(t *testing.T) {
+	ref, err := name.ParseReference("user/app:latest")
+	require.NoError(t, err)
+
+	newIndex := func(platforms ...v1.Platform) (v1.ImageIndex, []v1.Image) {
+		idx := empty.Index
+		var images []v1.Image
+		for _, platform := range platforms {
+			img, err := random.Image(64, 1)
+			require.NoError(t, err)
+			idx = mutate.AppendManifests(idx, mutate.IndexAddendum{
+				Add:        img,
+				Descriptor: v1.Descriptor{Platform: &platform},
+			})
+			images = append(images, img)
+		}
+		return idx, images
+	}
+
+	t.Run("selects linux/amd64", func(t *testing.T) {
+		idx, images := newIndex(v1.Platform{OS: "linux", Architecture: "arm64"}, v1.Platform{OS: "linux", Architecture: "amd64"})
+		result, err := extractDigestFromMultiPlatform(idx, ref, TargetPlatform(context.Background()))
+		require.NoError(t, err)
+
+		want, err := images[1].Digest()
+		require.NoError(t, err)
+		wantDigest, err := hexStringToBytes32(want.Hex)
+		require.NoError(t, err)
+		assert.Equal(t, wantDigest, result.digest)
+		assert.Equal(t, "index.docker.io/user/app", result.name)
+	})
+
+	t.Run("selects the target platform", func(t *testing.T) {
+		idx, images := newIndex(v1.Platform{OS: "linux", Architecture: "amd64"}, v1.Platform{OS: "linux", Architecture: "arm64"})
+		result, err := extractDigestFromMultiPlatform(idx, ref, Platform{OS: LinuxOS, Arch: ARM64Arch})
+		require.NoError(t, err)
+
+		want, err := images[1].Digest()
+		require.NoError(t, err)
+		wantDigest, err := hexStringToBytes32(want.Hex)
+		require.NoError(t, err)
+		assert.Equal(t, wantDigest, result.digest)
+	})
+
+	t.Run("reports platforms without linux/amd64", func(t *testing.T) {
+		idx, _ := newIndex(v1.Platform{OS: "linux", Architecture: "arm64"}, v1.Platform{OS: "linux", Architecture: "arm"})
+		result, err := extractDigestFromMultiPlatform(idx, ref, TargetPlatform(context.Background()))
+		require.NoError(t, err)
+		assert.Empty(t, result.name)
+		assert.Equal(t, []Platform{{OS: "linux", Arch: "arm64"}, {OS: "linux", Arch: "arm"}}, result.platforms)
+	})
+}
+
+func TestParsePlatform(t *testing.T) {
+	platform, err := ParsePlatform("linux/amd64")
+	require.NoError(t, err)
+	assert.Equal(t, Platform{OS: LinuxOS, Arch: AMD64Arch}, platform)
+
+	platform, err = ParsePlatform(" Linux/AMD64 ")
+	require.NoError(t, err)
+	assert.Equal(t, Platform{OS: LinuxOS, Arch: AMD64Arch}, platform)
+
+	platform, err = ParsePlatform("linux/arm64")
+	require.NoError(t, err)
+	assert.Equal(t, Platform{OS: LinuxOS, Arch: ARM64Arch}, platform)
+
+	for _, value := range []string{"", "linux", "linux/arm", "windows/amd64", "linux/amd64/v2"} {
+		_, err := ParsePlatform(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestTargetPlatform(t *testing.T) {
+	assert.Equal(t, Platform{OS: LinuxOS, Arch: AMD64Arch}, TargetPlatform(context.Background()))
+
+	arm := Platform{OS: LinuxOS, Arch: ARM64Arch}
+	assert.Equal(t, arm, TargetPlatform(WithTargetPlatform(context.Background(), arm)))
+}
@@ -0,0 +1,170 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common/output"
+	kmstypes "github.com/Layr-Labs/eigenx-kms/pkg/types"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+)
+
+// envVarNamePattern matches names that can be exported as environment variables
+var envVarNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ParseEnvAssignments parses KEY=VALUE arguments. Values may contain '=' and may be empty.
+func ParseEnvAssignments(args []string) (map[string]string, error) {
+	assignments := make(map[string]string, len(args))
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid assignment %q: expected KEY=VALUE", arg)
+		}
+		if !envVarNamePattern.MatchString(key) {
+			return nil, fmt.Errorf("invalid variable name %q", key)
+		}
+		if strings.ToUpper(key) == common.MnemonicEnvVar {
+			return nil, fmt.Errorf("%s is provided by the protocol and cannot be set", key)
+		}
+		assignments[key] = value
+	}
+	return assignments, nil
+}
+
+// LocalPrivateEnv returns the private variables defined locally, from --private-env-file or the
+// private variables of --env-file, and a description of where they came from. The deployed private
+// env is encrypted, so a release that changes any variable must re-supply it from a local file.
+// It returns an empty set and source when no file exists.
+func LocalPrivateEnv(cCtx *cli.Context) (kmstypes.Env, string, error) {
+	privateEnvFilePath := cCtx.String(common.PrivateEnvFileFlag.Name)
+	envFilePath := ""
+	if privateEnvFilePath == "" {
+		if path := cCtx.String(common.EnvFlag.Name); path != "" {
+			if _, err := os.Stat(path); err == nil {
+				envFilePath = path
+			}
+		}
+	}
+
+	private, err := readLocalPrivateEnv(envFilePath, privateEnvFilePath)
+	if err != nil {
+		return nil, "", err
+	}
+	if privateEnvFilePath != "" {
+		return private, privateEnvFilePath, nil
+	}
+	return private, envFilePath, nil
+}
+
+// stdinIsTerminal reports whether confirmations can be asked for interactively
+var stdinIsTerminal = func() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// ConfirmPrivateEnvReplacement guards an env change when no local private env was found. The
+// private env of a release is replaced as a whole, so the upgraded app would keep only the
+// private variables set on the command line. It proceeds with --force, otherwise only after
+// explicit confirmation, which fails when stdin is not a terminal.
+func ConfirmPrivateEnvReplacement(cCtx *cli.Context) error {
+	logger := common.LoggerFromContext(cCtx)
+	logger.Warn("No local env file found: the deployed private variables would be replaced by only those set here")
+	if cCtx.Bool(common.ForceFlag.Name) {
+		return nil
+	}
+	if !stdinIsTerminal() {
+		return fmt.Errorf("refusing to replace the deployed private env without a local env file: pass --%s or --%s with all private variables, or --%s to continue", common.EnvFlag.Name, common.PrivateEnvFileFlag.Name, common.ForceFlag.Name)
+	}
+
+	confirmed, err := output.Confirm("Replace the deployed private env?")
+	if err != nil {
+		return fmt.Errorf("failed to get confirmation: %w", err)
+	}
+	if !confirmed {
+		return fmt.Errorf("env change cancelled by user")
+	}
+	return nil
+}
+
+// readLocalPrivateEnv returns every variable of privateEnvFilePath when set, otherwise the variables
+// of envFilePath without the _PUBLIC suffix, applying the same categorization as parseAndValidateEnvFile.
+// The mnemonic is always dropped. It returns an empty set when neither file is given.
+func readLocalPrivateEnv(envFilePath, privateEnvFilePath string) (kmstypes.Env, error) {
+	path := envFilePath
+	if privateEnvFilePath != "" {
+		path = privateEnvFilePath
+	}
+	private := kmstypes.Env{}
+	if path == "" {
+		return private, nil
+	}
+
+	envVars, err := readEnvFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	for varName, value := range envVars {
+		if strings.ToUpper(varName) == common.MnemonicEnvVar {
+			continue
+		}
+		if privateEnvFilePath == "" && strings.HasSuffix(varName, "_PUBLIC") {
+			continue
+		}
+		private[varName] = value
+	}
+	return private, nil
+}
+
+// ApplyEnvAssignments sets each assigned variable in the public or private env, removing it from
+// the other one, asks the user to confirm the resulting categorization and returns both sets
+func ApplyEnvAssignments(cCtx *cli.Context, publicEnv, privateEnv map[string]string, assignments map[string]string, makePublic bool, source string) (kmstypes.Env, kmstypes.Env, error) {
+	public, private := applyEnvAssignments(publicEnv, privateEnv, assignments, makePublic)
+
+	categorization := envCategorization{
+		publicEnv:       public,
+		privateEnv:      private,
+		source:          source,
+		makePrivateHint: "set it again without --public to encrypt it",
+	}
+	if err := confirmEnvCategorization(cCtx, categorization); err != nil {
+		return nil, nil, err
+	}
+	return public, private, nil
+}
+
+func applyEnvAssignments(publicEnv, privateEnv map[string]string, assignments map[string]string, makePublic bool) (kmstypes.Env, kmstypes.Env) {
+	public := kmstypes.Env{}
+	for varName, value := range publicEnv {
+		public[varName] = value
+	}
+	private := kmstypes.Env{}
+	for varName, value := range privateEnv {
+		private[varName] = value
+	}
+
+	for varName, value := range assignments {
+		if makePublic {
+			public[varName] = value
+			delete(private, varName)
+		} else {
+			private[varName] = value
+			delete(public, varName)
+		}
+	}
+	return public, private
+}
+
+// sortedEnvKeys returns the variable names of env in order
+func sortedEnvKeys(env map[string]string) []string {
+	keys := make([]string, 0, len(env))
+	for varName := range env {
+		keys = append(keys, varName)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestParseEnvAssignments(t *testing.T) {
+	assignments, err := ParseEnvAssignments([]string{"API_URL=https://example.com/?a=b", "EMPTY=", "_X1=y"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"API_URL": "https://example.com/?a=b",
+		"EMPTY":   "",
+		"_X1":     "y",
+	}, assignments)
+
+	for _, arg := range []string{"NOVALUE", "=value", "1ABC=x", "MY-VAR=x", "mnemonic=words"} {
+		_, err := ParseEnvAssignments([]string{arg})
+		assert.Error(t, err, arg)
+	}
+}
+
+func TestApplyEnvAssignments(t *testing.T) {
+	public := map[string]string{"A_PUBLIC": "1", "SHARED": "public"}
+	private := map[string]string{"SECRET": "s", "OTHER": "o"}
+
+	t.Run("private", func(t *testing.T) {
+		gotPublic, gotPrivate := applyEnvAssignments(public, private, map[string]string{"SHARED": "now-private", "NEW": "n"}, false)
+		assert.Equal(t, map[string]string{"A_PUBLIC": "1"}, map[string]string(gotPublic))
+		assert.Equal(t, map[string]string{"SECRET": "s", "OTHER": "o", "SHARED": "now-private", "NEW": "n"}, map[string]string(gotPrivate))
+	})
+
+	t.Run("public", func(t *testing.T) {
+		gotPublic, gotPrivate := applyEnvAssignments(public, private, map[string]string{"SECRET": "exposed"}, true)
+		assert.Equal(t, map[string]string{"A_PUBLIC": "1", "SHARED": "public", "SECRET": "exposed"}, map[string]string(gotPublic))
+		assert.Equal(t, map[string]string{"OTHER": "o"}, map[string]string(gotPrivate))
+	})
+
+	// The inputs are left untouched
+	assert.Equal(t, map[string]string{"A_PUBLIC": "1", "SHARED": "public"}, public)
+	assert.Equal(t, map[string]string{"SECRET": "s", "OTHER": "o"}, private)
+}
+
+func TestReadLocalPrivateEnv(t *testing.T) {
+	envFile := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(envFile, []byte("API_KEY=k\nAPI_URL_PUBLIC=u\nMNEMONIC=words\n"), 0644))
+
+	private, err := readLocalPrivateEnv(envFile, "")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"API_KEY": "k"}, map[string]string(private))
+
+	private, err = readLocalPrivateEnv("", envFile)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"API_KEY": "k", "API_URL_PUBLIC": "u"}, map[string]string(private))
+
+	private, err = readLocalPrivateEnv("", "")
+	require.NoError(t, err)
+	assert.Empty(t, private)
+}
+
+func TestConfirmPrivateEnvReplacement(t *testing.T) {
+	newContext := func(args ...string) *cli.Context {
+		set := flag.NewFlagSet("test", flag.ContinueOnError)
+		require.NoError(t, common.ForceFlag.Apply(set))
+		require.NoError(t, set.Parse(args))
+		return cli.NewContext(&cli.App{}, set, nil)
+	}
+
+	interactive := stdinIsTerminal
+	t.Cleanup(func() { stdinIsTerminal = interactive })
+	stdinIsTerminal = func() bool { return false }
+
+	err := ConfirmPrivateEnvReplacement(newContext())
+	assert.ErrorContains(t, err, "refusing to replace the deployed private env")
+
+	assert.NoError(t, ConfirmPrivateEnvReplacement(newContext("--force")))
+	assert.NoError(t, ConfirmPrivateEnvReplacement(newContext("--yes")))
+}
@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+const (
+	// DefaultEnvEntropyThreshold is the Shannon entropy, in bits per character, above which a
+	// public value is reported as a likely secret. Long random hex reaches 4.0 and random
+	// base64 5.0-6.0, while natural words and identifiers usually stay below 3.7.
+	DefaultEnvEntropyThreshold = 4.0
+
+	// minEntropyCheckLength skips short values whose entropy estimate is too noisy to be useful
+	minEntropyCheckLength = 16
+)
+
+// highEntropyVar is a public env var whose value looks like a secret
+type highEntropyVar struct {
+	Name    string
+	Entropy float64
+}
+
+// shannonEntropy returns the Shannon entropy of s in bits per character
+func shannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+
+	counts := make(map[rune]int)
+	total := 0
+	for _, r := range s {
+		counts[r]++
+		total++
+	}
+
+	entropy := 0.0
+	for _, count := range counts {
+		p := float64(count) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// findHighEntropyValues returns the variables whose values look like random secrets,
+// sorted by name. Values that are short, contain whitespace, or are URLs are skipped
+// since they are rarely credentials and produce false positives.
+func findHighEntropyValues(env map[string]string, threshold float64) []highEntropyVar {
+	var found []highEntropyVar
+	for name, value := range env {
+		if len(value) < minEntropyCheckLength || strings.ContainsAny(value, " \t\n") || strings.Contains(value, "://") {
+			continue
+		}
+		if entropy := shannonEntropy(value); entropy >= threshold {
+			found = append(found, highEntropyVar{Name: name, Entropy: entropy})
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].Name < found[j].Name })
+	return found
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/urfave/cli/v2"
+)
+
+// EnvSizeWarningBytes is the serialized env size above which the user is warned, unless
+// --max-env-size sets a hard limit instead
+const EnvSizeWarningBytes = 16 * 1024
+
+// envVarSize is the serialized size attributed to one env variable
+type envVarSize struct {
+	name  string
+	bytes int
+}
+
+// CheckEnvSize warns when the serialized public and private env together are larger than
+// EnvSizeWarningBytes, or fails when they exceed --max-env-size. Both are stored onchain with
+// the release, so gas costs grow with their size and an oversized env can fail the deploy.
+func CheckEnvSize(cCtx *cli.Context, publicEnv, privateEnv map[string]string, serializedBytes int) error {
+	logger := common.LoggerFromContext(cCtx)
+
+	limit := cCtx.Int(common.MaxEnvSizeFlag.Name)
+	threshold := EnvSizeWarningBytes
+	if limit > 0 {
+		threshold = limit
+	}
+	if serializedBytes <= threshold {
+		return nil
+	}
+
+	largest := largestEnvVars(publicEnv, privateEnv, 5)
+	if limit > 0 {
+		names := make([]string, 0, len(largest))
+		for _, v := range largest {
+			names = append(names, fmt.Sprintf("%s (%s)", v.name, formatBytes(int64(v.bytes))))
+		}
+		return fmt.Errorf("serialized env is %s, over the --%s limit of %s (largest variables: %s); move large values such as certificates or config files out of env vars, e.g. into the image",
+			formatBytes(int64(serializedBytes)), common.MaxEnvSizeFlag.Name, formatBytes(int64(limit)), strings.Join(names, ", "))
+	}
+
+	logger.Warn("Serialized env is %s. It is stored onchain, so it increases gas costs and can make the transaction fail.", formatBytes(int64(serializedBytes)))
+	logger.Info("Largest variables:")
+	for _, v := range largest {
+		logger.Info("  • %s (%s)", v.name, formatBytes(int64(v.bytes)))
+	}
+	logger.Info("Consider moving large values such as certificates or config files into the image, or set --%s to enforce a limit", common.MaxEnvSizeFlag.Name)
+	return nil
+}
+
+// largestEnvVars returns up to n variables across both envs, largest first by name plus value length
+func largestEnvVars(publicEnv, privateEnv map[string]string, n int) []envVarSize {
+	var sizes []envVarSize
+	for _, env := range []map[string]string{publicEnv, privateEnv} {
+		for name, value := range env {
+			sizes = append(sizes, envVarSize{name: name, bytes: len(name) + len(value)})
+		}
+	}
+	sort.Slice(sizes, func(i, j int) bool {
+		if sizes[i].bytes != sizes[j].bytes {
+			return sizes[i].bytes > sizes[j].bytes
+		}
+		return sizes[i].name < sizes[j].name
+	})
+	if len(sizes) > n {
+		sizes = sizes[:n]
+	}
+	return sizes
+}
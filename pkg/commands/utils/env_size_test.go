@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestLargestEnvVars(t *testing.T) {
+	publicEnv := map[string]string{"A_PUBLIC": "1", "CONFIG_PUBLIC": strings.Repeat("x", 100)}
+	privateEnv := map[string]string{"CERT": strings.Repeat("x", 200), "B": "12", "C": "34"}
+
+	assert.Equal(t, []envVarSize{
+		{name: "CERT", bytes: 204},
+		{name: "CONFIG_PUBLIC", bytes: 113},
+		{name: "A_PUBLIC", bytes: 9},
+	}, largestEnvVars(publicEnv, privateEnv, 3))
+	assert.Len(t, largestEnvVars(publicEnv, privateEnv, 10), 5)
+}
+
+func TestCheckEnvSize(t *testing.T) {
+	newContext := func(args ...string) *cli.Context {
+		set := flag.NewFlagSet("test", flag.ContinueOnError)
+		require.NoError(t, common.MaxEnvSizeFlag.Apply(set))
+		require.NoError(t, set.Parse(args))
+		return cli.NewContext(&cli.App{}, set, nil)
+	}
+	publicEnv := map[string]string{"URL_PUBLIC": "https://example.com"}
+	privateEnv := map[string]string{"CERT": strings.Repeat("x", 2048)}
+
+	// Without a limit an oversized env only warns
+	require.NoError(t, CheckEnvSize(newContext(), publicEnv, privateEnv, EnvSizeWarningBytes+1))
+
+	require.NoError(t, CheckEnvSize(newContext("--max-env-size", "4096"), publicEnv, privateEnv, 4096))
+
+	err := CheckEnvSize(newContext("--max-env-size", "1024"), publicEnv, privateEnv, 2100)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "serialized env is 2.1 KiB, over the --max-env-size limit of 1.0 KiB")
+	assert.Contains(t, err.Error(), "largest variables: CERT (2.0 KiB), URL_PUBLIC (29 B)")
+}
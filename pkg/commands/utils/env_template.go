@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	appcontrollerV2 "github.com/Layr-Labs/eigenx-contracts/pkg/bindings/v2/AppController"
+	kmstypes "github.com/Layr-Labs/eigenx-kms/pkg/types"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/joho/godotenv"
+	"github.com/urfave/cli/v2"
+)
+
+// WriteEnvTemplate writes a .env.example-style template of the variables a deployed release uses:
+// public variables with their values, and the private variables encrypted into it by name only. The instance type is left
+// out since it is chosen at deploy time rather than configured.
+func WriteEnvTemplate(cCtx *cli.Context, path string, appID gethcommon.Address, release appcontrollerV2.IAppControllerRelease, privateEnv kmstypes.Env) error {
+	logger := common.LoggerFromContext(cCtx)
+
+	publicEnv := map[string]string{}
+	if len(release.PublicEnv) > 0 {
+		if err := json.Unmarshal(release.PublicEnv, &publicEnv); err != nil {
+			return fmt.Errorf("failed to decode public env: %w", err)
+		}
+	}
+	delete(publicEnv, common.EigenMachineTypeEnvVar)
+
+	template, err := formatEnvTemplate(appID, publicEnv, sortedEnvKeys(privateEnv), HasExplicitEnvFiles(cCtx))
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(template), 0644); err != nil {
+		return fmt.Errorf("failed to write env template %s: %w", path, err)
+	}
+
+	logger.Info("Env template written to %s", path)
+	return nil
+}
+
+// formatEnvTemplate renders the template. With explicit env files public variables carry no
+// _PUBLIC suffix, so the header says which file each section belongs in.
+func formatEnvTemplate(appID gethcommon.Address, publicEnv map[string]string, privateEnvKeys []string, explicitEnvFiles bool) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Environment for app %s, generated by eigenx app deploy\n", appID.Hex())
+
+	b.WriteString("\n# Public variables (stored on-chain, visible to anyone)\n")
+	if explicitEnvFiles {
+		b.WriteString("# Put these in the file passed to --public-env-file\n")
+	}
+	if len(publicEnv) > 0 {
+		lines, err := godotenv.Marshal(publicEnv)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode public env: %w", err)
+		}
+		b.WriteString(lines + "\n")
+	}
+
+	b.WriteString("\n# Private variables (encrypted, values redacted)\n")
+	if explicitEnvFiles {
+		b.WriteString("# Put these in the file passed to --private-env-file\n")
+	}
+	for _, key := range privateEnvKeys {
+		b.WriteString(key + "=\n")
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatEnvTemplate(t *testing.T) {
+	appID := gethcommon.HexToAddress("0x1")
+
+	template, err := formatEnvTemplate(appID, map[string]string{
+		"PORT_PUBLIC":   "8080",
+		"DOMAIN_PUBLIC": "example.com",
+	}, []string{"API_KEY", "DB_PASSWORD"}, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, `# Environment for app 0x0000000000000000000000000000000000000001, generated by eigenx app deploy
+
+# Public variables (stored on-chain, visible to anyone)
+DOMAIN_PUBLIC="example.com"
+PORT_PUBLIC=8080
+
+# Private variables (encrypted, values redacted)
+API_KEY=
+DB_PASSWORD=
+`, template)
+}
+
+func TestFormatEnvTemplate_ExplicitEnvFiles(t *testing.T) {
+	template, err := formatEnvTemplate(gethcommon.Address{}, nil, nil, true)
+	require.NoError(t, err)
+
+	assert.Contains(t, template, "--public-env-file")
+	assert.Contains(t, template, "--private-env-file")
+}
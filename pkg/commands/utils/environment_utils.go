@@ -7,6 +7,7 @@ import (
 
 	project "github.com/Layr-Labs/eigenx-cli"
 	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common/iface"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/urfave/cli/v2"
 )
@@ -18,8 +19,11 @@ func GetEnvironmentConfig(cCtx *cli.Context) (common.EnvironmentConfig, error) {
 	}
 
 	// 2. Try to detect from RPC URL's chain ID
-	if rpcURL := cCtx.String(common.RpcUrlFlag.Name); rpcURL != "" {
-		if environment, err := detectEnvironmentFromRPC(cCtx.Context, rpcURL); err == nil {
+	if rpcURLs := parseRPCURLs(cCtx.String(common.RpcUrlFlag.Name)); len(rpcURLs) > 0 {
+		rpcCtx, cancel := RPCContext(cCtx)
+		environment, err := detectEnvironmentFromRPC(rpcCtx, common.LoggerFromContext(cCtx), rpcURLs)
+		cancel()
+		if err == nil {
 			return getEnvironmentByName(environment)
 		}
 		// If RPC detection fails, continue to default
@@ -61,9 +65,29 @@ func getEnvironmentByName(name string) (common.EnvironmentConfig, error) {
 	return config, nil
 }
 
-// detectEnvironmentFromRPC connects to an RPC endpoint and detects the environment from chain ID
-func detectEnvironmentFromRPC(ctx context.Context, rpcURL string) (string, error) {
-	client, err := ethclient.Dial(rpcURL)
+// ProbeEnvironment checks that rpcURL is reachable and serves the chain the environment is deployed on
+func ProbeEnvironment(ctx context.Context, environmentConfig common.EnvironmentConfig, rpcURL string) error {
+	client, err := ethclient.DialContext(ctx, rpcURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to RPC %s: %w", rpcURL, err)
+	}
+	defer client.Close()
+
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get chain ID from %s: %w", rpcURL, err)
+	}
+
+	if expected, ok := common.ChainIDForEnvironment(environmentConfig.Name); ok && chainID.Uint64() != expected {
+		return fmt.Errorf("RPC %s is on chain ID %s but environment %s is on chain ID %d", rpcURL, chainID.String(), environmentConfig.Name, expected)
+	}
+	return nil
+}
+
+// detectEnvironmentFromRPC connects to the RPC endpoints, failing over between them, and detects
+// the environment from chain ID
+func detectEnvironmentFromRPC(ctx context.Context, logger iface.Logger, rpcURLs []string) (string, error) {
+	client, err := common.NewFailoverClient(rpcURLs, logger)
 	if err != nil {
 		return "", fmt.Errorf("failed to connect to RPC: %w", err)
 	}
@@ -82,18 +106,43 @@ func detectEnvironmentFromRPC(ctx context.Context, rpcURL string) (string, error
 	return environment, nil
 }
 
+// CheckKMSKeys reports whether the KMS encryption and signing keys for environment are bundled
+// with this build
+func CheckKMSKeys(environment string) error {
+	_, _, err := getKMSKeysForEnvironment(environment)
+	return err
+}
+
+// KMSKeysError reports that the KMS public keys for an environment are not bundled with this
+// build of the CLI, usually because the environment is misconfigured or newer than the CLI
+type KMSKeysError struct {
+	Environment string
+	Build       string
+	Err         error
+}
+
+func (e *KMSKeysError) Error() string {
+	return fmt.Sprintf("no KMS public keys for environment %q in this %s build of eigenx: %v\n"+
+		"The keys are bundled with the CLI rather than fetched from the KMS server. Check the selected environment with 'eigenx environment current', "+
+		"switch with 'eigenx environment set', or upgrade eigenx if the environment is newer than this CLI", e.Environment, e.Build, e.Err)
+}
+
+func (e *KMSKeysError) Unwrap() error {
+	return e.Err
+}
+
 func getKMSKeysForEnvironment(environment string) (encryptionKey []byte, signingKey []byte, err error) {
 	encryptionPath := fmt.Sprintf("keys/%s/%s/kms-encryption-public-key.pem", environment, common.Build)
 	signingPath := fmt.Sprintf("keys/%s/%s/kms-signing-public-key.pem", environment, common.Build)
 
 	encryptionKey, err = fs.ReadFile(project.KeysFS, encryptionPath)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to read encryption key for environment %s: %w", environment, err)
+		return nil, nil, &KMSKeysError{Environment: environment, Build: common.Build, Err: fmt.Errorf("failed to read encryption key: %w", err)}
 	}
 
 	signingKey, err = fs.ReadFile(project.KeysFS, signingPath)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to read signing key for environment %s: %w", environment, err)
+		return nil, nil, &KMSKeysError{Environment: environment, Build: common.Build, Err: fmt.Errorf("failed to read signing key: %w", err)}
 	}
 
 	return encryptionKey, signingKey, nil
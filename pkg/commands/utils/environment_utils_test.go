@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetKMSKeysForEnvironment_UnknownEnvironment(t *testing.T) {
+	_, _, err := getKMSKeysForEnvironment("no-such-environment")
+	require.Error(t, err)
+
+	var keysErr *KMSKeysError
+	require.True(t, errors.As(err, &keysErr))
+	assert.Equal(t, "no-such-environment", keysErr.Environment)
+	assert.Equal(t, common.Build, keysErr.Build)
+	assert.Contains(t, err.Error(), "eigenx environment set")
+}
@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/urfave/cli/v2"
+)
+
+// maxDirtyFilesShown bounds how many uncommitted paths are listed when refusing a dirty build
+const maxDirtyFilesShown = 10
+
+// gitWorkingTree describes the git checkout a build context belongs to
+type gitWorkingTree struct {
+	Commit     string
+	DirtyFiles []string
+}
+
+// Revision returns the commit SHA, suffixed with "-dirty" when there are uncommitted changes
+func (t *gitWorkingTree) Revision() string {
+	if len(t.DirtyFiles) > 0 {
+		return t.Commit + "-dirty"
+	}
+	return t.Commit
+}
+
+// readGitWorkingTree returns the HEAD commit and uncommitted paths of the repository
+// containing dir. It fails if git is not installed or dir is not in a repository.
+func readGitWorkingTree(ctx context.Context, dir string) (*gitWorkingTree, error) {
+	commit, err := runGit(ctx, dir, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := runGit(ctx, dir, "status", "--porcelain", "--untracked-files=normal", "--", ".")
+	if err != nil {
+		return nil, err
+	}
+
+	tree := &gitWorkingTree{Commit: commit}
+	for _, line := range strings.Split(status, "\n") {
+		if len(line) > 3 {
+			tree.DirtyFiles = append(tree.DirtyFiles, line[3:])
+		}
+	}
+	return tree, nil
+}
+
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	out, err := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...).Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %w", args[0], err)
+	}
+	return strings.TrimRight(string(out), "\n"), nil
+}
+
+// gitDirtyCheckEnabled reports whether builds must come from a clean working tree:
+// --git-dirty-check when given, otherwise only for mainnet environments
+func gitDirtyCheckEnabled(cCtx *cli.Context, environmentConfig *common.EnvironmentConfig) bool {
+	if cCtx.IsSet(common.GitDirtyCheckFlag.Name) {
+		return cCtx.Bool(common.GitDirtyCheckFlag.Name)
+	}
+	chainID, _ := common.ChainIDForEnvironment(environmentConfig.Name)
+	return chainID == common.MainnetChainID
+}
+
+// CheckGitWorkingTree refuses to build from buildContext when the dirty check is enabled and
+// the working tree has uncommitted changes, unless --allow-dirty is set. It returns the git
+// revision being built, or "" when the build context is not in a git repository.
+func CheckGitWorkingTree(cCtx *cli.Context, environmentConfig *common.EnvironmentConfig, buildContext string) (string, error) {
+	logger := common.LoggerFromContext(cCtx)
+	enabled := gitDirtyCheckEnabled(cCtx, environmentConfig)
+
+	tree, err := readGitWorkingTree(cCtx.Context, buildContext)
+	if err != nil {
+		if enabled && cCtx.IsSet(common.GitDirtyCheckFlag.Name) {
+			return "", fmt.Errorf("--%s requires the build context to be in a git repository: %w", common.GitDirtyCheckFlag.Name, err)
+		}
+		logger.Debug("Skipping git dirty check: %v", err)
+		return "", nil
+	}
+
+	if len(tree.DirtyFiles) == 0 {
+		logger.Info("Building from git commit %s", tree.Commit)
+		return tree.Revision(), nil
+	}
+
+	if !enabled {
+		return tree.Revision(), nil
+	}
+
+	shown := tree.DirtyFiles
+	if len(shown) > maxDirtyFilesShown {
+		shown = shown[:maxDirtyFilesShown]
+	}
+
+	if cCtx.Bool(common.AllowDirtyFlag.Name) {
+		logger.Warn("Building from a working tree with %d uncommitted change(s) on top of %s (--%s)", len(tree.DirtyFiles), tree.Commit, common.AllowDirtyFlag.Name)
+		return tree.Revision(), nil
+	}
+
+	logger.Error("The working tree has %d uncommitted change(s):", len(tree.DirtyFiles))
+	for _, path := range shown {
+		logger.Error("  • %s", path)
+	}
+	if len(tree.DirtyFiles) > len(shown) {
+		logger.Error("  … and %d more", len(tree.DirtyFiles)-len(shown))
+	}
+	return "", fmt.Errorf("refusing to deploy uncommitted code to %s: commit your changes or pass --%s", environmentConfig.Name, common.AllowDirtyFlag.Name)
+}
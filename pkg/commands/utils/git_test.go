@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadGitWorkingTree(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	_, err := readGitWorkingTree(context.Background(), dir)
+	assert.Error(t, err, "not a git repository")
+
+	git("init", "-q")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM scratch\n"), 0644))
+	git("add", "Dockerfile")
+	git("commit", "-q", "-m", "initial")
+
+	tree, err := readGitWorkingTree(context.Background(), dir)
+	require.NoError(t, err)
+	assert.Len(t, tree.Commit, 40)
+	assert.Empty(t, tree.DirtyFiles)
+	assert.Equal(t, tree.Commit, tree.Revision())
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM alpine\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "new.txt"), []byte("x"), 0644))
+
+	tree, err = readGitWorkingTree(context.Background(), dir)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Dockerfile", "new.txt"}, tree.DirtyFiles)
+	assert.Equal(t, tree.Commit+"-dirty", tree.Revision())
+}
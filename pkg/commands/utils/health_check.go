@@ -0,0 +1,143 @@
+package utils
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+// healthRequestTimeout bounds each individual --verify-running request
+const healthRequestTimeout = 10 * time.Second
+
+// appPortEnvVar holds the port the app listens on, checked directly when the app has no TLS
+const appPortEnvVar = "APP_PORT"
+
+// ValidateHealthFlags ensures the health check flags form a consistent combination
+func ValidateHealthFlags(cCtx *cli.Context) error {
+	if !cCtx.Bool(common.VerifyRunningFlag.Name) {
+		for _, name := range []string{common.HealthPathFlag.Name, common.HealthTimeoutFlag.Name} {
+			if cCtx.IsSet(name) {
+				return fmt.Errorf("--%s requires --%s", name, common.VerifyRunningFlag.Name)
+			}
+		}
+		return nil
+	}
+	if !strings.HasPrefix(cCtx.String(common.HealthPathFlag.Name), "/") {
+		return fmt.Errorf("--%s must start with /", common.HealthPathFlag.Name)
+	}
+	if cCtx.Duration(common.HealthTimeoutFlag.Name) <= 0 {
+		return fmt.Errorf("--%s must be positive", common.HealthTimeoutFlag.Name)
+	}
+	return nil
+}
+
+// VerifyAppServing checks, when --verify-running is set, that the app configured by the env
+// file(s) answers requests on --health-path
+func VerifyAppServing(cCtx *cli.Context, appID ethcommon.Address, envFilePath string) error {
+	return VerifyAppServingWithEnv(cCtx, appID, configuredEnv(cCtx, envFilePath))
+}
+
+// VerifyAppServingWithEnv checks, when --verify-running is set, that the app answers requests
+// on --health-path. TLS apps are reached through the DOMAIN in env, others over HTTP on the
+// app's IP and the APP_PORT in env (port 80 when it is unset).
+func VerifyAppServingWithEnv(cCtx *cli.Context, appID ethcommon.Address, env map[string]string) error {
+	if !cCtx.Bool(common.VerifyRunningFlag.Name) {
+		return nil
+	}
+	logger := common.LoggerFromContext(cCtx)
+
+	host := ""
+	scheme := "http"
+	if domain := domainFromEnv(env); domain != "" && !cCtx.Bool(common.NoTLSFlag.Name) {
+		host = domain
+		scheme = "https"
+	} else {
+		userApiClient, err := NewUserApiClient(cCtx)
+		if err != nil {
+			return fmt.Errorf("failed to get userApi client: %w", err)
+		}
+		info, err := userApiClient.GetInfos(cCtx, []ethcommon.Address{appID}, 1, AddressChainsAll)
+		if err != nil {
+			return fmt.Errorf("failed to get app info: %w", err)
+		}
+		if len(info.Apps) == 0 || info.Apps[0].Ip == "" {
+			return fmt.Errorf("app %s has no IP address to check", appID.Hex())
+		}
+		host = info.Apps[0].Ip
+		if port := env[appPortEnvVar]; port != "" {
+			host = net.JoinHostPort(host, port)
+		}
+	}
+	url := healthCheckURL(scheme, host, cCtx.String(common.HealthPathFlag.Name))
+
+	// Let's Encrypt staging certificates are untrusted by design
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cCtx.Bool(common.TLSStagingFlag.Name) {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	client := &http.Client{Transport: transport, Timeout: healthRequestTimeout}
+
+	timeout := cCtx.Duration(common.HealthTimeoutFlag.Name)
+	logger.Info("Waiting up to %s for %s to respond...", timeout, url)
+	if err := waitForHealthy(cCtx.Context, client, url, timeout, time.Duration(WatchPollInterval(cCtx))*time.Second); err != nil {
+		return fmt.Errorf("app is running but failed its health check at %s: %w", url, err)
+	}
+
+	logger.Info("✓ App is serving at %s", url)
+	return nil
+}
+
+// healthCheckURL joins the scheme, host and path of the health check
+func healthCheckURL(scheme, host, path string) string {
+	return fmt.Sprintf("%s://%s%s", scheme, host, path)
+}
+
+// waitForHealthy requests url every interval until it answers with a 2xx status or timeout
+// elapses, returning the last failure in that case
+func waitForHealthy(ctx context.Context, client *http.Client, url string, timeout, interval time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var lastErr error
+	for {
+		err := probeHealth(ctx, client, url)
+		if err == nil {
+			return nil
+		}
+		// Keep the app's own failure over the deadline cutting off the last request
+		if lastErr == nil || ctx.Err() == nil {
+			lastErr = err
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("no successful response within %s: %w", timeout, lastErr)
+		case <-time.After(interval):
+		}
+	}
+}
+
+// probeHealth sends one GET request to url and reports whether it succeeded
+func probeHealth(ctx context.Context, client *http.Client, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("got %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthCheckURL(t *testing.T) {
+	assert.Equal(t, "https://app.example.com/healthz", healthCheckURL("https", "app.example.com", "/healthz"))
+	assert.Equal(t, "http://1.2.3.4/", healthCheckURL("http", "1.2.3.4", "/"))
+}
+
+func TestWaitForHealthy(t *testing.T) {
+	t.Run("succeeds once the app answers", func(t *testing.T) {
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if requests.Add(1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		err := waitForHealthy(context.Background(), server.Client(), server.URL+"/healthz", 5*time.Second, 10*time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), requests.Load())
+	})
+
+	t.Run("fails with the last error after the timeout", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		err := waitForHealthy(context.Background(), server.Client(), server.URL, 50*time.Millisecond, 10*time.Millisecond)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "502")
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"os"
 	"path/filepath"
+	"regexp"
 	"slices"
 	"sort"
 	"strings"
@@ -13,9 +14,9 @@ import (
 
 	"github.com/Layr-Labs/eigenx-cli/pkg/common"
 	"github.com/Layr-Labs/eigenx-cli/pkg/common/output"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common/progress"
 	"github.com/Layr-Labs/eigenx-cli/pkg/template"
 	"github.com/Layr-Labs/eigenx-contracts/pkg/bindings/v1/AppController"
-	dockercommand "github.com/docker/cli/cli/command"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli/v2"
@@ -25,9 +26,12 @@ import (
 type registryInfo struct {
 	URL      string
 	Username string
-	Type     string // "dockerhub", "ghcr", "gcr", "other"
+	Type     string // "dockerhub", "ghcr", "gcr", "ecr", "acr", "other"
 }
 
+// ecrHostPattern matches Amazon ECR private registry hosts: <account>.dkr.ecr.<region>.amazonaws.com[.cn]
+var ecrHostPattern = regexp.MustCompile(`^(\d{12})\.dkr\.ecr(?:-fips)?\.([a-z0-9-]+)\.amazonaws\.com(?:\.cn)?$`)
+
 // SelectTemplateInteractive prompts the user to select a template from the catalog
 func SelectTemplateInteractive(language string) (string, error) {
 	// Fetch the template catalog
@@ -123,7 +127,7 @@ func GetImageReferenceInteractive(cCtx *cli.Context, argIndex int, buildFromDock
 	}
 
 	// Get available registries
-	registries, _ := getAvailableRegistries()
+	registries, _ := getAvailableRegistries(cCtx.String(common.RegistryAuthFileFlag.Name))
 
 	// Get default app name for suggestions
 	appName := getDefaultAppName()
@@ -185,7 +189,7 @@ func GetLayeredTargetImageInteractive(cCtx *cli.Context, sourceImageRef string)
 	fmt.Println()
 
 	// Detect available registries
-	registries, err := getAvailableRegistries()
+	registries, err := getAvailableRegistries(cCtx.String(common.RegistryAuthFileFlag.Name))
 
 	// Extract base image name and tag from source for suggestions
 	baseImage, tag := extractImageNameAndTag(sourceImageRef)
@@ -261,7 +265,9 @@ func GetAppIDInteractive(cCtx *cli.Context, argIndex int, action string) (ethcom
 		return ethcommon.Address{}, fmt.Errorf("failed to get developer address: %w", err)
 	}
 
-	result, err := appController.GetAppsByDeveloper(&bind.CallOpts{Context: cCtx.Context}, developerAddr, big.NewInt(0), big.NewInt(50))
+	rpcCtx, cancel := RPCContext(cCtx)
+	result, err := appController.GetAppsByDeveloper(&bind.CallOpts{Context: rpcCtx}, developerAddr, big.NewInt(0), big.NewInt(50))
+	cancel()
 	if err != nil {
 		return ethcommon.Address{}, fmt.Errorf("failed to list apps: %w", err)
 	}
@@ -285,11 +291,18 @@ func GetAppIDInteractive(cCtx *cli.Context, argIndex int, action string) (ethcom
 		index   int // Index from contract result (newer apps have higher indices)
 	}
 
-	// Get API statuses for all Started apps to identify which have exited
-	exitedApps := getExitedApps(cCtx, result.Apps, result.AppConfigsMem)
-
-	// Get profile names for all apps from API (for better display in selection list)
-	profileNames := getProfileNamesForApps(cCtx, result.Apps)
+	// Get API statuses for all Started apps to identify which have exited, and profile names
+	// for all apps (for better display in selection list). Both are fetched concurrently.
+	spinner := progress.StartSpinner(fmt.Sprintf("Loading %d apps...", len(result.Apps)))
+	exitedApps, profileNames, _ := common.Parallel(
+		func() (map[string]bool, error) {
+			return getExitedApps(cCtx, result.Apps, result.AppConfigsMem), nil
+		},
+		func() (map[string]string, error) {
+			return getProfileNamesForApps(cCtx, result.Apps), nil
+		},
+	)
+	spinner.Stop()
 
 	// Determine which apps are eligible for the action
 	isEligible := func(status common.AppStatus, addr ethcommon.Address) bool {
@@ -457,6 +470,11 @@ func IsAppNameAvailable(context, name string) bool {
 
 // GetEnvFileInteractive prompts for env file path if not provided
 func GetEnvFileInteractive(cCtx *cli.Context) (string, error) {
+	// Explicit public/private env files replace the single env file
+	if HasExplicitEnvFiles(cCtx) {
+		return "", nil
+	}
+
 	// Check if provided via flag and exists
 	if envFile := cCtx.String(common.EnvFlag.Name); envFile != "" {
 		if _, err := os.Stat(envFile); err == nil {
@@ -539,14 +557,15 @@ func GetDockerfileInteractive(cCtx *cli.Context) (string, error) {
 	}
 }
 
-// getAvailableRegistries returns a list of registries the user has authenticated to
-func getAvailableRegistries() ([]registryInfo, error) {
-	dockerCli, err := dockercommand.NewDockerCli()
+// getAvailableRegistries returns a list of registries the user has authenticated to, read from
+// registryAuthFile when set
+func getAvailableRegistries(registryAuthFile string) ([]registryInfo, error) {
+	configFile, err := loadDockerConfigFile(registryAuthFile)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create docker cli: %w", err)
+		return nil, err
 	}
 
-	allCreds, err := dockerCli.ConfigFile().GetAllCredentials()
+	allCreds, err := configFile.GetAllCredentials()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get credentials: %w", err)
 	}
@@ -564,17 +583,7 @@ func getAvailableRegistries() ([]registryInfo, error) {
 			Username: auth.Username,
 		}
 
-		// Determine registry type
-		switch {
-		case strings.Contains(registry, "index.docker.io"):
-			info.Type = "dockerhub"
-		case strings.Contains(registry, "ghcr.io"):
-			info.Type = "ghcr"
-		case strings.Contains(registry, "gcr.io"):
-			info.Type = "gcr"
-		default:
-			info.Type = "other"
-		}
+		info.Type = classifyRegistry(registry)
 
 		// Skip access-token and refresh-token entries for Docker Hub
 		if info.Type == "dockerhub" && (strings.Contains(registry, "access-token") || strings.Contains(registry, "refresh-token")) {
@@ -613,6 +622,52 @@ func getAvailableRegistries() ([]registryInfo, error) {
 	return registries, nil
 }
 
+// AuthenticatedRegistries returns the registries the Docker config (or registryAuthFile) has
+// credentials for, Docker Hub first
+func AuthenticatedRegistries(registryAuthFile string) ([]string, error) {
+	registries, err := getAvailableRegistries(registryAuthFile)
+	if err != nil {
+		return nil, err
+	}
+	urls := make([]string, len(registries))
+	for i, registry := range registries {
+		urls[i] = registry.URL
+	}
+	return urls, nil
+}
+
+// classifyRegistry determines the registry type from a credential store key
+func classifyRegistry(registry string) string {
+	host := registryHost(registry)
+	switch {
+	case strings.Contains(registry, "index.docker.io"):
+		return "dockerhub"
+	case strings.Contains(registry, "ghcr.io"):
+		return "ghcr"
+	case ecrHostPattern.MatchString(host):
+		return "ecr"
+	case strings.HasSuffix(host, ".azurecr.io"):
+		return "acr"
+	case strings.Contains(registry, "gcr.io"):
+		return "gcr"
+	default:
+		return "other"
+	}
+}
+
+// registryHost strips the scheme and any path from a registry URL
+func registryHost(registry string) string {
+	host := registry
+	if after, ok := strings.CutPrefix(host, "https://"); ok {
+		host = after
+	}
+	if after, ok := strings.CutPrefix(host, "http://"); ok {
+		host = after
+	}
+	host, _, _ = strings.Cut(host, "/")
+	return host
+}
+
 // suggestImageReference generates an image reference suggestion based on registry and context
 func suggestImageReference(registry registryInfo, imageName string, tag string) string {
 	// Clean up image name for use in image reference
@@ -632,17 +687,13 @@ func suggestImageReference(registry registryInfo, imageName string, tag string)
 	case "gcr":
 		// For GCR, username is typically the project ID
 		return fmt.Sprintf("gcr.io/%s/%s:%s", registry.Username, imageName, tag)
+	case "ecr", "acr":
+		// ECR and ACR repositories live directly under the registry host; the
+		// username is a fixed login name ("AWS") or token ID, not a namespace
+		return fmt.Sprintf("%s/%s:%s", registryHost(registry.URL), imageName, tag)
 	default:
 		// For other registries, try to construct a reasonable default
-		host := registry.URL
-		if after, ok := strings.CutPrefix(host, "https://"); ok {
-			host = after
-		}
-		if after, ok := strings.CutPrefix(host, "http://"); ok {
-			host = after
-		}
-		host = strings.TrimSuffix(host, "/")
-		return fmt.Sprintf("%s/%s/%s:%s", host, registry.Username, imageName, tag)
+		return fmt.Sprintf("%s/%s/%s:%s", registryHost(registry.URL), registry.Username, imageName, tag)
 	}
 }
 
@@ -655,6 +706,43 @@ func getDefaultAppName() string {
 	return filepath.Base(cwd)
 }
 
+// GetAppNameFromDir returns the current directory name as an app name for --name-from-dir.
+// The name is sanitized, validated and must not already be used in the given context.
+func GetAppNameFromDir(context string) (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	name := sanitizeAppName(filepath.Base(cwd))
+	if err := common.ValidateAppName(name); err != nil {
+		return "", fmt.Errorf("directory name %q is not a valid app name: %w", filepath.Base(cwd), err)
+	}
+	if !IsAppNameAvailable(context, name) {
+		return "", fmt.Errorf("app name '%s' is already taken in %s, rename the existing app with 'eigenx app name' or use a different directory", name, context)
+	}
+	return name, nil
+}
+
+// sanitizeAppName lowercases a name and replaces runs of characters not allowed
+// in app names with a single hyphen
+func sanitizeAppName(name string) string {
+	var sb strings.Builder
+	lastHyphen := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' || r == '-' {
+			sb.WriteRune(r)
+			lastHyphen = r == '-'
+			continue
+		}
+		if !lastHyphen {
+			sb.WriteByte('-')
+			lastHyphen = true
+		}
+	}
+	return strings.Trim(sb.String(), "-")
+}
+
 // extractImageNameAndTag extracts the base image name and tag from an image reference
 func extractImageNameAndTag(imageRef string) (imageName string, tag string) {
 	// Remove registry prefix if present
@@ -680,6 +768,8 @@ func displayAuthenticationInstructions() {
 	fmt.Println("   • Docker Hub: docker login")
 	fmt.Println("   • GitHub: docker login ghcr.io")
 	fmt.Println("   • Google: docker login gcr.io")
+	fmt.Println("   • AWS: aws ecr get-login-password | docker login --username AWS --password-stdin <account>.dkr.ecr.<region>.amazonaws.com")
+	fmt.Println("   • Azure: az acr login --name <registry>")
 	fmt.Println()
 }
 
@@ -692,6 +782,8 @@ func displayRegistryExamples(appName string) {
 	fmt.Printf("  • username/%s:latest (Docker Hub)\n", appName)
 	fmt.Printf("  • ghcr.io/username/%s:v1.0 (GitHub)\n", appName)
 	fmt.Printf("  • gcr.io/project/%s:latest (Google)\n", appName)
+	fmt.Printf("  • 123456789012.dkr.ecr.us-east-1.amazonaws.com/%s:latest (AWS)\n", appName)
+	fmt.Printf("  • myregistry.azurecr.io/%s:latest (Azure)\n", appName)
 }
 
 // displayDetectedRegistries shows detected registries with examples
@@ -709,6 +801,13 @@ func displayDetectedRegistries(registries []registryInfo, appName string) {
 		case "gcr":
 			fmt.Printf("  • Google Container Registry (project: %s)\n", reg.Username)
 			fmt.Printf("    Example: %s\n", suggestion)
+		case "ecr":
+			match := ecrHostPattern.FindStringSubmatch(registryHost(reg.URL))
+			fmt.Printf("  • Amazon ECR (account: %s, region: %s)\n", match[1], match[2])
+			fmt.Printf("    Example: %s\n", suggestion)
+		case "acr":
+			fmt.Printf("  • Azure Container Registry (registry: %s)\n", strings.TrimSuffix(registryHost(reg.URL), ".azurecr.io"))
+			fmt.Printf("    Example: %s\n", suggestion)
 		default:
 			fmt.Printf("  • %s (username: %s)\n", reg.URL, reg.Username)
 			fmt.Printf("    Example: %s\n", suggestion)
@@ -838,14 +937,38 @@ func getExitedApps(cCtx *cli.Context, apps []ethcommon.Address, appConfigs []App
 		}
 	}
 
-	if len(startedApps) > 0 {
-		if userApiClient, err := NewUserApiClient(cCtx); err == nil {
-			if statuses, err := userApiClient.GetStatuses(cCtx, startedApps); err == nil && statuses != nil {
-				for i, appAddr := range startedApps {
-					if i < len(statuses.Apps) && strings.EqualFold(statuses.Apps[i].Status, common.AppStatusExited) {
-						exitedApps[appAddr.Hex()] = true
-					}
-				}
+	if len(startedApps) == 0 {
+		return exitedApps
+	}
+
+	userApiClient, err := NewUserApiClient(cCtx)
+	if err != nil {
+		return exitedApps
+	}
+
+	// Fetch statuses in batches, all batches in parallel
+	type batchResult struct {
+		batch    []ethcommon.Address
+		statuses *AppStatusResponse
+	}
+	batches := batchAppIDs(startedApps)
+	resultsCh := make(chan batchResult, len(batches))
+
+	for _, batch := range batches {
+		go func(b []ethcommon.Address) {
+			statuses, _ := userApiClient.GetStatuses(cCtx, b)
+			resultsCh <- batchResult{batch: b, statuses: statuses}
+		}(batch)
+	}
+
+	for range batches {
+		res := <-resultsCh
+		if res.statuses == nil {
+			continue
+		}
+		for i, appAddr := range res.batch {
+			if i < len(res.statuses.Apps) && strings.EqualFold(res.statuses.Apps[i].Status, common.AppStatusExited) {
+				exitedApps[appAddr.Hex()] = true
 			}
 		}
 	}
@@ -853,20 +976,40 @@ func getExitedApps(cCtx *cli.Context, apps []ethcommon.Address, appConfigs []App
 	return exitedApps
 }
 
+// batchAppIDs splits apps into batches of at most MaxAppsPerRequest
+func batchAppIDs(apps []ethcommon.Address) [][]ethcommon.Address {
+	var batches [][]ethcommon.Address
+	for i := 0; i < len(apps); i += MaxAppsPerRequest {
+		end := min(i+MaxAppsPerRequest, len(apps))
+		batches = append(batches, apps[i:end])
+	}
+	return batches
+}
+
+// LogSettingsForVisibility maps a log visibility of public, private or off to the log
+// redirection and public logs settings of a release
+func LogSettingsForVisibility(visibility string) (logRedirect string, publicLogs bool, err error) {
+	switch visibility {
+	case "public":
+		return "always", true, nil
+	case "private":
+		return "always", false, nil
+	case "off":
+		return "", false, nil
+	default:
+		return "", false, fmt.Errorf("%s (must be public, private, or off)", visibility)
+	}
+}
+
 // GetLogSettingsInteractive gets log redirection and visibility settings from flags or interactive prompt
 func GetLogSettingsInteractive(cCtx *cli.Context) (logRedirect string, publicLogs bool, err error) {
 	// Check if flag is provided
 	if logVisibilityFlag := cCtx.String("log-visibility"); logVisibilityFlag != "" {
-		switch logVisibilityFlag {
-		case "public":
-			return "always", true, nil
-		case "private":
-			return "always", false, nil
-		case "off":
-			return "", false, nil
-		default:
-			return "", false, fmt.Errorf("invalid --log-visibility value: %s (must be public, private, or off)", logVisibilityFlag)
+		logRedirect, publicLogs, err = LogSettingsForVisibility(logVisibilityFlag)
+		if err != nil {
+			return "", false, fmt.Errorf("invalid --log-visibility value: %w", err)
 		}
+		return logRedirect, publicLogs, nil
 	}
 
 	// Interactive prompt with three options
@@ -967,9 +1110,26 @@ func selectInstanceTypeInteractively(availableTypes []InstanceType, defaultSKU s
 		fmt.Println("\nSelect instance type:")
 	}
 
-	// Build options and create lookup map in a single pass
+	options, defaultOption := instanceTypeOptions(availableTypes, defaultSKU, isCurrentType)
+	choice, err := output.SelectStringWithDefault("Choose instance:", options, defaultOption)
+	if err != nil {
+		return "", fmt.Errorf("failed to select instance: %w", err)
+	}
+
+	// Return the selected SKU
+	for i, option := range options {
+		if option == choice {
+			return availableTypes[i].SKU, nil
+		}
+	}
+	return "", fmt.Errorf("failed to find selected instance type")
+}
+
+// instanceTypeOptions describes each instance type for the picker, in the same order, and returns
+// the option for defaultSKU so it can be preselected
+func instanceTypeOptions(availableTypes []InstanceType, defaultSKU string, isCurrentType bool) ([]string, string) {
 	options := make([]string, len(availableTypes))
-	optionToSKU := make(map[string]string, len(availableTypes))
+	defaultOption := ""
 	for i, it := range availableTypes {
 		option := fmt.Sprintf("%s - %s", it.SKU, it.Description)
 		// Mark the default/current option
@@ -979,18 +1139,11 @@ func selectInstanceTypeInteractively(availableTypes []InstanceType, defaultSKU s
 			} else {
 				option += " (default)"
 			}
+			defaultOption = option
 		}
 		options[i] = option
-		optionToSKU[option] = it.SKU
 	}
-
-	choice, err := output.SelectString("Choose instance:", options)
-	if err != nil {
-		return "", fmt.Errorf("failed to select instance: %w", err)
-	}
-
-	// Return the selected SKU
-	return optionToSKU[choice], nil
+	return options, defaultOption
 }
 
 // GetEnvironmentInteractive gets environment from args or interactive selection
@@ -1098,19 +1251,21 @@ func GetAppXURLInteractive(cCtx *cli.Context) (*string, error) {
 	})
 }
 
-func GetAppImageInteractive(cCtx *cli.Context) (string, error) {
+// GetAppImageInteractive returns the path of the app icon/logo to upload, and whether it is a
+// temporary resized copy that the caller removes once uploaded
+func GetAppImageInteractive(cCtx *cli.Context) (string, bool, error) {
 	if imageFlag := cCtx.String("image"); imageFlag != "" {
 		cleanedPath, imgInfo, err := ValidateAndGetImageInfo(imageFlag)
 		if err != nil {
-			return "", fmt.Errorf("invalid image file: %w", err)
+			return "", false, fmt.Errorf("invalid image file: %w", err)
 		}
 		printImageInfo(imgInfo)
-		return cleanedPath, nil
+		return prepareProfileImage(cCtx, cleanedPath, imgInfo)
 	}
 
 	wantsImage, err := output.Confirm("Would you like to upload an app icon/logo?")
 	if err != nil || !wantsImage {
-		return "", nil
+		return "", false, nil
 	}
 
 	imageInput, err := output.InputString(
@@ -1126,14 +1281,47 @@ func GetAppImageInteractive(cCtx *cli.Context) (string, error) {
 		},
 	)
 	if err != nil || imageInput == "" {
-		return "", nil
+		return "", false, nil
 	}
 
 	cleanedPath, imgInfo, err := ValidateAndGetImageInfo(imageInput)
-	if err == nil {
-		printImageInfo(imgInfo)
+	if err != nil {
+		return "", false, err
+	}
+	printImageInfo(imgInfo)
+	return prepareProfileImage(cCtx, cleanedPath, imgInfo)
+}
+
+// prepareProfileImage returns the path of the image to upload: a resized copy with --resize, or
+// when the user agrees to crop a non-square image, otherwise the image itself. The bool reports
+// whether the path is a resized copy.
+func prepareProfileImage(cCtx *cli.Context, imagePath string, imgInfo *ImageInfo) (string, bool, error) {
+	if !imgInfo.NeedsResize() {
+		return imagePath, false, nil
+	}
+
+	if !cCtx.Bool(common.ResizeImageFlag.Name) {
+		// Oversized square images are fine to upload as they are
+		if imgInfo.IsSquare() || !progress.IsTTY() {
+			return imagePath, false, nil
+		}
+		resize, err := output.ConfirmWithDefault(fmt.Sprintf("Crop and resize the image to %dx%d before uploading?", ProfileImageSize, ProfileImageSize), true)
+		if err != nil {
+			return "", false, fmt.Errorf("failed to get confirmation: %w", err)
+		}
+		if !resize {
+			return imagePath, false, nil
+		}
+	}
+
+	resizedPath, err := ResizeProfileImage(imagePath)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to resize image: %w", err)
 	}
-	return cleanedPath, err
+	if _, resizedInfo, err := ValidateAndGetImageInfo(resizedPath); err == nil {
+		fmt.Printf("📐 Resized to %dx%d PNG, %.1f KB\n", resizedInfo.Width, resizedInfo.Height, resizedInfo.SizeKB)
+	}
+	return resizedPath, true, nil
 }
 
 // CollectedProfile holds collected profile information with pointer fields for optional values
@@ -1143,6 +1331,15 @@ type CollectedProfile struct {
 	Description *string
 	XURL        *string
 	ImagePath   string
+
+	resizedImage bool // ImagePath is a temporary resized copy
+}
+
+// RemoveResizedImage deletes the temporary resized copy of the image, if one was made
+func (p *CollectedProfile) RemoveResizedImage() {
+	if p != nil && p.resizedImage {
+		os.Remove(p.ImagePath)
+	}
 }
 
 // GetAppProfileInteractive collects app profile information interactively
@@ -1174,17 +1371,18 @@ func GetAppProfileInteractive(cCtx *cli.Context, defaultName string, allowRetry
 			return nil, err
 		}
 
-		imagePath, err := GetAppImageInteractive(cCtx)
+		imagePath, resizedImage, err := GetAppImageInteractive(cCtx)
 		if err != nil {
 			return nil, err
 		}
 
 		profile := &CollectedProfile{
-			Name:        name,
-			Website:     website,
-			Description: description,
-			XURL:        xURL,
-			ImagePath:   imagePath,
+			Name:         name,
+			Website:      website,
+			Description:  description,
+			XURL:         xURL,
+			ImagePath:    imagePath,
+			resizedImage: resizedImage,
 		}
 
 		// Always display profile for confirmation
@@ -1192,12 +1390,14 @@ func GetAppProfileInteractive(cCtx *cli.Context, defaultName string, allowRetry
 
 		confirmed, err := output.Confirm("Continue with this profile?")
 		if err != nil {
+			profile.RemoveResizedImage()
 			return nil, fmt.Errorf("failed to get confirmation: %w", err)
 		}
 
 		if confirmed {
 			return profile, nil
 		}
+		profile.RemoveResizedImage()
 
 		// User rejected the profile
 		if !allowRetry {
@@ -1275,7 +1475,7 @@ func getFromFlagOrPrompt(cCtx *cli.Context, config PromptConfig) (*string, error
 func printImageInfo(img *ImageInfo) {
 	fmt.Printf("📸 Image: %dx%d pixels, %.1f KB\n", img.Width, img.Height, img.SizeKB)
 	if !img.IsSquare() {
-		fmt.Printf("⚠️  Note: Image is not square (%.2f:1 ratio). Square images display best.\n", img.AspectRatio())
+		fmt.Printf("⚠️  Note: Image is not square (%.2f:1 ratio) and may be cropped when displayed. Pass --%s to crop it to a square.\n", img.AspectRatio(), common.ResizeImageFlag.Name)
 	}
 }
 
@@ -1292,23 +1492,17 @@ func getProfileNamesForApps(cCtx *cli.Context, apps []ethcommon.Address) map[str
 		return profileNames
 	}
 
-	// Create batches
-	var batches [][]ethcommon.Address
-	for i := 0; i < len(apps); i += MaxAppsPerRequest {
-		end := min(i+MaxAppsPerRequest, len(apps))
-		batches = append(batches, apps[i:end])
-	}
-
 	// Fetch all batches in parallel
 	type batchResult struct {
 		batch []ethcommon.Address
 		infos *AppInfoResponse
 	}
+	batches := batchAppIDs(apps)
 	resultsCh := make(chan batchResult, len(batches))
 
 	for _, batch := range batches {
 		go func(b []ethcommon.Address) {
-			infos, _ := userApiClient.GetInfos(cCtx, b, 0)
+			infos, _ := userApiClient.GetInfos(cCtx, b, 0, AddressChainsAll)
 			resultsCh <- batchResult{batch: b, infos: infos}
 		}(batch)
 	}
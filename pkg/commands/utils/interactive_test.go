@@ -0,0 +1,119 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeAppName(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"my-app", "my-app"},
+		{"MyApp", "myapp"},
+		{"My Cool App", "my-cool-app"},
+		{"api_server.v2", "api_server-v2"},
+		{"  --weird..name--  ", "weird-name"},
+		{"café", "caf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.want, sanitizeAppName(tt.input))
+		})
+	}
+}
+
+func TestClassifyRegistry(t *testing.T) {
+	tests := []struct {
+		registry string
+		want     string
+	}{
+		{"https://index.docker.io/v1/", "dockerhub"},
+		{"ghcr.io", "ghcr"},
+		{"gcr.io", "gcr"},
+		{"https://eu.gcr.io", "gcr"},
+		{"123456789012.dkr.ecr.us-east-1.amazonaws.com", "ecr"},
+		{"https://123456789012.dkr.ecr.eu-west-2.amazonaws.com", "ecr"},
+		{"123456789012.dkr.ecr.cn-north-1.amazonaws.com.cn", "ecr"},
+		{"123456789012.dkr.ecr-fips.us-gov-west-1.amazonaws.com", "ecr"},
+		{"public.ecr.aws", "other"},
+		{"myregistry.azurecr.io", "acr"},
+		{"https://myregistry.azurecr.io", "acr"},
+		{"registry.example.com", "other"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.registry, func(t *testing.T) {
+			assert.Equal(t, tt.want, classifyRegistry(tt.registry))
+		})
+	}
+}
+
+func TestSuggestImageReference(t *testing.T) {
+	tests := []struct {
+		name     string
+		registry registryInfo
+		want     string
+	}{
+		{
+			name:     "dockerhub",
+			registry: registryInfo{URL: "https://index.docker.io/v1/", Username: "alice", Type: "dockerhub"},
+			want:     "alice/my-app:latest",
+		},
+		{
+			name:     "ecr",
+			registry: registryInfo{URL: "https://123456789012.dkr.ecr.us-east-1.amazonaws.com", Username: "AWS", Type: "ecr"},
+			want:     "123456789012.dkr.ecr.us-east-1.amazonaws.com/my-app:latest",
+		},
+		{
+			name:     "acr",
+			registry: registryInfo{URL: "myregistry.azurecr.io", Username: "00000000-0000-0000-0000-000000000000", Type: "acr"},
+			want:     "myregistry.azurecr.io/my-app:latest",
+		},
+		{
+			name:     "other",
+			registry: registryInfo{URL: "https://registry.example.com/", Username: "bob", Type: "other"},
+			want:     "registry.example.com/bob/my-app:latest",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, suggestImageReference(tt.registry, "My_App", ""))
+		})
+	}
+}
+
+func TestInstanceTypeOptions(t *testing.T) {
+	types := []InstanceType{
+		{SKU: "g1-standard-4t", Description: "4 vCPUs"},
+		{SKU: "g1-standard-8t", Description: "8 vCPUs"},
+	}
+
+	options, defaultOption := instanceTypeOptions(types, "g1-standard-8t", true)
+	assert.Equal(t, []string{"g1-standard-4t - 4 vCPUs", "g1-standard-8t - 8 vCPUs (current)"}, options)
+	assert.Equal(t, options[1], defaultOption)
+
+	options, defaultOption = instanceTypeOptions(types, "g1-standard-4t", false)
+	assert.Equal(t, "g1-standard-4t - 4 vCPUs (default)", options[0])
+	assert.Equal(t, options[0], defaultOption)
+
+	// A current type the backend no longer offers preselects nothing
+	_, defaultOption = instanceTypeOptions(types, "g1-retired", true)
+	assert.Empty(t, defaultOption)
+}
+
+func TestValidateInstanceTypeSKU(t *testing.T) {
+	types := []InstanceType{{SKU: "g1-standard-4t"}, {SKU: "g1-standard-8t"}}
+
+	sku, err := validateInstanceTypeSKU("g1-standard-8t", types)
+	require.NoError(t, err)
+	assert.Equal(t, "g1-standard-8t", sku)
+
+	_, err = validateInstanceTypeSKU("g1-huge", types)
+	assert.EqualError(t, err, "invalid --instance-type value: g1-huge (must be one of: g1-standard-4t, g1-standard-8t)")
+}
@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-cli/internal/version"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	appcontrollerV2 "github.com/Layr-Labs/eigenx-contracts/pkg/bindings/v2/AppController"
+	kmstypes "github.com/Layr-Labs/eigenx-kms/pkg/types"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+// ReleaseManifestVersion is the schema version written to release manifests
+const ReleaseManifestVersion = 1
+
+// ReleaseManifest records the exact inputs of a deployed release so it can be reproduced.
+// Private env values are never written, only their names.
+type ReleaseManifest struct {
+	Version        int                      `json:"version"`
+	CLIVersion     string                   `json:"cliVersion"`
+	GeneratedAt    string                   `json:"generatedAt"`
+	Environment    string                   `json:"environment"`
+	ChainID        uint64                   `json:"chainId,omitempty"`
+	AppID          string                   `json:"appId"`
+	Image          string                   `json:"image"`
+	InstanceType   string                   `json:"instanceType"`
+	LogRedirect    string                   `json:"logRedirect,omitempty"`
+	PublicLogs     bool                     `json:"publicLogs"`
+	GitCommit      string                   `json:"gitCommit,omitempty"`
+	TxHash         string                   `json:"txHash,omitempty"`
+	PublicEnv      map[string]string        `json:"publicEnv"`
+	PrivateEnvKeys []string                 `json:"privateEnvKeys"`
+	Contracts      ReleaseManifestContracts `json:"contracts"`
+}
+
+// ReleaseManifestContracts records the contract addresses the release was deployed against
+type ReleaseManifestContracts struct {
+	AppController        string `json:"appController"`
+	PermissionController string `json:"permissionController"`
+	ERC7702Delegator     string `json:"erc7702Delegator"`
+}
+
+// ReleaseManifestInput holds everything needed to describe a prepared release
+type ReleaseManifestInput struct {
+	EnvironmentConfig *common.EnvironmentConfig
+	AppID             gethcommon.Address
+	Release           appcontrollerV2.IAppControllerRelease
+	PrivateEnv        kmstypes.Env
+	InstanceType      string
+	LogRedirect       string
+	PublicLogs        bool
+	GitCommit         string
+	TxHash            gethcommon.Hash
+}
+
+// WriteReleaseManifest writes a release manifest for the given input to path
+func WriteReleaseManifest(cCtx *cli.Context, path string, input ReleaseManifestInput) error {
+	logger := common.LoggerFromContext(cCtx)
+
+	manifest, err := newReleaseManifest(input, time.Now())
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode release manifest: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write release manifest %s: %w", path, err)
+	}
+
+	logger.Info("Release manifest written to %s", path)
+	return nil
+}
+
+// newReleaseManifest builds the manifest from a prepared release
+func newReleaseManifest(input ReleaseManifestInput, now time.Time) (*ReleaseManifest, error) {
+	artifacts := input.Release.RmsRelease.Artifacts
+	if len(artifacts) == 0 {
+		return nil, fmt.Errorf("release has no image artifacts")
+	}
+	artifact := artifacts[0]
+
+	publicEnv := map[string]string{}
+	if len(input.Release.PublicEnv) > 0 {
+		if err := json.Unmarshal(input.Release.PublicEnv, &publicEnv); err != nil {
+			return nil, fmt.Errorf("failed to decode public env: %w", err)
+		}
+	}
+
+	privateEnvKeys := sortedEnvKeys(input.PrivateEnv)
+
+	chainID, _ := common.ChainIDForEnvironment(input.EnvironmentConfig.Name)
+
+	txHash := ""
+	if input.TxHash != (gethcommon.Hash{}) {
+		txHash = input.TxHash.Hex()
+	}
+
+	return &ReleaseManifest{
+		Version:        ReleaseManifestVersion,
+		CLIVersion:     version.GetVersion(),
+		GeneratedAt:    now.UTC().Format(time.RFC3339),
+		Environment:    input.EnvironmentConfig.Name,
+		ChainID:        chainID,
+		AppID:          input.AppID.Hex(),
+		Image:          fmt.Sprintf("%s@%s%s", artifact.Registry, SHA256Prefix, hex.EncodeToString(artifact.Digest[:])),
+		InstanceType:   input.InstanceType,
+		LogRedirect:    input.LogRedirect,
+		PublicLogs:     input.PublicLogs,
+		GitCommit:      input.GitCommit,
+		TxHash:         txHash,
+		PublicEnv:      publicEnv,
+		PrivateEnvKeys: privateEnvKeys,
+		Contracts: ReleaseManifestContracts{
+			AppController:        input.EnvironmentConfig.AppControllerAddress.Hex(),
+			PermissionController: input.EnvironmentConfig.PermissionControllerAddress.Hex(),
+			ERC7702Delegator:     input.EnvironmentConfig.ERC7702DelegatorAddress.Hex(),
+		},
+	}, nil
+}
@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	appcontrollerV2 "github.com/Layr-Labs/eigenx-contracts/pkg/bindings/v2/AppController"
+	kmstypes "github.com/Layr-Labs/eigenx-kms/pkg/types"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReleaseManifest(t *testing.T) {
+	var digest [32]byte
+	digest[0] = 0xab
+
+	input := ReleaseManifestInput{
+		EnvironmentConfig: &common.EnvironmentConfig{Name: "sepolia"},
+		AppID:             gethcommon.HexToAddress("0x1"),
+		Release: appcontrollerV2.IAppControllerRelease{
+			RmsRelease: appcontrollerV2.IReleaseManagerTypesRelease{
+				Artifacts: []appcontrollerV2.IReleaseManagerTypesArtifact{
+					{Digest: digest, Registry: "docker.io/user/app"},
+				},
+			},
+			PublicEnv: []byte(`{"API_URL_PUBLIC":"https://example.com"}`),
+		},
+		PrivateEnv:   kmstypes.Env{"DB_PASSWORD": "hunter2", "API_KEY": "secret"},
+		InstanceType: "g1-standard-4t",
+		PublicLogs:   true,
+		TxHash:       gethcommon.HexToHash("0x2"),
+	}
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	manifest, err := newReleaseManifest(input, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, ReleaseManifestVersion, manifest.Version)
+	assert.Equal(t, "2025-01-02T03:04:05Z", manifest.GeneratedAt)
+	assert.Equal(t, "sepolia", manifest.Environment)
+	assert.Equal(t, "docker.io/user/app@sha256:ab"+strings.Repeat("00", 31), manifest.Image)
+	assert.Equal(t, map[string]string{"API_URL_PUBLIC": "https://example.com"}, manifest.PublicEnv)
+	assert.Equal(t, []string{"API_KEY", "DB_PASSWORD"}, manifest.PrivateEnvKeys)
+	assert.True(t, manifest.PublicLogs)
+	assert.Equal(t, gethcommon.HexToHash("0x2").Hex(), manifest.TxHash)
+}
+
+func TestNewReleaseManifest_NoArtifacts(t *testing.T) {
+	input := ReleaseManifestInput{EnvironmentConfig: &common.EnvironmentConfig{Name: "sepolia"}}
+
+	_, err := newReleaseManifest(input, time.Now())
+	assert.Error(t, err)
+}
+
+func TestNewReleaseManifest_NoPrivateEnv(t *testing.T) {
+	input := ReleaseManifestInput{
+		EnvironmentConfig: &common.EnvironmentConfig{Name: "sepolia"},
+		Release: appcontrollerV2.IAppControllerRelease{
+			RmsRelease: appcontrollerV2.IReleaseManagerTypesRelease{
+				Artifacts: []appcontrollerV2.IReleaseManagerTypesArtifact{{Registry: "docker.io/user/app"}},
+			},
+		},
+	}
+
+	manifest, err := newReleaseManifest(input, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []string{}, manifest.PrivateEnvKeys)
+}
@@ -8,7 +8,6 @@ import (
 	"github.com/Layr-Labs/eigenx-cli/pkg/common"
 	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/urfave/cli/v2"
 )
 
@@ -16,7 +15,7 @@ import (
 type PreflightContext struct {
 	Caller            *common.ContractCaller
 	EnvironmentConfig *common.EnvironmentConfig
-	Client            *ethclient.Client
+	Client            *common.FailoverClient
 	PrivateKey        string
 }
 
@@ -39,23 +38,25 @@ func DoPreflightChecks(cCtx *cli.Context) (*PreflightContext, error) {
 		return nil, fmt.Errorf("failed to get environment config: %w", err)
 	}
 
-	// 3. Get RPC URL
-	rpcURL, err := getRPCURL(cCtx, &environmentConfig)
+	// 3. Get RPC URLs
+	rpcURLs, err := GetRPCURLs(cCtx, &environmentConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get RPC URL: %w", err)
 	}
 
 	// 4. Test network connectivity
 	logger.Debug("Testing network connectivity...")
-	client, err := ethclient.Dial(rpcURL)
+	client, err := common.NewFailoverClient(rpcURLs, logger)
 	if err != nil {
-		return nil, fmt.Errorf("cannot connect to %s RPC at %s: %w", environmentConfig.Name, rpcURL, err)
+		return nil, fmt.Errorf("cannot connect to %s RPC: %w", environmentConfig.Name, err)
 	}
 
 	// 5. Get chain ID
-	chainID, err := client.ChainID(cCtx.Context)
+	rpcCtx, cancel := RPCContext(cCtx)
+	chainID, err := client.ChainID(rpcCtx)
+	cancel()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get chain ID from %s: %w", rpcURL, err)
+		return nil, fmt.Errorf("failed to get chain ID from %s: %w", client.Endpoint(), err)
 	}
 
 	// 6. Create contract caller
@@ -69,6 +70,8 @@ func DoPreflightChecks(cCtx *cli.Context) (*PreflightContext, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create contract caller: %w", err)
 	}
+	contractCaller.SetRPCTimeout(cCtx.Duration(common.RpcTimeoutFlag.Name))
+	contractCaller.SetReuseDelegationCheck(cCtx.Bool(common.ReuseDelegationCheckFlag.Name))
 
 	return &PreflightContext{
 		Caller:            contractCaller,
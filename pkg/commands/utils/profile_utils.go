@@ -4,8 +4,9 @@ import (
 	"fmt"
 	"html"
 	"image"
+	"image/color"
 	_ "image/jpeg" // Register JPEG format decoder
-	_ "image/png"  // Register PNG format decoder
+	"image/png"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -18,6 +19,7 @@ const (
 	MaxAppNameLength     = 100
 	MaxDescriptionLength = 1000
 	BytesPerMB           = 1024 * 1024
+	ProfileImageSize     = 512 // Width and height of resized profile images
 )
 
 var (
@@ -42,13 +44,9 @@ type ImageInfo struct {
 	Format string
 }
 
-// IsSquare checks if image has approximately square aspect ratio
+// IsSquare checks if the image is as wide as it is high
 func (img *ImageInfo) IsSquare() bool {
-	if img.Width == 0 || img.Height == 0 {
-		return false
-	}
-	aspectRatio := float64(img.Width) / float64(img.Height)
-	return aspectRatio >= 0.8 && aspectRatio <= 1.25
+	return img.Width > 0 && img.Width == img.Height
 }
 
 // AspectRatio returns the width/height ratio
@@ -59,6 +57,73 @@ func (img *ImageInfo) AspectRatio() float64 {
 	return float64(img.Width) / float64(img.Height)
 }
 
+// NeedsResize reports whether the image is not square or larger than ProfileImageSize
+func (img *ImageInfo) NeedsResize() bool {
+	return !img.IsSquare() || img.Width > ProfileImageSize || img.Height > ProfileImageSize
+}
+
+// ResizeProfileImage center-crops the image at filePath to a square, scales it down to at most
+// ProfileImageSize pixels and writes it as a PNG to a temporary file, whose path is returned
+func ResizeProfileImage(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open image file: %w", err)
+	}
+	defer file.Close()
+
+	src, _, err := image.Decode(file)
+	if err != nil {
+		return "", fmt.Errorf("invalid or corrupted image file: %w", err)
+	}
+
+	out, err := os.CreateTemp("", "eigenx-profile-*.png")
+	if err != nil {
+		return "", fmt.Errorf("failed to create resized image: %w", err)
+	}
+	defer out.Close()
+
+	if err := png.Encode(out, cropAndScale(src, ProfileImageSize)); err != nil {
+		os.Remove(out.Name())
+		return "", fmt.Errorf("failed to encode resized image: %w", err)
+	}
+	return out.Name(), nil
+}
+
+// cropAndScale crops the center square of src and scales it down to at most size pixels per
+// side, averaging the source pixels that fall into each destination pixel. Smaller images are
+// only cropped.
+func cropAndScale(src image.Image, size int) *image.RGBA {
+	bounds := src.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	x0 := bounds.Min.X + (bounds.Dx()-side)/2
+	y0 := bounds.Min.Y + (bounds.Dy()-side)/2
+	target := min(side, size)
+
+	dst := image.NewRGBA(image.Rect(0, 0, target, target))
+	for y := 0; y < target; y++ {
+		sy0, sy1 := y*side/target, max((y+1)*side/target, y*side/target+1)
+		for x := 0; x < target; x++ {
+			sx0, sx1 := x*side/target, max((x+1)*side/target, x*side/target+1)
+
+			var r, g, b, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					pr, pg, pb, pa := src.At(x0+sx, y0+sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(b / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+	return dst
+}
+
 // ValidateURL validates that a string is a valid URL
 func ValidateURL(rawURL string) error {
 	if strings.TrimSpace(rawURL) == "" {
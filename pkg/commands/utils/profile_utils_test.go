@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestPNG(t *testing.T, width, height int) string {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			// Left half red, right half blue so the crop position is observable
+			c := color.RGBA{R: 255, A: 255}
+			if x >= width/2 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "image.png")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+	require.NoError(t, png.Encode(f, img))
+	return path
+}
+
+func TestImageInfoNeedsResize(t *testing.T) {
+	assert.False(t, (&ImageInfo{Width: 512, Height: 512}).NeedsResize())
+	assert.True(t, (&ImageInfo{Width: 100, Height: 110}).NeedsResize(), "nearly square images are cropped too")
+	assert.True(t, (&ImageInfo{Width: 1024, Height: 1024}).NeedsResize())
+	assert.True(t, (&ImageInfo{Width: 300, Height: 100}).NeedsResize())
+}
+
+func TestCropAndScale(t *testing.T) {
+	t.Run("crops the center of a wide image", func(t *testing.T) {
+		src := image.NewRGBA(image.Rect(0, 0, 300, 100))
+		for x := 100; x < 200; x++ {
+			for y := 0; y < 100; y++ {
+				src.SetRGBA(x, y, color.RGBA{G: 255, A: 255})
+			}
+		}
+		dst := cropAndScale(src, 512)
+		assert.Equal(t, image.Rect(0, 0, 100, 100), dst.Bounds())
+		assert.Equal(t, color.RGBA{G: 255, A: 255}, dst.RGBAAt(0, 0))
+		assert.Equal(t, color.RGBA{G: 255, A: 255}, dst.RGBAAt(99, 99))
+	})
+
+	t.Run("scales large images down", func(t *testing.T) {
+		src := image.NewRGBA(image.Rect(0, 0, 1024, 2048))
+		dst := cropAndScale(src, 512)
+		assert.Equal(t, image.Rect(0, 0, 512, 512), dst.Bounds())
+	})
+
+	t.Run("averages source pixels", func(t *testing.T) {
+		src := image.NewRGBA(image.Rect(0, 0, 2, 2))
+		src.SetRGBA(0, 0, color.RGBA{R: 255, A: 255})
+		src.SetRGBA(1, 1, color.RGBA{R: 255, A: 255})
+		dst := cropAndScale(src, 1)
+		assert.Equal(t, color.RGBA{R: 127, A: 127}, dst.RGBAAt(0, 0))
+	})
+}
+
+func TestResizeProfileImage(t *testing.T) {
+	path := writeTestPNG(t, 2000, 1000)
+
+	resized, err := ResizeProfileImage(path)
+	require.NoError(t, err)
+	defer os.Remove(resized)
+
+	_, info, err := ValidateAndGetImageInfo(resized)
+	require.NoError(t, err)
+	assert.Equal(t, "png", info.Format)
+	assert.Equal(t, ProfileImageSize, info.Width)
+	assert.Equal(t, ProfileImageSize, info.Height)
+	assert.False(t, info.NeedsResize())
+
+	_, err = ResizeProfileImage(filepath.Join(t.TempDir(), "missing.png"))
+	assert.Error(t, err)
+}
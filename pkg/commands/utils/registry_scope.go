@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	// ghcrPushScope is the classic personal access token scope needed to push to GHCR
+	ghcrPushScope = "write:packages"
+
+	// ghcrNewTokenURL opens the GitHub token form with the push scope preselected
+	ghcrNewTokenURL = "https://github.com/settings/tokens/new?scopes=write:packages"
+
+	registryScopeCheckTimeout = 10 * time.Second
+)
+
+// githubUserAPIURL is the authorized endpoint whose response lists the scopes of a classic token
+var githubUserAPIURL = "https://api.github.com/user"
+
+// CheckRegistryPushScope warns before building when --registry-scope-check is set and the stored
+// GHCR token for imageRef cannot push packages, so the push doesn't fail after a full build.
+// Only classic tokens report their scopes; other tokens and registries are not checked.
+func CheckRegistryPushScope(cCtx *cli.Context, imageRef string) {
+	if !cCtx.Bool(common.RegistryScopeCheckFlag.Name) {
+		return
+	}
+	logger := common.LoggerFromContext(cCtx)
+
+	if classifyRegistry(imageRef) != "ghcr" {
+		logger.Debug("Skipping registry scope check: %s is not a GHCR image", imageRef)
+		return
+	}
+
+	configFile, err := loadDockerConfigFile(cCtx.String(common.RegistryAuthFileFlag.Name))
+	if err != nil {
+		logger.Warn("Could not check GHCR token scopes: %v", err)
+		return
+	}
+	auth, err := configFile.GetAuthConfig(registryHost(imageRef))
+	if err != nil || auth.Password == "" {
+		logger.Warn("No stored credentials for ghcr.io, the push will fail. Run: docker login ghcr.io")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(cCtx.Context, registryScopeCheckTimeout)
+	defer cancel()
+
+	scopes, known, err := fetchGitHubTokenScopes(ctx, auth.Password)
+	if err != nil {
+		logger.Warn("Could not check GHCR token scopes: %v", err)
+		return
+	}
+	if !known {
+		logger.Debug("GHCR token does not report its scopes (fine-grained or app token), skipping scope check")
+		return
+	}
+	if !slices.Contains(scopes, ghcrPushScope) {
+		logger.Warn("The GHCR token for %s lacks the '%s' scope, so pushing %s will fail", auth.Username, ghcrPushScope, imageRef)
+		logger.Warn("Create a token with that scope at %s, then run: docker login ghcr.io", ghcrNewTokenURL)
+	}
+}
+
+// fetchGitHubTokenScopes returns the scopes GitHub reports for token. known is false when the
+// response carries no X-OAuth-Scopes header, which is the case for non-classic tokens.
+func fetchGitHubTokenScopes(ctx context.Context, token string) (scopes []string, known bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, githubUserAPIURL, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to query GitHub: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return nil, false, fmt.Errorf("GitHub rejected the stored token, it may be expired or revoked")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("GitHub returned %s", resp.Status)
+	}
+
+	header, ok := resp.Header[http.CanonicalHeaderKey("X-OAuth-Scopes")]
+	if !ok {
+		return nil, false, nil
+	}
+	for _, scope := range strings.Split(strings.Join(header, ","), ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes, true, nil
+}
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchGitHubTokenScopes(t *testing.T) {
+	tests := []struct {
+		name       string
+		token      string
+		wantScopes []string
+		wantKnown  bool
+		wantErr    bool
+	}{
+		{name: "classic token with push scope", token: "push", wantScopes: []string{"read:packages", "write:packages"}, wantKnown: true},
+		{name: "classic token without scopes", token: "empty", wantKnown: true},
+		{name: "fine-grained token", token: "fine-grained"},
+		{name: "revoked token", token: "revoked", wantErr: true},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "Bearer push":
+			w.Header().Set("X-OAuth-Scopes", "read:packages, write:packages")
+		case "Bearer empty":
+			w.Header().Set("X-OAuth-Scopes", "")
+		case "Bearer fine-grained":
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	original := githubUserAPIURL
+	githubUserAPIURL = server.URL
+	defer func() { githubUserAPIURL = original }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scopes, known, err := fetchGitHubTokenScopes(context.Background(), tt.token)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantKnown, known)
+			assert.Equal(t, tt.wantScopes, scopes)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package utils
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	appcontrollerV1 "github.com/Layr-Labs/eigenx-contracts/pkg/bindings/v1/AppController"
+	appcontrollerV2 "github.com/Layr-Labs/eigenx-contracts/pkg/bindings/v2/AppController"
+	kmstypes "github.com/Layr-Labs/eigenx-kms/pkg/types"
+	"github.com/urfave/cli/v2"
+)
+
+// ReleaseDifference is a single field that differs between a deployed and a local release.
+// An empty Deployed or Local value means the field is absent on that side.
+type ReleaseDifference struct {
+	Field    string
+	Deployed string
+	Local    string
+}
+
+// releaseSnapshot holds the comparable parts of a release. The private env is
+// encrypted with a fresh key for every release, so it can never be compared.
+type releaseSnapshot struct {
+	Image     string
+	PublicEnv map[string]string
+}
+
+// DiffDeployedRelease prints a unified diff of the image and public env between the latest
+// release of an app and a local one. An empty image is not compared, for releases that have
+// not been built. Private variables are encrypted on-chain, so the local privateEnv is listed
+// by name only. It returns true if the image or public env differ.
+func DiffDeployedRelease(cCtx *cli.Context, appID string, deployed *appcontrollerV1.IAppControllerRelease, image string, publicEnv map[string]string, privateEnv kmstypes.Env) (bool, error) {
+	logger := common.LoggerFromContext(cCtx)
+
+	deployedSnapshot, err := newReleaseSnapshot(deployedImage(deployed), deployed.PublicEnv)
+	if err != nil {
+		return false, fmt.Errorf("failed to read deployed release: %w", err)
+	}
+	localSnapshot := releaseSnapshot{Image: image, PublicEnv: publicEnv}
+
+	changed := len(diffReleaseSnapshots(deployedSnapshot, localSnapshot)) > 0
+
+	fmt.Println()
+	fmt.Printf("--- deployed (%s)\n", appID)
+	fmt.Println("+++ local")
+	for _, line := range unifiedReleaseDiff(deployedSnapshot, localSnapshot) {
+		switch line[0] {
+		case '-':
+			fmt.Println(common.Style(line, common.StyleRed))
+		case '+':
+			fmt.Println(common.Style(line, common.StyleGreen))
+		default:
+			fmt.Println(line)
+		}
+	}
+
+	if len(privateEnv) > 0 {
+		fmt.Println()
+		fmt.Println("Private variables (encrypted on-chain, values are never compared):")
+		for _, key := range sortedEnvKeys(privateEnv) {
+			fmt.Printf("  ? %s\n", key)
+		}
+	}
+	fmt.Println()
+
+	if image == "" {
+		logger.Info("The image is not compared, as it is only known after building")
+	}
+	if !changed {
+		logger.Info("No changes: local configuration matches the deployed release of %s", appID)
+	}
+	return changed, nil
+}
+
+// DiffPreparedRelease is DiffDeployedRelease for a release returned by PrepareReleaseFromContext
+func DiffPreparedRelease(cCtx *cli.Context, appID string, deployed *appcontrollerV1.IAppControllerRelease, local appcontrollerV2.IAppControllerRelease, privateEnv kmstypes.Env) (bool, error) {
+	localSnapshot, err := newReleaseSnapshot(localImage(local), local.PublicEnv)
+	if err != nil {
+		return false, fmt.Errorf("failed to read local release: %w", err)
+	}
+	return DiffDeployedRelease(cCtx, appID, deployed, localSnapshot.Image, localSnapshot.PublicEnv, privateEnv)
+}
+
+// unifiedReleaseDiff renders the image and public env of both releases as "image=..." and "KEY=value"
+// lines sorted by name, prefixed with ' ' when unchanged, '-' when only deployed and '+' when only
+// local. An empty local image is shown as unchanged.
+func unifiedReleaseDiff(deployed, local releaseSnapshot) []string {
+	var lines []string
+	if local.Image == "" || deployed.Image == local.Image {
+		lines = append(lines, " image="+deployed.Image)
+	} else {
+		lines = append(lines, "-image="+deployed.Image, "+image="+local.Image)
+	}
+
+	for _, key := range mergedEnvKeys(deployed.PublicEnv, local.PublicEnv) {
+		deployedValue, inDeployed := deployed.PublicEnv[key]
+		localValue, inLocal := local.PublicEnv[key]
+		if inDeployed && inLocal && deployedValue == localValue {
+			lines = append(lines, " "+key+"="+localValue)
+			continue
+		}
+		if inDeployed {
+			lines = append(lines, "-"+key+"="+deployedValue)
+		}
+		if inLocal {
+			lines = append(lines, "+"+key+"="+localValue)
+		}
+	}
+	return lines
+}
+
+func deployedImage(release *appcontrollerV1.IAppControllerRelease) string {
+	if len(release.RmsRelease.Artifacts) == 0 {
+		return ""
+	}
+	artifact := release.RmsRelease.Artifacts[0]
+	return fmt.Sprintf("%s@%s%s", artifact.Registry, SHA256Prefix, hex.EncodeToString(artifact.Digest[:]))
+}
+
+func localImage(release appcontrollerV2.IAppControllerRelease) string {
+	if len(release.RmsRelease.Artifacts) == 0 {
+		return ""
+	}
+	artifact := release.RmsRelease.Artifacts[0]
+	return fmt.Sprintf("%s@%s%s", artifact.Registry, SHA256Prefix, hex.EncodeToString(artifact.Digest[:]))
+}
+
+// newReleaseSnapshot decodes the public env of a release
+func newReleaseSnapshot(image string, publicEnv []byte) (releaseSnapshot, error) {
+	snapshot := releaseSnapshot{Image: image, PublicEnv: map[string]string{}}
+	if len(publicEnv) > 0 {
+		if err := json.Unmarshal(publicEnv, &snapshot.PublicEnv); err != nil {
+			return releaseSnapshot{}, fmt.Errorf("failed to decode public env: %w", err)
+		}
+	}
+	return snapshot, nil
+}
+
+// diffReleaseSnapshots lists the image change, if any, followed by public env changes sorted by
+// name. The image is skipped when the local one is unknown.
+func diffReleaseSnapshots(deployed, local releaseSnapshot) []ReleaseDifference {
+	var differences []ReleaseDifference
+	if local.Image != "" && deployed.Image != local.Image {
+		differences = append(differences, ReleaseDifference{Field: "image", Deployed: deployed.Image, Local: local.Image})
+	}
+
+	for _, key := range mergedEnvKeys(deployed.PublicEnv, local.PublicEnv) {
+		deployedValue, inDeployed := deployed.PublicEnv[key]
+		localValue, inLocal := local.PublicEnv[key]
+		if inDeployed && inLocal && deployedValue == localValue {
+			continue
+		}
+		differences = append(differences, ReleaseDifference{Field: key, Deployed: deployedValue, Local: localValue})
+	}
+	return differences
+}
+
+// mergedEnvKeys returns the keys of both env maps, sorted and without duplicates
+func mergedEnvKeys(a, b map[string]string) []string {
+	merged := make(map[string]string, len(a)+len(b))
+	maps.Copy(merged, a)
+	maps.Copy(merged, b)
+	return sortedEnvKeys(merged)
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffReleaseSnapshots(t *testing.T) {
+	deployed, err := newReleaseSnapshot("docker.io/user/app@sha256:aa", []byte(`{"A_PUBLIC":"1","B_PUBLIC":"2","EIGEN_MACHINE_TYPE_PUBLIC":"g1-standard-4t"}`))
+	require.NoError(t, err)
+
+	t.Run("identical", func(t *testing.T) {
+		local, err := newReleaseSnapshot(deployed.Image, []byte(`{"EIGEN_MACHINE_TYPE_PUBLIC":"g1-standard-4t","B_PUBLIC":"2","A_PUBLIC":"1"}`))
+		require.NoError(t, err)
+		assert.Empty(t, diffReleaseSnapshots(deployed, local))
+	})
+
+	t.Run("changes", func(t *testing.T) {
+		local, err := newReleaseSnapshot("docker.io/user/app@sha256:bb", []byte(`{"A_PUBLIC":"1","C_PUBLIC":"3","EIGEN_MACHINE_TYPE_PUBLIC":"g1-standard-8t"}`))
+		require.NoError(t, err)
+
+		assert.Equal(t, []ReleaseDifference{
+			{Field: "image", Deployed: "docker.io/user/app@sha256:aa", Local: "docker.io/user/app@sha256:bb"},
+			{Field: "B_PUBLIC", Deployed: "2"},
+			{Field: "C_PUBLIC", Local: "3"},
+			{Field: "EIGEN_MACHINE_TYPE_PUBLIC", Deployed: "g1-standard-4t", Local: "g1-standard-8t"},
+		}, diffReleaseSnapshots(deployed, local))
+	})
+
+	t.Run("unbuilt image is not compared", func(t *testing.T) {
+		local := releaseSnapshot{PublicEnv: map[string]string{"A_PUBLIC": "1", "B_PUBLIC": "2", "EIGEN_MACHINE_TYPE_PUBLIC": "g1-standard-4t"}}
+		assert.Empty(t, diffReleaseSnapshots(deployed, local))
+	})
+}
+
+func TestNewReleaseSnapshot_InvalidPublicEnv(t *testing.T) {
+	_, err := newReleaseSnapshot("img", []byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestUnifiedReleaseDiff(t *testing.T) {
+	deployed, err := newReleaseSnapshot("docker.io/user/app@sha256:aa", []byte(`{"A_PUBLIC":"1","B_PUBLIC":"2","D_PUBLIC":"4"}`))
+	require.NoError(t, err)
+
+	t.Run("identical", func(t *testing.T) {
+		assert.Equal(t, []string{
+			" image=docker.io/user/app@sha256:aa",
+			" A_PUBLIC=1",
+			" B_PUBLIC=2",
+			" D_PUBLIC=4",
+		}, unifiedReleaseDiff(deployed, deployed))
+	})
+
+	t.Run("changes", func(t *testing.T) {
+		local, err := newReleaseSnapshot("docker.io/user/app@sha256:bb", []byte(`{"A_PUBLIC":"1","C_PUBLIC":"3","D_PUBLIC":"5"}`))
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{
+			"-image=docker.io/user/app@sha256:aa",
+			"+image=docker.io/user/app@sha256:bb",
+			" A_PUBLIC=1",
+			"-B_PUBLIC=2",
+			"+C_PUBLIC=3",
+			"-D_PUBLIC=4",
+			"+D_PUBLIC=5",
+		}, unifiedReleaseDiff(deployed, local))
+	})
+
+	t.Run("unbuilt image", func(t *testing.T) {
+		local := releaseSnapshot{PublicEnv: deployed.PublicEnv}
+		assert.Equal(t, " image=docker.io/user/app@sha256:aa", unifiedReleaseDiff(deployed, local)[0])
+	})
+}
@@ -2,15 +2,23 @@ package utils
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"regexp"
+	"slices"
+	"sort"
 	"strings"
 	"text/tabwriter"
 	"time"
 
 	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	commonlogger "github.com/Layr-Labs/eigenx-cli/pkg/common/logger"
 	"github.com/Layr-Labs/eigenx-cli/pkg/common/output"
 	appcontrollerV2 "github.com/Layr-Labs/eigenx-contracts/pkg/bindings/v2/AppController"
 	kmscrypto "github.com/Layr-Labs/eigenx-kms/pkg/crypto"
@@ -31,12 +39,13 @@ import (
 // PrepareReleaseFromContext prepares a release with separated Dockerfile handling
 // The dockerfile path and env file path are provided as parameters (already collected earlier)
 // maxPushRetries controls how many times to retry on push permission errors (0 = no retries)
-func PrepareReleaseFromContext(cCtx *cli.Context, environmentConfig *common.EnvironmentConfig, appID gethcommon.Address, dockerfilePath string, imageRef string, envFilePath string, logRedirect string, instanceType string, maxPushRetries int) (appcontrollerV2.IAppControllerRelease, string, error) {
+// It also returns the private env encrypted into the release, for describing it by name.
+func PrepareReleaseFromContext(cCtx *cli.Context, environmentConfig *common.EnvironmentConfig, appID gethcommon.Address, dockerfilePath string, imageRef string, envFilePath string, logRedirect string, instanceType string, maxPushRetries int) (appcontrollerV2.IAppControllerRelease, kmstypes.Env, string, error) {
 	logger := common.LoggerFromContext(cCtx)
 
 	// Create operation closures that capture context
 	buildAndPush := func(ref string) (string, error) {
-		return buildAndPushLayeredImage(cCtx, *environmentConfig, dockerfilePath, ref, logRedirect, envFilePath)
+		return buildAndPushLayeredImage(cCtx, *environmentConfig, ".", dockerfilePath, ref, logRedirect, envFilePath)
 	}
 
 	layerRemoteImage := func(ref string) (string, error) {
@@ -46,41 +55,141 @@ func PrepareReleaseFromContext(cCtx *cli.Context, environmentConfig *common.Envi
 	// Ensure image is compatible with EigenX (either build from Dockerfile or layer existing image)
 	var err error
 	if dockerfilePath != "" {
+		if cCtx.Bool(common.VerifySignatureFlag.Name) {
+			return appcontrollerV2.IAppControllerRelease{}, nil, imageRef, fmt.Errorf("--%s verifies a published image and cannot be used when building from a Dockerfile", common.VerifySignatureFlag.Name)
+		}
+		if err := CheckBuildContextSize(cCtx, ".", dockerfilePath); err != nil {
+			return appcontrollerV2.IAppControllerRelease{}, nil, imageRef, err
+		}
+
 		// Build and push with retry logic for permission errors
 		imageRef, err = retryImagePushOperation(cCtx, maxPushRetries, "build and push", buildAndPush, imageRef)
 		if err != nil {
-			return appcontrollerV2.IAppControllerRelease{}, imageRef, fmt.Errorf("failed to build and push layered image: %w", err)
+			return appcontrollerV2.IAppControllerRelease{}, nil, imageRef, fmt.Errorf("failed to build and push layered image: %w", err)
 		}
 
 		// Wait for registry propagation
-		logger.Info("Waiting %d seconds for registry propagation...", RegistryPropagationWaitSeconds)
-		time.Sleep(RegistryPropagationWaitSeconds * time.Second)
+		waitForRegistryPropagation(cCtx, imageRef)
 	} else {
+		// Check the image the user published, before it is layered into one they never signed
+		if err := verifySourceImageSignature(cCtx, imageRef); err != nil {
+			return appcontrollerV2.IAppControllerRelease{}, nil, imageRef, err
+		}
+
 		// Layer remote image if needed, with retry logic for permission errors
+		sourceImageRef := imageRef
 		imageRef, err = retryImagePushOperation(cCtx, maxPushRetries, "layer published image", layerRemoteImage, imageRef)
+		if err != nil && rebuildablePlatformMismatch(cCtx, err) == nil {
+			return appcontrollerV2.IAppControllerRelease{}, nil, imageRef, fmt.Errorf("failed to ensure image compatibility: %w", err)
+		}
 		if err != nil {
-			return appcontrollerV2.IAppControllerRelease{}, imageRef, fmt.Errorf("failed to ensure image compatibility: %w", err)
+			// Resolved below by rebuilding the image from the local Dockerfile
+			imageRef = sourceImageRef
 		}
 	}
 
-	digest, name, err := getImageDigestAndName(cCtx.Context, imageRef)
+	var digest [32]byte
+	var name string
+	if err == nil {
+		digest, name, err = getImageDigestAndName(cCtx.Context, DigestResolverFromContext(cCtx), imageRef)
+	}
+	if mismatchErr := rebuildablePlatformMismatch(cCtx, err); mismatchErr != nil {
+		digest, name, imageRef, err = rebuildForPlatformMismatch(cCtx, environmentConfig, dockerfilePath, imageRef, envFilePath, logRedirect, maxPushRetries, mismatchErr)
+	}
 	if err != nil {
-		return appcontrollerV2.IAppControllerRelease{}, imageRef, fmt.Errorf("failed to get image digest and name: %w", err)
+		return appcontrollerV2.IAppControllerRelease{}, nil, imageRef, fmt.Errorf("failed to get image digest and name: %w", err)
 	}
 
 	fmt.Println()
 	logger.Info("Name: %s", name)
 	logger.Info("Image digest: %s", hex.EncodeToString(digest[:]))
 
-	var publicEnv, privateEnv map[string]string
+	publicEnv, privateEnv, err := ParseEnvFromContext(cCtx, envFilePath)
+	if err != nil {
+		return appcontrollerV2.IAppControllerRelease{}, nil, imageRef, err
+	}
+
+	release, err := NewReleaseForImage(cCtx, environmentConfig, appID, digest, name, publicEnv, privateEnv, instanceType)
+	if err != nil {
+		return appcontrollerV2.IAppControllerRelease{}, nil, imageRef, err
+	}
+
+	return release, privateEnv, imageRef, nil
+}
+
+// ParseEnvFromContext reads the public and private variables for a release from the
+// --public-env-file/--private-env-file flags or from envFilePath, which may be empty
+func ParseEnvFromContext(cCtx *cli.Context, envFilePath string) (kmstypes.Env, kmstypes.Env, error) {
+	if HasExplicitEnvFiles(cCtx) {
+		publicEnv, privateEnv, err := parseAndValidateExplicitEnvFiles(cCtx, cCtx.String(common.PublicEnvFileFlag.Name), cCtx.String(common.PrivateEnvFileFlag.Name))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse and validate env files: %w", err)
+		}
+		return publicEnv, privateEnv, nil
+	}
+
 	if envFilePath == "" {
-		logger.Info("Continuing without environment file")
-		publicEnv, privateEnv = make(map[string]string), make(map[string]string)
-	} else {
-		publicEnv, privateEnv, err = parseAndValidateEnvFile(cCtx, envFilePath)
+		common.LoggerFromContext(cCtx).Info("Continuing without environment file")
+		return kmstypes.Env{}, kmstypes.Env{}, nil
+	}
+
+	publicEnv, privateEnv, err := parseAndValidateEnvFile(cCtx, envFilePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse and validate env file: %w", err)
+	}
+	return publicEnv, privateEnv, nil
+}
+
+// ParseEnvWithPublicBase takes publicEnv as the public variables (e.g. copied from another app)
+// and reads the private variables from privateEnvFilePath, which may be empty. Variables
+// defined in the private env file are removed from the public ones.
+func ParseEnvWithPublicBase(cCtx *cli.Context, publicEnv map[string]string, publicSource, privateEnvFilePath string) (kmstypes.Env, kmstypes.Env, error) {
+	public := kmstypes.Env{}
+	for varName, value := range publicEnv {
+		public[varName] = value
+	}
+	private := kmstypes.Env{}
+	mnemonicFiltered := false
+
+	sources := []string{fmt.Sprintf("public: %s", publicSource)}
+	if privateEnvFilePath != "" {
+		envVars, err := readEnvFile(privateEnvFilePath)
 		if err != nil {
-			return appcontrollerV2.IAppControllerRelease{}, imageRef, fmt.Errorf("failed to parse and validate env file: %w", err)
+			return nil, nil, err
+		}
+		for varName, value := range envVars {
+			if strings.ToUpper(varName) == common.MnemonicEnvVar {
+				mnemonicFiltered = true
+				continue
+			}
+			// A private definition takes the variable out of the public env
+			delete(public, varName)
+			private[varName] = value
 		}
+		sources = append(sources, fmt.Sprintf("private: %s", privateEnvFilePath))
+	}
+
+	categorization := envCategorization{
+		publicEnv:        public,
+		privateEnv:       private,
+		mnemonicFiltered: mnemonicFiltered,
+		source:           strings.Join(sources, ", "),
+		makePrivateHint:  "add it to the private env file to encrypt it",
+	}
+	if err := confirmEnvCategorization(cCtx, categorization); err != nil {
+		return nil, nil, err
+	}
+	return public, private, nil
+}
+
+// NewReleaseForImage assembles a release for an image that is already published. The
+// instance type is injected into the public env and the private env is encrypted for appID.
+func NewReleaseForImage(cCtx *cli.Context, environmentConfig *common.EnvironmentConfig, appID gethcommon.Address, digest [32]byte, registry string, publicEnv, privateEnv map[string]string, instanceType string) (appcontrollerV2.IAppControllerRelease, error) {
+	logger := common.LoggerFromContext(cCtx)
+
+	// Keep private values out of --log-file output
+	for _, value := range privateEnv {
+		commonlogger.RegisterSecret(value)
 	}
 
 	// Inject instance type selection into public environment variables
@@ -90,39 +199,44 @@ func PrepareReleaseFromContext(cCtx *cli.Context, environmentConfig *common.Envi
 
 	publicEnvBytes, err := json.Marshal(publicEnv)
 	if err != nil {
-		return appcontrollerV2.IAppControllerRelease{}, imageRef, fmt.Errorf("failed to marshal public env: %w", err)
+		return appcontrollerV2.IAppControllerRelease{}, fmt.Errorf("failed to marshal public env: %w", err)
 	}
 	privateEnvBytes, err := json.Marshal(privateEnv)
 	if err != nil {
-		return appcontrollerV2.IAppControllerRelease{}, imageRef, fmt.Errorf("failed to marshal private env: %w", err)
+		return appcontrollerV2.IAppControllerRelease{}, fmt.Errorf("failed to marshal private env: %w", err)
+	}
+	if err := CheckEnvSize(cCtx, publicEnv, privateEnv, len(publicEnvBytes)+len(privateEnvBytes)); err != nil {
+		return appcontrollerV2.IAppControllerRelease{}, err
 	}
 
+	ReportStage(cCtx, StageEncrypt, 0, "Encrypting private environment")
 	encryptionKey, _, err := getKMSKeysForEnvironment(environmentConfig.Name)
 	if err != nil {
-		return appcontrollerV2.IAppControllerRelease{}, imageRef, fmt.Errorf("failed to get encryption key: %w", err)
+		return appcontrollerV2.IAppControllerRelease{}, fmt.Errorf("failed to get encryption key: %w", err)
 	}
 
 	protectedHeaders := kmscrypto.GetAppProtectedHeaders(appID.Hex())
 	encryptedEnvStr, err := kmscrypto.EncryptRSAOAEPAndAES256GCMWithPEM(encryptionKey, privateEnvBytes, protectedHeaders)
 	if err != nil {
-		return appcontrollerV2.IAppControllerRelease{}, imageRef, fmt.Errorf("failed to encrypt env: %w", err)
+		return appcontrollerV2.IAppControllerRelease{}, fmt.Errorf("failed to encrypt env: %w", err)
 	}
+	ReportStage(cCtx, StageEncrypt, 100, "Private environment encrypted")
 
 	release := appcontrollerV2.IAppControllerRelease{
 		RmsRelease: appcontrollerV2.IReleaseManagerTypesRelease{
 			Artifacts: []appcontrollerV2.IReleaseManagerTypesArtifact{
 				{
 					Digest:   digest,
-					Registry: name,
+					Registry: registry,
 				},
 			},
-			UpgradeByTime: uint32(time.Now().Unix() + 3600),
+			UpgradeByTime: common.NewUpgradeByTime(time.Now()),
 		},
 		PublicEnv:    publicEnvBytes,
 		EncryptedEnv: []byte(encryptedEnvStr),
 	}
 
-	return release, imageRef, nil
+	return release, nil
 }
 
 // retryImagePushOperation wraps an image push operation with retry logic for permission errors
@@ -145,6 +259,21 @@ func retryImagePushOperation(
 			break
 		}
 
+		// An expired token is fixed by logging in again, not by choosing another registry
+		var pushErr *PushPermissionError
+		if attempt < maxRetries && errors.As(err, &pushErr) && pushErr.TokenExpired() {
+			fmt.Println()
+			logger.Warn("Push failed during %s because the registry rejected your credentials as expired.", operationName)
+			if imageRegistry(imageRef) == "ghcr.io" {
+				logger.Info("Create a new GitHub personal access token with the 'write:packages' scope and log in with it.")
+			}
+			if !waitForRegistryReauth(cCtx, imageRef, attempt) {
+				break
+			}
+			logger.Info("Retrying with the same image reference: %s\n", imageRef)
+			continue
+		}
+
 		// Permission error detected - offer to retry with different registry
 		if attempt < maxRetries {
 			fmt.Println()
@@ -155,6 +284,15 @@ func retryImagePushOperation(
 			logger.Info("  • The registry requires additional authentication steps")
 			fmt.Println()
 
+			// Retry the same target once the user has re-authenticated out of band
+			if cCtx.Bool(common.RegistryRetrySameFlag.Name) {
+				if !waitForRegistryReauth(cCtx, imageRef, attempt) {
+					break
+				}
+				logger.Info("Retrying with the same image reference: %s\n", imageRef)
+				continue
+			}
+
 			// Ask if they want to try a different registry
 			retry, retryErr := output.Confirm("Would you like to try a different registry?")
 			if retryErr != nil || !retry {
@@ -178,6 +316,38 @@ func retryImagePushOperation(
 	return imageRef, err
 }
 
+// imageRegistry returns the registry of imageRef, e.g. "ghcr.io", or "" if it cannot be parsed
+func imageRegistry(imageRef string) string {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return ""
+	}
+	return ref.Context().RegistryStr()
+}
+
+// waitForRegistryReauth gives the user a chance to re-authenticate with the registry of
+// imageRef before retrying. Interactively it waits for confirmation; with --force it waits
+// a growing delay instead. It returns false if the user gives up.
+func waitForRegistryReauth(cCtx *cli.Context, imageRef string, attempt int) bool {
+	logger := common.LoggerFromContext(cCtx)
+
+	registry := imageRegistry(imageRef)
+	if registry == "" {
+		registry = "<registry>"
+	}
+	logger.Info("Re-authenticate in another terminal, e.g. 'docker login %s'", registry)
+
+	if cCtx.Bool(common.ForceFlag.Name) {
+		wait := time.Duration(RegistryRetryWaitSeconds*attempt) * time.Second
+		logger.Info("Retrying in %s...", wait)
+		time.Sleep(wait)
+		return true
+	}
+
+	retry, err := output.ConfirmWithDefault("Retry pushing to the same registry?", true)
+	return err == nil && retry
+}
+
 func layerRemoteImageIfNeeded(cCtx *cli.Context, environmentConfig common.EnvironmentConfig, imageRef, logRedirect, envFilePath string) (string, error) {
 	// Check if the provided image is missing image layering, which is required for EigenX
 	dockerClient, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
@@ -186,7 +356,7 @@ func layerRemoteImageIfNeeded(cCtx *cli.Context, environmentConfig common.Enviro
 	}
 	defer dockerClient.Close()
 
-	alreadyLayered, err := checkIfImageAlreadyLayeredForEigenX(dockerClient, cCtx.Context, imageRef)
+	alreadyLayered, err := checkIfImageAlreadyLayeredForEigenX(dockerClient, cCtx.Context, imageRef, DigestResolverFromContext(cCtx))
 	if err != nil {
 		return "", fmt.Errorf("failed to check if image needs layering: %w", err)
 	}
@@ -200,21 +370,74 @@ func layerRemoteImageIfNeeded(cCtx *cli.Context, environmentConfig common.Enviro
 			return "", fmt.Errorf("failed to get target image reference: %w", err)
 		}
 
+		ctx, cancel := withBuildTimeout(cCtx)
+		defer cancel()
+
 		logger.Info("Adding EigenX components to create %s from %s...", targetImageRef, imageRef)
-		layeredImageRef, err := layerLocalImage(cCtx, dockerClient, environmentConfig, imageRef, targetImageRef, logRedirect, envFilePath)
+		layeredImageRef, err := layerLocalImage(cCtx, ctx, dockerClient, environmentConfig, imageRef, targetImageRef, logRedirect, envFilePath, "")
 		if err != nil {
-			return "", fmt.Errorf("failed to layer published image: %w", err)
+			return "", annotateBuildTimeout(cCtx, fmt.Errorf("failed to layer published image: %w", err))
 		}
 		imageRef = layeredImageRef
 
 		// Wait for registry propagation
-		logger.Info("Waiting %d seconds for registry propagation...", RegistryPropagationWaitSeconds)
-		time.Sleep(RegistryPropagationWaitSeconds * time.Second)
+		waitForRegistryPropagation(cCtx, imageRef)
 	}
 
 	return imageRef, nil
 }
 
+// waitForRegistryPropagation waits until a freshly pushed image resolves in the registry. The
+// wait is bounded by --propagation-wait (default RegistryPropagationWaitSeconds, 0 disables it).
+func waitForRegistryPropagation(cCtx *cli.Context, imageRef string) {
+	logger := common.LoggerFromContext(cCtx)
+
+	wait := propagationWait(cCtx)
+	if wait <= 0 {
+		logger.Debug("Skipping registry propagation wait")
+		return
+	}
+
+	ReportStage(cCtx, StagePropagate, 0, "Waiting for registry propagation")
+	defer ReportStage(cCtx, StagePropagate, 100, "Registry propagation complete")
+
+	logger.Info("Waiting up to %s for %s to resolve in the registry...", wait, imageRef)
+	deadline := time.Now().Add(wait)
+	for {
+		_, _, err := getImageDigestAndName(cCtx.Context, DigestResolverFromContext(cCtx), imageRef)
+		var mismatchErr *PlatformMismatchError
+		if err == nil || errors.As(err, &mismatchErr) {
+			// The image is resolvable; platform problems are reported by the caller
+			return
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 || cCtx.Context.Err() != nil {
+			logger.Warn("%s did not resolve within %s: %v", imageRef, wait, err)
+			return
+		}
+		sleepWithContext(cCtx.Context, min(RegistryPropagationPollInterval, remaining))
+	}
+}
+
+// propagationWait returns the registry propagation wait selected by --propagation-wait
+func propagationWait(cCtx *cli.Context) time.Duration {
+	if cCtx.IsSet(common.PropagationWaitFlag.Name) {
+		return cCtx.Duration(common.PropagationWaitFlag.Name)
+	}
+	return RegistryPropagationWaitSeconds * time.Second
+}
+
+// sleepWithContext sleeps for d or until ctx is cancelled
+func sleepWithContext(ctx context.Context, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
 // ============================================================================
 // Image Registry Operations
 // ============================================================================
@@ -230,9 +453,61 @@ func (p Platform) String() string {
 	return fmt.Sprintf("%s/%s", p.OS, p.Arch)
 }
 
-// IsLinuxAMD64 checks if platform matches required linux/amd64
-func (p Platform) IsLinuxAMD64() bool {
-	return p.OS == LinuxOS && p.Arch == AMD64Arch
+// Matches checks if platform is the target platform
+func (p Platform) Matches(target Platform) bool {
+	return p.OS == target.OS && p.Arch == target.Arch
+}
+
+// TEEPlatform is the platform TEE instances run, which images target by default
+var TEEPlatform = Platform{OS: LinuxOS, Arch: AMD64Arch}
+
+// supportedPlatforms lists the platforms --platform accepts. Platforms other than TEEPlatform
+// are accepted with a warning until TEE instances, the KMS client and the TLS tools support them.
+var supportedPlatforms = []Platform{
+	TEEPlatform,
+	{OS: LinuxOS, Arch: ARM64Arch},
+}
+
+// ParsePlatform parses an os/arch platform string and checks it is one of supportedPlatforms
+func ParsePlatform(value string) (Platform, error) {
+	osName, arch, _ := strings.Cut(strings.ToLower(strings.TrimSpace(value)), "/")
+	platform := Platform{OS: osName, Arch: arch}
+	if !slices.Contains(supportedPlatforms, platform) {
+		names := make([]string, len(supportedPlatforms))
+		for i, p := range supportedPlatforms {
+			names[i] = p.String()
+		}
+		return Platform{}, fmt.Errorf("unsupported platform %q: must be one of %s", value, strings.Join(names, ", "))
+	}
+	return platform, nil
+}
+
+type targetPlatformContextKey struct{}
+
+// WithTargetPlatform stores the platform images are built, pulled and resolved for
+func WithTargetPlatform(ctx context.Context, platform Platform) context.Context {
+	return context.WithValue(ctx, targetPlatformContextKey{}, platform)
+}
+
+// TargetPlatform returns the platform stored with WithTargetPlatform, or TEEPlatform
+func TargetPlatform(ctx context.Context) Platform {
+	if platform, ok := ctx.Value(targetPlatformContextKey{}).(Platform); ok {
+		return platform
+	}
+	return TEEPlatform
+}
+
+// ApplyPlatformFlag validates --platform and makes it the target platform for this command
+func ApplyPlatformFlag(cCtx *cli.Context) error {
+	platform, err := ParsePlatform(cCtx.String(common.PlatformFlag.Name))
+	if err != nil {
+		return fmt.Errorf("invalid --%s: %w", common.PlatformFlag.Name, err)
+	}
+	if platform != TEEPlatform {
+		common.LoggerFromContext(cCtx).Warn("Targeting %s is experimental: TEE instances and the bundled KMS client and TLS tools only support %s so far, so the app may not start until %s support lands", platform, TEEPlatform, platform)
+	}
+	cCtx.Context = WithTargetPlatform(cCtx.Context, platform)
+	return nil
 }
 
 // imageDigestResult holds the result of image digest extraction
@@ -242,8 +517,8 @@ type imageDigestResult struct {
 	platforms []Platform
 }
 
-// extractDigestFromMultiPlatform extracts digest from multi-platform image index
-func extractDigestFromMultiPlatform(idx v1.ImageIndex, ref name.Reference) (*imageDigestResult, error) {
+// extractDigestFromMultiPlatform extracts the target platform's digest from a multi-platform image index
+func extractDigestFromMultiPlatform(idx v1.ImageIndex, ref name.Reference, target Platform) (*imageDigestResult, error) {
 	manifest, err := idx.IndexManifest()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get image manifest: %w", err)
@@ -255,7 +530,7 @@ func extractDigestFromMultiPlatform(idx v1.ImageIndex, ref name.Reference) (*ima
 			platform := Platform{OS: m.Platform.OS, Arch: m.Platform.Architecture}
 			platforms = append(platforms, platform)
 
-			if platform.IsLinuxAMD64() {
+			if platform.Matches(target) {
 				digest, err := hexStringToBytes32(m.Digest.Hex)
 				if err != nil {
 					return nil, fmt.Errorf("failed to decode digest %s: %w", m.Digest.Hex, err)
@@ -272,8 +547,8 @@ func extractDigestFromMultiPlatform(idx v1.ImageIndex, ref name.Reference) (*ima
 	return &imageDigestResult{platforms: platforms}, nil
 }
 
-// extractDigestFromSinglePlatform extracts digest from single-platform image
-func extractDigestFromSinglePlatform(img v1.Image, ref name.Reference) (*imageDigestResult, error) {
+// extractDigestFromSinglePlatform extracts digest from a single-platform image built for target
+func extractDigestFromSinglePlatform(img v1.Image, ref name.Reference, target Platform) (*imageDigestResult, error) {
 	config, err := img.ConfigFile()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get image config: %w", err)
@@ -282,7 +557,7 @@ func extractDigestFromSinglePlatform(img v1.Image, ref name.Reference) (*imageDi
 	platform := Platform{OS: config.OS, Arch: config.Architecture}
 	platforms := []Platform{platform}
 
-	if platform.IsLinuxAMD64() {
+	if platform.Matches(target) {
 		digestHash, err := img.Digest()
 		if err != nil {
 			return nil, fmt.Errorf("failed to get image digest: %w", err)
@@ -301,82 +576,167 @@ func extractDigestFromSinglePlatform(img v1.Image, ref name.Reference) (*imageDi
 	return &imageDigestResult{platforms: platforms}, nil
 }
 
+// PlatformMismatchError indicates an image has no variant for the target platform
+type PlatformMismatchError struct {
+	ImageRef  string
+	Platforms []Platform
+	Target    Platform
+}
+
+func (e *PlatformMismatchError) Error() string {
+	return createPlatformErrorMessage(e.ImageRef, e.Platforms, e.Target).Error()
+}
+
+// rebuildablePlatformMismatch returns the PlatformMismatchError in err when
+// --fail-on-platform-mismatch=false allows rebuilding the image instead of failing
+func rebuildablePlatformMismatch(cCtx *cli.Context, err error) *PlatformMismatchError {
+	var mismatchErr *PlatformMismatchError
+	if errors.As(err, &mismatchErr) && !cCtx.Bool(common.FailOnPlatformMismatchFlag.Name) {
+		return mismatchErr
+	}
+	return nil
+}
+
+// rebuildForPlatformMismatch rebuilds and pushes the image for the target platform from a local
+// Dockerfile when the referenced image has no compatible platform. The Dockerfile is dockerfilePath,
+// --dockerfile or ./Dockerfile, in that order. If none exists the original mismatch error,
+// including its remediation steps, is returned. The rebuild is pushed to the reference derived by
+// platformRebuildTarget, so the published source tag is never overwritten.
+func rebuildForPlatformMismatch(cCtx *cli.Context, environmentConfig *common.EnvironmentConfig, dockerfilePath, imageRef, envFilePath, logRedirect string, maxPushRetries int, mismatchErr *PlatformMismatchError) ([32]byte, string, string, error) {
+	logger := common.LoggerFromContext(cCtx)
+	platform := TargetPlatform(cCtx.Context).String()
+
+	if dockerfilePath == "" {
+		dockerfilePath = cCtx.String(common.FileFlag.Name)
+	}
+	if dockerfilePath == "" {
+		dockerfilePath = "Dockerfile"
+	}
+	if _, err := os.Stat(dockerfilePath); err != nil {
+		logger.Warn("No Dockerfile available to rebuild %s for %s", imageRef, platform)
+		return [32]byte{}, "", imageRef, mismatchErr
+	}
+
+	targetImageRef, err := platformRebuildTarget(imageRef)
+	if err != nil {
+		return [32]byte{}, "", imageRef, err
+	}
+	logger.Warn("Image %s is not available for %s, rebuilding from %s as %s...", imageRef, platform, dockerfilePath, targetImageRef)
+
+	if err := CheckBuildContextSize(cCtx, ".", dockerfilePath); err != nil {
+		return [32]byte{}, "", imageRef, err
+	}
+	rebuild := func(ref string) (string, error) {
+		return buildAndPushLayeredImage(cCtx, *environmentConfig, ".", dockerfilePath, ref, logRedirect, envFilePath)
+	}
+	imageRef, err = retryImagePushOperation(cCtx, maxPushRetries, "rebuild for "+platform, rebuild, targetImageRef)
+	if err != nil {
+		return [32]byte{}, "", imageRef, fmt.Errorf("failed to rebuild image for %s: %w", platform, err)
+	}
+
+	// Wait for registry propagation
+	waitForRegistryPropagation(cCtx, imageRef)
+
+	digest, name, err := getImageDigestAndName(cCtx.Context, DigestResolverFromContext(cCtx), imageRef)
+	return digest, name, imageRef, err
+}
+
+// platformRebuildTarget derives the reference a platform rebuild of imageRef is pushed to: the
+// same repository with "-eigenx" appended to the tag, as for layered published images. A digest
+// reference has no tag, so its short digest is used instead.
+func platformRebuildTarget(imageRef string) (string, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return "", fmt.Errorf("invalid image reference %s: %w", imageRef, err)
+	}
+	tag := ref.Identifier()
+	if digest, ok := ref.(name.Digest); ok {
+		tag = strings.TrimPrefix(digest.DigestStr(), "sha256:")[:12]
+	}
+	return fmt.Sprintf("%s:%s-eigenx", ref.Context().Name(), tag), nil
+}
+
 // createPlatformErrorMessage creates a detailed error message for platform mismatch
-func createPlatformErrorMessage(imageRef string, platforms []Platform) error {
+func createPlatformErrorMessage(imageRef string, platforms []Platform, target Platform) error {
 	platformStrs := make([]string, len(platforms))
 	for i, p := range platforms {
 		platformStrs[i] = p.String()
 	}
 
-	errorMsg := fmt.Sprintf(`EigenX requires linux/amd64 images for TEE deployment.
+	errorMsg := fmt.Sprintf(`EigenX requires %[1]s images for TEE deployment.
 
-Image: %s
-Found platform(s): %s
-Required platform: linux/amd64
+Image: %[2]s
+Found platform(s): %[3]s
+Required platform: %[1]s
 
 To fix this issue:
 1. Manual fix:
    a. Rebuild your image with the correct platform:
-      docker build --platform linux/amd64 -t %s .
+      docker build --platform %[1]s -t %[2]s .
    b. Push the rebuilt image to your remote registry:
-      docker push %s
+      docker push %[2]s
 
 2. Or use eigenx to build with the correct platform automatically:
    eigenx app deploy --dockerfile /path/to/Dockerfile
 
    (Or run 'eigenx app deploy' from the directory containing your Dockerfile)
 
-The --platform linux/amd64 flag ensures your image works in EigenX's TEE environment.`,
-		imageRef,
-		strings.Join(platformStrs, ", "),
+The --platform %[1]s flag ensures your image works in EigenX's TEE environment.`,
+		target,
 		imageRef,
-		imageRef)
+		strings.Join(platformStrs, ", "))
 
 	return fmt.Errorf("%s", errorMsg)
 }
 
-func getImageDigestAndName(ctx context.Context, imageRef string) ([32]byte, string, error) {
-	ref, err := name.ParseReference(imageRef)
-	if err != nil {
-		return [32]byte{}, "", fmt.Errorf("failed to parse image reference %s: %w", imageRef, err)
+// ValidateRegistryCACert checks that the --registry-ca-cert, if set, contains at least one PEM certificate
+func ValidateRegistryCACert(cCtx *cli.Context) error {
+	path := cCtx.String(common.RegistryCACertFlag.Name)
+	if path == "" {
+		return nil
+	}
+	if _, err := loadRegistryCAPool(path); err != nil {
+		return fmt.Errorf("invalid --%s: %w", common.RegistryCACertFlag.Name, err)
 	}
+	return nil
+}
 
-	desc, err := remote.Get(ref, remote.WithContext(ctx))
+// loadRegistryCAPool returns the system CA pool extended with the certificates in caCertPath
+func loadRegistryCAPool(caCertPath string) (*x509.CertPool, error) {
+	pemData, err := os.ReadFile(caCertPath)
 	if err != nil {
-		return [32]byte{}, "", fmt.Errorf("failed to get image %s: %w", imageRef, err)
+		return nil, fmt.Errorf("failed to read CA certificate file: %w", err)
 	}
 
-	var result *imageDigestResult
-
-	if desc.MediaType.IsIndex() {
-		idx, err := desc.ImageIndex()
-		if err != nil {
-			return [32]byte{}, "", fmt.Errorf("failed to get image index %s: %w", imageRef, err)
-		}
-
-		result, err = extractDigestFromMultiPlatform(idx, ref)
-		if err != nil {
-			return [32]byte{}, "", fmt.Errorf("failed to process multi-platform image %s: %w", imageRef, err)
-		}
-	} else {
-		img, err := desc.Image()
-		if err != nil {
-			return [32]byte{}, "", fmt.Errorf("failed to get image %s: %w", imageRef, err)
-		}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pemData) {
+		return nil, fmt.Errorf("no PEM certificates found in %s", caCertPath)
+	}
+	return pool, nil
+}
 
-		result, err = extractDigestFromSinglePlatform(img, ref)
-		if err != nil {
-			return [32]byte{}, "", fmt.Errorf("failed to process single-platform image %s: %w", imageRef, err)
-		}
+// registryRemoteOptions returns the options for registry requests, trusting the
+// certificates in caCertPath when it is set
+func registryRemoteOptions(ctx context.Context, caCertPath string) ([]remote.Option, error) {
+	opts := []remote.Option{remote.WithContext(ctx)}
+	if caCertPath == "" {
+		return opts, nil
 	}
 
-	// If we found a compatible platform, return success
-	if result.name != "" {
-		return result.digest, result.name, nil
+	pool, err := loadRegistryCAPool(caCertPath)
+	if err != nil {
+		return nil, err
 	}
 
-	// No compatible platform found, return helpful error
-	return [32]byte{}, "", createPlatformErrorMessage(imageRef, result.platforms)
+	transport := remote.DefaultTransport.(*http.Transport).Clone()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	transport.TLSClientConfig.RootCAs = pool
+	return append(opts, remote.WithTransport(transport)), nil
 }
 
 func hexStringToBytes32(hexStr string) ([32]byte, error) {
@@ -405,23 +765,20 @@ func hexStringToBytes32(hexStr string) ([32]byte, error) {
 // ============================================================================
 
 func parseAndValidateEnvFile(cCtx *cli.Context, envFilePath string) (kmstypes.Env, kmstypes.Env, error) {
-	logger := common.LoggerFromContext(cCtx)
+	envVars, err := readEnvFile(envFilePath)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	file, err := os.Open(envFilePath)
+	prefix, err := envPrefix(cCtx)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to open env file %s: %w", envFilePath, err)
+		return nil, nil, err
 	}
-	defer file.Close()
 
 	publicEnv := kmstypes.Env{}
 	privateEnv := kmstypes.Env{}
 	mnemonicFiltered := false
 
-	envVars, err := envparse.Parse(file)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to parse env file %s: %w", envFilePath, err)
-	}
-
 	for varName, value := range envVars {
 		// Filter out mnemonic variables
 		if strings.ToUpper(varName) == common.MnemonicEnvVar {
@@ -429,14 +786,158 @@ func parseAndValidateEnvFile(cCtx *cli.Context, envFilePath string) (kmstypes.En
 			continue
 		}
 
+		// Categorize by the original name, then namespace it
 		if strings.HasSuffix(varName, "_PUBLIC") {
-			publicEnv[varName] = value
+			publicEnv[prefix+varName] = value
 		} else {
-			privateEnv[varName] = value
+			privateEnv[prefix+varName] = value
 		}
 	}
 
-	logger.Info("Your container will deploy with the following environment variables:")
+	categorization := envCategorization{
+		publicEnv:        publicEnv,
+		privateEnv:       privateEnv,
+		mnemonicFiltered: mnemonicFiltered,
+		source:           envFilePath,
+		makePrivateHint:  "drop the _PUBLIC suffix to encrypt it",
+	}
+	if err := confirmEnvCategorization(cCtx, categorization); err != nil {
+		return nil, nil, err
+	}
+	return publicEnv, privateEnv, nil
+}
+
+// HasExplicitEnvFiles reports whether --public-env-file or --private-env-file was given
+func HasExplicitEnvFiles(cCtx *cli.Context) bool {
+	return cCtx.String(common.PublicEnvFileFlag.Name) != "" || cCtx.String(common.PrivateEnvFileFlag.Name) != ""
+}
+
+// ValidateEnvFileFlags rejects combining --env-file with the explicit public/private env files
+func ValidateEnvFileFlags(cCtx *cli.Context) error {
+	if HasExplicitEnvFiles(cCtx) && cCtx.IsSet(common.EnvFlag.Name) {
+		return fmt.Errorf("--%s cannot be combined with --%s or --%s", common.EnvFlag.Name, common.PublicEnvFileFlag.Name, common.PrivateEnvFileFlag.Name)
+	}
+	return nil
+}
+
+// parseAndValidateExplicitEnvFiles reads public and private variables from separate files.
+// Variables are categorized by the file they come from, regardless of any _PUBLIC suffix.
+// Either path may be empty.
+func parseAndValidateExplicitEnvFiles(cCtx *cli.Context, publicEnvFilePath, privateEnvFilePath string) (kmstypes.Env, kmstypes.Env, error) {
+	prefix, err := envPrefix(cCtx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	publicEnv := kmstypes.Env{}
+	privateEnv := kmstypes.Env{}
+	mnemonicFiltered := false
+
+	for _, file := range []struct {
+		path string
+		env  kmstypes.Env
+	}{
+		{publicEnvFilePath, publicEnv},
+		{privateEnvFilePath, privateEnv},
+	} {
+		if file.path == "" {
+			continue
+		}
+		envVars, err := readEnvFile(file.path)
+		if err != nil {
+			return nil, nil, err
+		}
+		for varName, value := range envVars {
+			if strings.ToUpper(varName) == common.MnemonicEnvVar {
+				mnemonicFiltered = true
+				continue
+			}
+			file.env[prefix+varName] = value
+		}
+	}
+
+	for varName := range publicEnv {
+		if _, ok := privateEnv[varName]; ok {
+			return nil, nil, fmt.Errorf("%s is defined in both %s and %s", varName, publicEnvFilePath, privateEnvFilePath)
+		}
+	}
+
+	var sources []string
+	if publicEnvFilePath != "" {
+		sources = append(sources, fmt.Sprintf("public: %s", publicEnvFilePath))
+	}
+	if privateEnvFilePath != "" {
+		sources = append(sources, fmt.Sprintf("private: %s", privateEnvFilePath))
+	}
+
+	categorization := envCategorization{
+		publicEnv:        publicEnv,
+		privateEnv:       privateEnv,
+		mnemonicFiltered: mnemonicFiltered,
+		source:           strings.Join(sources, ", "),
+		makePrivateHint:  "move it to the private env file to encrypt it",
+	}
+	if err := confirmEnvCategorization(cCtx, categorization); err != nil {
+		return nil, nil, err
+	}
+	return publicEnv, privateEnv, nil
+}
+
+// envPrefixPattern matches prefixes that keep prefixed names valid env variable names
+var envPrefixPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// envPrefix returns the --env-prefix value added to every user-provided variable name
+func envPrefix(cCtx *cli.Context) (string, error) {
+	prefix := cCtx.String(common.EnvPrefixFlag.Name)
+	if prefix != "" && !envPrefixPattern.MatchString(prefix) {
+		return "", fmt.Errorf("invalid --%s %q: use letters, digits and underscores, not starting with a digit", common.EnvPrefixFlag.Name, prefix)
+	}
+	return prefix, nil
+}
+
+// maskEnvValue hides a private value, keeping only whether it is set
+func maskEnvValue(value string) string {
+	if value == "" {
+		return ""
+	}
+	return "********"
+}
+
+// readEnvFile parses a single env file
+func readEnvFile(envFilePath string) (map[string]string, error) {
+	file, err := os.Open(envFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open env file %s: %w", envFilePath, err)
+	}
+	defer file.Close()
+
+	envVars, err := envparse.Parse(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse env file %s: %w", envFilePath, err)
+	}
+	return envVars, nil
+}
+
+// envCategorization is a public/private split of env variables awaiting user confirmation
+type envCategorization struct {
+	publicEnv        kmstypes.Env
+	privateEnv       kmstypes.Env
+	mnemonicFiltered bool
+	// source describes where the variables were read from, for messages
+	source string
+	// makePrivateHint tells the user how to make a public variable private
+	makePrivateHint string
+}
+
+// confirmEnvCategorization prints the split, warns about reserved and secret-looking
+// variables and asks the user to confirm. Private values are masked unless --show-values
+// is set. With --dry-run-env nothing is asked.
+func confirmEnvCategorization(cCtx *cli.Context, c envCategorization) error {
+	logger := common.LoggerFromContext(cCtx)
+	dryRun := cCtx.Bool(common.DryRunEnvFlag.Name)
+	showValues := cCtx.Bool(common.ShowValuesFlag.Name)
+
+	logger.Info("Your container will deploy with the following environment variables (%s):", c.source)
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 	defer w.Flush()
@@ -444,18 +945,18 @@ func parseAndValidateEnvFile(cCtx *cli.Context, envFilePath string) (kmstypes.En
 	fmt.Fprintf(w, "\n")
 
 	// Print filtered mnemonic variables
-	if mnemonicFiltered {
-		fmt.Fprintf(w, "\033[3;36mMnemonic environment variable removed to be overridden by protocol provided mnemonic\033[0m\n")
+	if c.mnemonicFiltered {
+		fmt.Fprintf(w, "%s\n", common.Style("Mnemonic environment variable removed to be overridden by protocol provided mnemonic", common.StyleItalic, common.StyleCyan))
 		fmt.Fprintf(w, "\n")
 	}
 
 	// Print public variables
-	if len(publicEnv) != 0 {
+	if len(c.publicEnv) != 0 {
 		fmt.Fprintf(w, "PUBLIC VARIABLE\tVALUE\n")
 		fmt.Fprintf(w, "---------------\t-----\n")
 
-		for k, v := range publicEnv {
-			fmt.Fprintf(w, "%s\t%s\n", k, v)
+		for _, k := range sortedEnvKeys(c.publicEnv) {
+			fmt.Fprintf(w, "%s\t%s\n", k, c.publicEnv[k])
 		}
 	} else {
 		fmt.Fprintf(w, "No public variables found\n")
@@ -465,11 +966,15 @@ func parseAndValidateEnvFile(cCtx *cli.Context, envFilePath string) (kmstypes.En
 	fmt.Fprintf(w, "\n")
 
 	// Print private variables
-	if len(privateEnv) != 0 {
+	if len(c.privateEnv) != 0 {
 		fmt.Fprintf(w, "PRIVATE VARIABLE\tVALUE\n")
 		fmt.Fprintf(w, "----------------\t-----\n")
 
-		for k, v := range privateEnv {
+		for _, k := range sortedEnvKeys(c.privateEnv) {
+			v := c.privateEnv[k]
+			if !showValues {
+				v = maskEnvValue(v)
+			}
 			fmt.Fprintf(w, "%s\t%s\n", k, v)
 		}
 	} else {
@@ -479,13 +984,46 @@ func parseAndValidateEnvFile(cCtx *cli.Context, envFilePath string) (kmstypes.En
 
 	w.Flush()
 
+	// Warn about protocol-reserved variables instead of silently overriding them
+	var reservedNames []string
+	for _, env := range []kmstypes.Env{c.publicEnv, c.privateEnv} {
+		for varName := range env {
+			if _, reserved := common.ReservedEnvVarReason(varName); reserved {
+				reservedNames = append(reservedNames, varName)
+			}
+		}
+	}
+	sort.Strings(reservedNames)
+	for _, varName := range reservedNames {
+		reason, _ := common.ReservedEnvVarReason(varName)
+		logger.Warn("%s is reserved by the protocol (%s); the value in %s will be overridden", varName, reason, c.source)
+	}
+	if len(reservedNames) > 0 {
+		fmt.Println()
+	}
+
+	if cCtx.Bool(common.EnvCheckEntropyFlag.Name) {
+		threshold := cCtx.Float64(common.EnvEntropyThresholdFlag.Name)
+		suspicious := findHighEntropyValues(c.publicEnv, threshold)
+		for _, v := range suspicious {
+			logger.Warn("%s looks like a secret (entropy %.2f bits/char >= %.2f). Public variables are stored in plaintext onchain; %s.", v.Name, v.Entropy, threshold, c.makePrivateHint)
+		}
+		if len(suspicious) > 0 {
+			fmt.Println()
+		}
+	}
+
+	if dryRun {
+		logger.Info("%s is added to the public variables with the selected instance type at deploy time", common.EigenMachineTypeEnvVar)
+		return nil
+	}
+
 	confirmed, err := output.ConfirmWithDefault("Is this categorization correct? Public variables will be in plaintext onchain. Private variables will be encrypted onchain.", false)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get confirmation: %w", err)
+		return fmt.Errorf("failed to get confirmation: %w", err)
 	}
 	if !confirmed {
-		return nil, nil, fmt.Errorf("user rejected variable categorization")
+		return fmt.Errorf("user rejected variable categorization")
 	}
-
-	return publicEnv, privateEnv, nil
+	return nil
 }
@@ -0,0 +1,180 @@
+package utils
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"flag"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+// writeTestCACert writes a self-signed CA certificate in PEM format and returns its path
+func writeTestCACert(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Registry CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	return path
+}
+
+func TestLoadRegistryCAPool(t *testing.T) {
+	pool, err := loadRegistryCAPool(writeTestCACert(t))
+	require.NoError(t, err)
+	assert.NotNil(t, pool)
+
+	_, err = loadRegistryCAPool(filepath.Join(t.TempDir(), "missing.pem"))
+	assert.Error(t, err)
+
+	notPEM := filepath.Join(t.TempDir(), "not.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0600))
+	_, err = loadRegistryCAPool(notPEM)
+	assert.ErrorContains(t, err, "no PEM certificates")
+}
+
+func TestRegistryRemoteOptions(t *testing.T) {
+	opts, err := registryRemoteOptions(context.Background(), "")
+	require.NoError(t, err)
+	assert.Len(t, opts, 1, "default options only carry the context")
+
+	opts, err = registryRemoteOptions(context.Background(), writeTestCACert(t))
+	require.NoError(t, err)
+	assert.Len(t, opts, 2, "a CA certificate adds a custom transport")
+}
+
+func TestPropagationWait(t *testing.T) {
+	newContext := func(args ...string) *cli.Context {
+		set := flag.NewFlagSet("test", flag.ContinueOnError)
+		require.NoError(t, common.PropagationWaitFlag.Apply(set))
+		require.NoError(t, set.Parse(args))
+		return cli.NewContext(&cli.App{}, set, nil)
+	}
+
+	assert.Equal(t, RegistryPropagationWaitSeconds*time.Second, propagationWait(newContext()))
+	assert.Equal(t, 10*time.Second, propagationWait(newContext("--propagation-wait", "10s")))
+	assert.Equal(t, time.Duration(0), propagationWait(newContext("--propagation-wait", "0")))
+}
+
+func TestParseAndValidateEnvFileDryRun(t *testing.T) {
+	envFile := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(envFile, []byte("API_KEY=secret\nAPI_URL_PUBLIC=https://example.com\nMNEMONIC=words\n"), 0644))
+
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	require.NoError(t, common.DryRunEnvFlag.Apply(set))
+	require.NoError(t, set.Parse([]string{"--dry-run-env"}))
+	cCtx := cli.NewContext(&cli.App{}, set, nil)
+
+	// No confirmation is asked for in a dry run
+	publicEnv, privateEnv, err := parseAndValidateEnvFile(cCtx, envFile)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"API_URL_PUBLIC": "https://example.com"}, map[string]string(publicEnv))
+	assert.Equal(t, map[string]string{"API_KEY": "secret"}, map[string]string(privateEnv))
+}
+
+func TestParseAndValidateEnvFileWithPrefix(t *testing.T) {
+	envFile := filepath.Join(t.TempDir(), ".env")
+	require.NoError(t, os.WriteFile(envFile, []byte("DATABASE_URL=postgres://db\nAPI_URL_PUBLIC=https://example.com\nMNEMONIC=words\n"), 0644))
+
+	newContext := func(prefix string) *cli.Context {
+		set := flag.NewFlagSet("test", flag.ContinueOnError)
+		require.NoError(t, common.DryRunEnvFlag.Apply(set))
+		require.NoError(t, common.EnvPrefixFlag.Apply(set))
+		require.NoError(t, set.Parse([]string{"--dry-run-env", "--env-prefix", prefix}))
+		return cli.NewContext(&cli.App{}, set, nil)
+	}
+
+	publicEnv, privateEnv, err := parseAndValidateEnvFile(newContext("MYAPP_"), envFile)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"MYAPP_API_URL_PUBLIC": "https://example.com"}, map[string]string(publicEnv))
+	assert.Equal(t, map[string]string{"MYAPP_DATABASE_URL": "postgres://db"}, map[string]string(privateEnv))
+
+	_, _, err = parseAndValidateEnvFile(newContext("1BAD-"), envFile)
+	assert.Error(t, err)
+}
+
+func TestMaskEnvValue(t *testing.T) {
+	assert.Equal(t, "********", maskEnvValue("secret"))
+	assert.Equal(t, "********", maskEnvValue("a-much-longer-secret-value"))
+	assert.Equal(t, "", maskEnvValue(""))
+}
+
+func TestPrepareReleaseFromContextPlatformMismatch(t *testing.T) {
+	// The working directory has a Dockerfile, but --dockerfile names one that does not exist, so
+	// reaching the rebuild branch returns the mismatch error instead of building
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.WriteFile("Dockerfile", []byte("FROM scratch\n"), 0644))
+
+	newContext := func(args ...string) *cli.Context {
+		set := flag.NewFlagSet("test", flag.ContinueOnError)
+		for _, f := range []cli.Flag{common.FailOnPlatformMismatchFlag, common.FileFlag} {
+			require.NoError(t, f.Apply(set))
+		}
+		require.NoError(t, set.Parse(append(args, "--dockerfile", "missing.Dockerfile")))
+		cCtx := cli.NewContext(&cli.App{}, set, nil)
+		resolver := &fakeDigestResolver{platforms: []Platform{{OS: "linux", Arch: "arm64"}}}
+		cCtx.Context = WithDigestResolver(context.Background(), resolver)
+		return cCtx
+	}
+	prepare := func(cCtx *cli.Context) error {
+		_, _, _, err := PrepareReleaseFromContext(cCtx, &common.EnvironmentConfig{}, [20]byte{}, "", "user/app:latest", "", "", "", 1)
+		return err
+	}
+
+	t.Run("fails by default", func(t *testing.T) {
+		err := prepare(newContext())
+		var mismatchErr *PlatformMismatchError
+		require.ErrorAs(t, err, &mismatchErr)
+		assert.ErrorContains(t, err, "failed to ensure image compatibility")
+	})
+
+	t.Run("rebuilds from --dockerfile", func(t *testing.T) {
+		err := prepare(newContext("--fail-on-platform-mismatch=false"))
+		var mismatchErr *PlatformMismatchError
+		require.ErrorAs(t, err, &mismatchErr)
+		assert.Equal(t, "user/app:latest", mismatchErr.ImageRef)
+		assert.ErrorContains(t, err, "failed to get image digest and name")
+	})
+}
+
+func TestPlatformRebuildTarget(t *testing.T) {
+	target, err := platformRebuildTarget("ghcr.io/user/app:v1")
+	require.NoError(t, err)
+	assert.Equal(t, "ghcr.io/user/app:v1-eigenx", target)
+
+	target, err = platformRebuildTarget("ghcr.io/user/app")
+	require.NoError(t, err)
+	assert.Equal(t, "ghcr.io/user/app:latest-eigenx", target)
+
+	target, err = platformRebuildTarget("ghcr.io/user/app@sha256:" + strings.Repeat("ab", 32))
+	require.NoError(t, err)
+	assert.Equal(t, "ghcr.io/user/app:abababababab-eigenx", target)
+
+	_, err = platformRebuildTarget("Invalid Ref")
+	assert.Error(t, err)
+}
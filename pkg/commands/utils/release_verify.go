@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	appcontrollerV2 "github.com/Layr-Labs/eigenx-contracts/pkg/bindings/v2/AppController"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+// VerifyReleaseDigest checks, after a deploy or upgrade transaction, that the digest recorded
+// on-chain and the digest imageRef currently resolves to both match the digest that was submitted.
+// A mismatch means the registry served a different manifest between push and pin (a tag race)
+// or the release was changed by another transaction. Problems are logged, never returned:
+// the transaction has already been confirmed.
+func VerifyReleaseDigest(cCtx *cli.Context, caller *common.ContractCaller, appID gethcommon.Address, release appcontrollerV2.IAppControllerRelease, imageRef string) {
+	logger := common.LoggerFromContext(cCtx)
+
+	if len(release.RmsRelease.Artifacts) == 0 {
+		return
+	}
+	submitted := release.RmsRelease.Artifacts[0].Digest
+
+	deployed, err := GetDeployedRelease(cCtx.Context, caller, appID)
+	if err != nil {
+		logger.Warn("Could not verify the on-chain release digest: %v", err)
+	} else if deployed.Digest != submitted {
+		logDigestMismatch(cCtx, "the on-chain release", submitted, deployed.Digest)
+	}
+
+	fetched, err := fetchDigestWithRetry(cCtx.Context, ReleaseVerifyAttempts, RegistryPropagationPollInterval, func() ([32]byte, error) {
+		digest, _, err := getImageDigestAndName(cCtx.Context, DigestResolverFromContext(cCtx), imageRef)
+		return digest, err
+	})
+	if err != nil {
+		logger.Warn("Could not re-fetch the digest of %s to verify the release: %v", imageRef, err)
+		return
+	}
+	if fetched != submitted {
+		logDigestMismatch(cCtx, imageRef, submitted, fetched)
+		return
+	}
+
+	logger.Debug("Release digest verified: %s", hex.EncodeToString(submitted[:]))
+}
+
+// fetchDigestWithRetry calls fetch up to attempts times, waiting interval between failures
+func fetchDigestWithRetry(ctx context.Context, attempts int, interval time.Duration, fetch func() ([32]byte, error)) ([32]byte, error) {
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		var digest [32]byte
+		if digest, err = fetch(); err == nil {
+			return digest, nil
+		}
+		if attempt == attempts || ctx.Err() != nil {
+			return [32]byte{}, fmt.Errorf("failed after %d attempt(s): %w", attempt, err)
+		}
+		sleepWithContext(ctx, interval)
+	}
+	return [32]byte{}, err
+}
+
+func logDigestMismatch(cCtx *cli.Context, source string, submitted, actual [32]byte) {
+	logger := common.LoggerFromContext(cCtx)
+	logger.Error("⚠️  DIGEST MISMATCH: the deployed release may not run the image you pushed")
+	logger.Error("  Submitted: %s%s", SHA256Prefix, hex.EncodeToString(submitted[:]))
+	logger.Error("  %s: %s%s", source, SHA256Prefix, hex.EncodeToString(actual[:]))
+	logger.Error("  Pin images by digest, or push a unique tag per release, then upgrade again")
+}
@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchDigestWithRetry(t *testing.T) {
+	want := [32]byte{1, 2, 3}
+
+	t.Run("succeeds after transient failures", func(t *testing.T) {
+		calls := 0
+		got, err := fetchDigestWithRetry(context.Background(), 3, 0, func() ([32]byte, error) {
+			calls++
+			if calls < 3 {
+				return [32]byte{}, errors.New("manifest unknown")
+			}
+			return want, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("gives up after the last attempt", func(t *testing.T) {
+		calls := 0
+		_, err := fetchDigestWithRetry(context.Background(), 2, 0, func() ([32]byte, error) {
+			calls++
+			return [32]byte{}, errors.New("manifest unknown")
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "2 attempt(s)")
+		assert.Contains(t, err.Error(), "manifest unknown")
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("stops when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		calls := 0
+		_, err := fetchDigestWithRetry(ctx, 5, 0, func() ([32]byte, error) {
+			calls++
+			return [32]byte{}, errors.New("connection refused")
+		})
+		require.Error(t, err)
+		assert.Equal(t, 1, calls)
+	})
+}
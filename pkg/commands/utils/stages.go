@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common/iface"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common/logger"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common/progress"
+	"github.com/urfave/cli/v2"
+)
+
+// Named stages of a deploy or upgrade, reported through the progress tracker
+const (
+	StageBuild     = "build"
+	StagePush      = "push"
+	StagePropagate = "propagate"
+	StageEncrypt   = "encrypt"
+	StageSubmitTx  = "submit-tx"
+	StageWatch     = "watch"
+)
+
+const (
+	progressFormatText = "text"
+	progressFormatJSON = "json"
+
+	progressModePlain = "plain"
+	progressModeTTY   = "tty"
+	progressModeNone  = "none"
+)
+
+// ApplyProgressFormat installs the output selected by --progress and then the progress tracker
+// selected by --progress-format. With neither flag the default tracker is kept, so stage updates
+// don't change the regular output.
+func ApplyProgressFormat(cCtx *cli.Context) error {
+	if err := applyProgressMode(cCtx); err != nil {
+		return err
+	}
+
+	switch format := cCtx.String(common.ProgressFormatFlag.Name); format {
+	case "", progressFormatText:
+		return nil
+	case progressFormatJSON:
+		cCtx.Context = common.WithProgressTracker(cCtx.Context, progress.NewJSONProgressTracker(os.Stderr))
+		return nil
+	default:
+		return fmt.Errorf("invalid --%s %q: must be %q or %q", common.ProgressFormatFlag.Name, format, progressFormatText, progressFormatJSON)
+	}
+}
+
+// applyProgressMode sets up --progress:
+//   - plain: raw buildx and push output, with stage completions logged
+//   - tty: stages drawn as progress bars; tool output and info logs are hidden
+//   - none: only warnings, errors and prompts are shown
+func applyProgressMode(cCtx *cli.Context) error {
+	switch mode := cCtx.String(common.ProgressFlag.Name); mode {
+	case "":
+		return nil
+	case progressModePlain:
+		cCtx.Context = common.WithProgressTracker(cCtx.Context, progress.NewLogProgressTracker(10, common.LoggerFromContext(cCtx)))
+		return nil
+	case progressModeTTY:
+		applyQuietOutput(cCtx)
+		cCtx.Context = common.WithProgressTracker(cCtx.Context, renderOnSet{progress.NewTTYProgressTracker(10, os.Stdout)})
+		return nil
+	case progressModeNone:
+		applyQuietOutput(cCtx)
+		return nil
+	default:
+		return fmt.Errorf("invalid --%s %q: must be %q, %q or %q", common.ProgressFlag.Name, mode, progressModePlain, progressModeTTY, progressModeNone)
+	}
+}
+
+// QuietChildContext returns a child of cCtx with quiet output, for work that runs concurrently
+// and would otherwise interleave its logs and progress bars
+func QuietChildContext(cCtx *cli.Context) *cli.Context {
+	child := cli.NewContext(cCtx.App, &flag.FlagSet{}, cCtx)
+	applyQuietOutput(child)
+	return child
+}
+
+// applyQuietOutput demotes info logs to debug, silences the progress tracker and marks the
+// context so build, push and watch output is suppressed. Already quiet contexts are left as is.
+func applyQuietOutput(cCtx *cli.Context) {
+	if common.IsQuietOutput(cCtx.Context) {
+		return
+	}
+	cCtx.Context = common.WithLogger(cCtx.Context, logger.Quiet(common.LoggerFromContext(cCtx)))
+	cCtx.Context = common.WithProgressTracker(cCtx.Context, logger.NewNoopProgressTracker())
+	cCtx.Context = common.WithQuietOutput(cCtx.Context)
+}
+
+// renderOnSet redraws the wrapped tracker after every update
+type renderOnSet struct {
+	iface.ProgressTracker
+}
+
+func (r renderOnSet) Set(id string, pct int, label string) {
+	r.ProgressTracker.Set(id, pct, label)
+	r.Render()
+}
+
+// ReportStage records the progress of a named stage (0 when it starts, 100 when it completes)
+func ReportStage(cCtx *cli.Context, stage string, pct int, label string) {
+	common.ProgressTrackerFromContext(cCtx.Context).Set(stage, pct, label)
+}
@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	"github.com/urfave/cli/v2"
+)
+
+// SubscriptionRequiredError explains why an account without app quota can't deploy, based on
+// its billing subscription: a missing subscription comes with a checkout link, a payment issue
+// with the billing portal. Without --skip-billing-check and when billing can't be reached, the
+// generic quota error is returned.
+func SubscriptionRequiredError(cCtx *cli.Context, environment string) error {
+	logger := common.LoggerFromContext(cCtx)
+	noQuota := fmt.Errorf("no app quota available. Run 'eigenx billing subscribe' to enable app deployment")
+	if cCtx.Bool(common.SkipBillingCheckFlag.Name) {
+		return noQuota
+	}
+
+	client, err := NewUserApiClient(cCtx)
+	if err != nil {
+		logger.Debug("Failed to create API client for the billing check: %v", err)
+		return noQuota
+	}
+	subscription, err := client.GetUserSubscription(cCtx)
+	if err != nil {
+		logger.Debug("Failed to check subscription status: %v", err)
+		return noQuota
+	}
+
+	checkoutURL := ""
+	if needsCheckout(subscription.Status) {
+		if session, err := client.CreateCheckoutSession(cCtx); err != nil {
+			logger.Debug("Failed to create checkout session: %v", err)
+		} else {
+			checkoutURL = session.CheckoutURL
+		}
+	}
+	return subscriptionRequiredError(environment, subscription, checkoutURL)
+}
+
+// needsCheckout reports whether a new subscription is needed, as opposed to an active one or one
+// with a payment issue that is fixed in the billing portal
+func needsCheckout(status SubscriptionStatus) bool {
+	return !status.IsActive() && status != StatusPastDue && status != StatusUnpaid
+}
+
+// subscriptionRequiredError builds the error for an account without app quota from its subscription
+func subscriptionRequiredError(environment string, subscription *UserSubscriptionResponse, checkoutURL string) error {
+	status := subscription.Status
+	if status == "" {
+		status = StatusInactive
+	}
+
+	switch {
+	case status.IsActive():
+		return fmt.Errorf("no app quota available on %s although your subscription is %s; the quota can take a few minutes to update onchain, so try again shortly or run 'eigenx billing status'", environment, status)
+	case !needsCheckout(status):
+		msg := fmt.Sprintf("your %s subscription has a payment issue (%s); update your payment method to deploy", environment, status)
+		if subscription.PortalURL != nil && *subscription.PortalURL != "" {
+			msg += ": " + *subscription.PortalURL
+		} else {
+			msg += ", see 'eigenx billing status'"
+		}
+		return errors.New(msg)
+	}
+
+	msg := fmt.Sprintf("deploying on %s requires an active subscription (status: %s). Run 'eigenx billing subscribe'", environment, status)
+	if checkoutURL != "" {
+		msg += ", or subscribe at: " + checkoutURL
+	}
+	return errors.New(msg)
+}
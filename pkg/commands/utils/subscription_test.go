@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscriptionRequiredError(t *testing.T) {
+	portal := "https://billing.example/portal"
+
+	tests := []struct {
+		name         string
+		subscription UserSubscriptionResponse
+		checkoutURL  string
+		want         string
+	}{
+		{
+			name:         "no subscription",
+			subscription: UserSubscriptionResponse{},
+			checkoutURL:  "https://checkout.example/session",
+			want:         "deploying on sepolia requires an active subscription (status: inactive). Run 'eigenx billing subscribe', or subscribe at: https://checkout.example/session",
+		},
+		{
+			name:         "canceled without checkout link",
+			subscription: UserSubscriptionResponse{Status: StatusCanceled},
+			want:         "deploying on sepolia requires an active subscription (status: canceled). Run 'eigenx billing subscribe'",
+		},
+		{
+			name:         "payment issue",
+			subscription: UserSubscriptionResponse{Status: StatusPastDue, PortalURL: &portal},
+			want:         "your sepolia subscription has a payment issue (past_due); update your payment method to deploy: https://billing.example/portal",
+		},
+		{
+			name:         "payment issue without portal",
+			subscription: UserSubscriptionResponse{Status: StatusUnpaid},
+			want:         "your sepolia subscription has a payment issue (unpaid); update your payment method to deploy, see 'eigenx billing status'",
+		},
+		{
+			name:         "active but quota not updated",
+			subscription: UserSubscriptionResponse{Status: StatusTrialing},
+			want:         "no app quota available on sepolia although your subscription is trialing; the quota can take a few minutes to update onchain, so try again shortly or run 'eigenx billing status'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, subscriptionRequiredError("sepolia", &tt.subscription, tt.checkoutURL), tt.want)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	appcontrollerV2 "github.com/Layr-Labs/eigenx-contracts/pkg/bindings/v2/AppController"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+// ApplySummaryOnly sets up --summary-only: info logs are demoted to debug, the progress
+// tracker and tool output are silenced, and the watch loop skips its countdown. It must run
+// before ApplyProgressFormat so --progress-format json still reports stages.
+func ApplySummaryOnly(cCtx *cli.Context) {
+	if cCtx.Bool(common.SummaryOnlyFlag.Name) {
+		applyQuietOutput(cCtx)
+	}
+}
+
+// PrintReleaseSummary prints the single --summary-only result line for a deployed or upgraded app
+func PrintReleaseSummary(cCtx *cli.Context, verb string, appID ethcommon.Address, appName string, release appcontrollerV2.IAppControllerRelease, txHash ethcommon.Hash) {
+	if !cCtx.Bool(common.SummaryOnlyFlag.Name) {
+		return
+	}
+
+	ip := ""
+	if userApiClient, err := NewUserApiClient(cCtx); err == nil {
+		if info, err := userApiClient.GetInfos(cCtx, []ethcommon.Address{appID}, 1, AddressChainsAll); err == nil && len(info.Apps) > 0 {
+			ip = info.Apps[0].Ip
+		}
+	}
+
+	digest := ""
+	if artifacts := release.RmsRelease.Artifacts; len(artifacts) > 0 {
+		digest = (&DeployedRelease{Digest: artifacts[0].Digest, Registry: artifacts[0].Registry}).ImageRef()
+	}
+
+	tx := ""
+	if txHash != (ethcommon.Hash{}) {
+		tx = txHash.Hex()
+	}
+
+	fmt.Println(formatReleaseSummary(verb, appID.Hex(), appName, ip, digest, tx))
+}
+
+// RecordTxHash logs the hash of a confirmed deploy or upgrade transaction and writes it to
+// --output-tx-hash-file when set. A failed write only warns, as the transaction already succeeded.
+func RecordTxHash(cCtx *cli.Context, txHash ethcommon.Hash) {
+	logger := common.LoggerFromContext(cCtx)
+	logger.Info("Transaction hash: %s", txHash.Hex())
+
+	path := cCtx.String(common.OutputTxHashFileFlag.Name)
+	if path == "" {
+		return
+	}
+	if err := writeTxHashFile(path, txHash); err != nil {
+		logger.Warn("Failed to write transaction hash: %s", err.Error())
+		return
+	}
+	logger.Info("Transaction hash written to %s", path)
+}
+
+// writeTxHashFile writes the hex transaction hash and a trailing newline to path
+func writeTxHashFile(path string, txHash ethcommon.Hash) error {
+	if err := os.WriteFile(path, []byte(txHash.Hex()+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// formatReleaseSummary joins the non-empty parts of a release summary into one line
+func formatReleaseSummary(verb, appID, appName, ip, image, tx string) string {
+	head := fmt.Sprintf("✅ %s %s", verb, appID)
+	if appName != "" {
+		head += fmt.Sprintf(" (%s)", appName)
+	}
+
+	parts := []string{head}
+	if ip != "" {
+		parts = append(parts, "ip "+ip)
+	}
+	if image != "" {
+		parts = append(parts, "image "+image)
+	}
+	if tx != "" {
+		parts = append(parts, "tx "+tx)
+	}
+	return strings.Join(parts, " · ")
+}
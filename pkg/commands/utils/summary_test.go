@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatReleaseSummary(t *testing.T) {
+	tests := []struct {
+		name                              string
+		verb, appID, appName, ip, img, tx string
+		want                              string
+	}{
+		{
+			name:    "all fields",
+			verb:    "Deployed",
+			appID:   "0xabc",
+			appName: "web",
+			ip:      "1.2.3.4",
+			img:     "docker.io/me/web@sha256:00",
+			tx:      "0xdef",
+			want:    "✅ Deployed 0xabc (web) · ip 1.2.3.4 · image docker.io/me/web@sha256:00 · tx 0xdef",
+		},
+		{
+			name:  "no name or ip",
+			verb:  "Upgraded",
+			appID: "0xabc",
+			img:   "docker.io/me/web@sha256:00",
+			want:  "✅ Upgraded 0xabc · image docker.io/me/web@sha256:00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, formatReleaseSummary(tt.verb, tt.appID, tt.appName, tt.ip, tt.img, tt.tx))
+		})
+	}
+}
+
+func TestWriteTxHashFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tx-hash")
+	txHash := ethcommon.HexToHash("0x1234")
+
+	require.NoError(t, writeTxHashFile(path, txHash))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, txHash.Hex()+"\n", string(data))
+
+	assert.Error(t, writeTxHashFile(filepath.Join(t.TempDir(), "missing", "tx-hash"), txHash))
+}
@@ -1,5 +1,7 @@
 package utils
 
+import "time"
+
 const (
 	KMSEncryptionPublicKeyPath  = "keys/%s/kms-encryption-public-key.pem"
 	KMSSigningPublicKeyPath     = "keys/%s/kms-signing-public-key.pem"
@@ -13,26 +15,46 @@ const (
 	LayeredBuildDirPrefix = "eigenx-layered-build"
 	LayeredDockerfileName = "Dockerfile.eigencompute"
 	EnvSourceScriptName   = "compute-source-env.sh"
+	LayeredEntrypoint     = "/usr/local/bin/" + EnvSourceScriptName // ENTRYPOINT of the layered Dockerfile
 	KMSClientBinaryName   = "kms-client"
 	KMSEncryptionKeyName  = "kms-encryption-public-key.pem"
 	KMSSigningKeyName     = "kms-signing-public-key.pem"
 	TlsKeygenBinaryName   = "tls-keygen"
 	CaddyfileName         = "Caddyfile"
-	DockerPlatform        = "linux/amd64"
 	LinuxOS               = "linux"
 	AMD64Arch             = "amd64"
+	ARM64Arch             = "arm64"
 	SHA256Prefix          = "sha256:"
 
 	RegistryPropagationWaitSeconds = 3
+
+	// RegistryPropagationPollInterval is the delay between digest lookups while waiting for propagation
+	RegistryPropagationPollInterval = 2 * time.Second
+
+	// ReleaseVerifyAttempts is how many times the pushed image digest is re-fetched after deploying
+	ReleaseVerifyAttempts = 3
+
+	// RegistryRetryWaitSeconds is the base delay between non-interactive --registry-retry-same attempts
+	RegistryRetryWaitSeconds = 10
+
+	// maxBaseImageNameLength bounds the path-derived part of temporary base image names
+	maxBaseImageNameLength = 64
+
+	// BuildProcessWaitDelay bounds how long to wait for buildx output to drain after it is killed
+	BuildProcessWaitDelay = 5 * time.Second
 )
 
 type LayeredDockerfileTemplateData struct {
 	BaseImage        string
+	Entrypoint       string
 	OriginalCmd      string
 	OriginalUser     string
 	LogRedirect      string
 	IncludeTLS       bool
+	TLSEmail         string
+	TLSStaging       bool
 	EigenXCLIVersion string
+	GitCommit        string
 }
 
 type EnvSourceScriptTemplateData struct {
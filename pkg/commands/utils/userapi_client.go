@@ -2,7 +2,9 @@ package utils
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -11,6 +13,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -37,11 +40,50 @@ const (
 	StatusInactive          SubscriptionStatus = "inactive"
 )
 
+// IsActive returns true if the subscription status allows deploying apps
+func (s SubscriptionStatus) IsActive() bool {
+	return s == StatusActive || s == StatusTrialing
+}
+
 const (
 	MaxAddressCount   = 5  // Max addresses to return per app
 	MaxAppsPerRequest = 10 // Max apps allowed per API request
 )
 
+// AddressChains selects which address families GetInfos keeps for each app
+type AddressChains string
+
+const (
+	AddressChainsAll    AddressChains = "all"
+	AddressChainsEVM    AddressChains = "evm"
+	AddressChainsSolana AddressChains = "solana"
+)
+
+// ParseAddressChains validates a --chains value
+func ParseAddressChains(value string) (AddressChains, error) {
+	switch chains := AddressChains(strings.ToLower(strings.TrimSpace(value))); chains {
+	case AddressChainsAll, AddressChainsEVM, AddressChainsSolana:
+		return chains, nil
+	case "":
+		return AddressChainsAll, nil
+	default:
+		return "", fmt.Errorf("invalid --chains value %q: must be evm, solana or all", value)
+	}
+}
+
+// AddressChainsFromContext reads and validates the --chains flag
+func AddressChainsFromContext(cCtx *cli.Context) (AddressChains, error) {
+	return ParseAddressChains(cCtx.String(common.AddressChainsFlag.Name))
+}
+
+func (c AddressChains) includesEVM() bool {
+	return c != AddressChainsSolana
+}
+
+func (c AddressChains) includesSolana() bool {
+	return c != AddressChainsEVM
+}
+
 type AppStatusResponse struct {
 	Apps []AppStatus `json:"apps"`
 }
@@ -115,6 +157,8 @@ type AppInfo struct {
 
 type AppInfoResponse struct {
 	Apps []AppInfo
+	// PollInterval is the server's suggested delay before polling again, or zero if none was sent
+	PollInterval time.Duration
 }
 
 type UserApiClient struct {
@@ -163,7 +207,8 @@ func (cc *UserApiClient) GetStatuses(cCtx *cli.Context, appIDs []ethcommon.Addre
 	return &result, nil
 }
 
-func (cc *UserApiClient) GetInfos(cCtx *cli.Context, appIDs []ethcommon.Address, addressCount int) (*AppInfoResponse, error) {
+// GetInfos fetches app info with up to addressCount addresses from each family in chains
+func (cc *UserApiClient) GetInfos(cCtx *cli.Context, appIDs []ethcommon.Address, addressCount int, chains AddressChains) (*AppInfoResponse, error) {
 	if addressCount > MaxAddressCount {
 		addressCount = MaxAddressCount
 	}
@@ -202,7 +247,8 @@ func (cc *UserApiClient) GetInfos(cCtx *cli.Context, appIDs []ethcommon.Address,
 	appIDList := strings.Split(appIDStrings, ",")
 
 	result := &AppInfoResponse{
-		Apps: make([]AppInfo, len(rawResult.Apps)),
+		Apps:         make([]AppInfo, len(rawResult.Apps)),
+		PollInterval: suggestedPollInterval(resp.Header, time.Now()),
 	}
 
 	for i, rawApp := range rawResult.Apps {
@@ -211,6 +257,7 @@ func (cc *UserApiClient) GetInfos(cCtx *cli.Context, appIDs []ethcommon.Address,
 			appIDs[i],
 			signingKey,
 			addressCount,
+			chains,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("error processing addresses for app %s: %w", appIDList[i], err)
@@ -229,6 +276,30 @@ func (cc *UserApiClient) GetInfos(cCtx *cli.Context, appIDs []ethcommon.Address,
 	return result, nil
 }
 
+// suggestedPollInterval reads the delay the server asks clients to wait before polling again
+// from X-Poll-Interval (seconds or a duration like "15s") or Retry-After (seconds or an HTTP
+// date), returning zero when neither header holds a positive delay
+func suggestedPollInterval(header http.Header, now time.Time) time.Duration {
+	if value := strings.TrimSpace(header.Get("X-Poll-Interval")); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		if d, err := time.ParseDuration(value); err == nil && d > 0 {
+			return d
+		}
+	}
+
+	if value := strings.TrimSpace(header.Get("Retry-After")); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		if at, err := http.ParseTime(value); err == nil && at.After(now) {
+			return at.Sub(now)
+		}
+	}
+	return 0
+}
+
 func (cc *UserApiClient) GetLogs(cCtx *cli.Context, appID ethcommon.Address) (string, error) {
 	endpoint := fmt.Sprintf("%s/logs/%s", cc.environmentConfig.UserApiServerURL, appID.Hex())
 
@@ -405,6 +476,23 @@ func (cc *UserApiClient) UploadAppProfile(cCtx *cli.Context, appAddress string,
 	return &result, nil
 }
 
+// DeleteAppProfile removes the public profile of an app
+func (cc *UserApiClient) DeleteAppProfile(cCtx *cli.Context, appAddress string) error {
+	endpoint := fmt.Sprintf("%s/apps/%s/profile", cc.environmentConfig.UserApiServerURL, appAddress)
+
+	resp, err := cc.makeAuthenticatedRequest(cCtx, "DELETE", endpoint, nil, "", &common.CanUpdateAppProfilePermission)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return handleErrorResponse(resp)
+	}
+
+	return nil
+}
+
 // buildAppIDsParam creates a comma-separated string of app IDs for URL parameters
 func buildAppIDsParam(appIDs []ethcommon.Address) string {
 	appIDStrings := make([]string, len(appIDs))
@@ -452,28 +540,60 @@ func (cc *UserApiClient) makeAuthenticatedRequest(cCtx *cli.Context, method, url
 	return resp, nil
 }
 
+// UserApiError is a non-success response from the userApi server
+type UserApiError struct {
+	StatusCode int
+	Message    string // Error field of a JSON error response, empty if the body was not one
+	Body       string
+}
+
+func (e *UserApiError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("userApi server error: %s", e.Message)
+	}
+	return fmt.Sprintf("userApi server returned status %d: %s", e.StatusCode, e.Body)
+}
+
 // handleErrorResponse processes non-200 HTTP responses with standard error parsing
 func handleErrorResponse(resp *http.Response) error {
 	body, _ := io.ReadAll(resp.Body)
+	apiErr := &UserApiError{StatusCode: resp.StatusCode, Body: string(body)}
 
-	// Try to parse JSON error response
+	// Try to parse JSON error response, falling back to the raw body
 	var errorResp struct {
 		Error string `json:"error"`
 	}
 	if err := json.Unmarshal(body, &errorResp); err == nil && errorResp.Error != "" {
-		return fmt.Errorf("userApi server error: %s", errorResp.Error)
+		apiErr.Message = errorResp.Error
+	}
+	return apiErr
+}
+
+// IsTransientUserApiError reports whether a failed userApi request is worth retrying. Only
+// cancellation and authentication or permission errors (401, 403) are final.
+func IsTransientUserApiError(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
 	}
 
-	// Fallback to raw body if not valid JSON
-	return fmt.Errorf("userApi server returned status %d: %s", resp.StatusCode, string(body))
+	var apiErr *UserApiError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return false
+		}
+	}
+	return true
 }
 
-// processAddressesResponse attempts to parse and validate addresses response as V2, then V1
+// processAddressesResponse attempts to parse and validate addresses response as V2, then V1,
+// keeping only the address families in chains
 func processAddressesResponse(
 	rawAddresses json.RawMessage,
 	appID ethcommon.Address,
 	signingKey []byte,
 	addressCount int,
+	chains AddressChains,
 ) (evmAddrs []kmstypes.EVMAddressAndDerivationPath, solanaAddrs []kmstypes.SolanaAddressAndDerivationPath, err error) {
 	// Try V2 first - unmarshal and verify signature
 	var signedV2 kmstypes.SignedResponse[kmstypes.AddressesResponseV2]
@@ -485,15 +605,7 @@ func processAddressesResponse(
 				return nil, nil, fmt.Errorf("app ID mismatch in V2 response")
 			}
 
-			// Truncate to requested count
-			evmAddrs = signedV2.Data.EVMAddresses
-			if len(evmAddrs) > addressCount {
-				evmAddrs = evmAddrs[:addressCount]
-			}
-			solanaAddrs = signedV2.Data.SolanaAddresses
-			if len(solanaAddrs) > addressCount {
-				solanaAddrs = solanaAddrs[:addressCount]
-			}
+			evmAddrs, solanaAddrs = selectAddresses(signedV2.Data.EVMAddresses, signedV2.Data.SolanaAddresses, addressCount, chains)
 			return evmAddrs, solanaAddrs, nil
 		}
 		// Signature failed - might be V1 response, fall through to try V1
@@ -513,14 +625,27 @@ func processAddressesResponse(
 	}
 
 	// V1 doesn't have AppID field, so we can't validate it
-	// Truncate to requested count
-	evmAddrs = signedV1.Data.EVMAddresses
-	if len(evmAddrs) > addressCount {
-		evmAddrs = evmAddrs[:addressCount]
-	}
-	solanaAddrs = signedV1.Data.SolanaAddresses
-	if len(solanaAddrs) > addressCount {
-		solanaAddrs = solanaAddrs[:addressCount]
-	}
+	evmAddrs, solanaAddrs = selectAddresses(signedV1.Data.EVMAddresses, signedV1.Data.SolanaAddresses, addressCount, chains)
 	return evmAddrs, solanaAddrs, nil
 }
+
+// selectAddresses drops the address families not in chains and truncates the rest to the
+// requested count
+func selectAddresses(
+	evm []kmstypes.EVMAddressAndDerivationPath,
+	solana []kmstypes.SolanaAddressAndDerivationPath,
+	addressCount int,
+	chains AddressChains,
+) ([]kmstypes.EVMAddressAndDerivationPath, []kmstypes.SolanaAddressAndDerivationPath) {
+	if !chains.includesEVM() {
+		evm = nil
+	} else if len(evm) > addressCount {
+		evm = evm[:addressCount]
+	}
+	if !chains.includesSolana() {
+		solana = nil
+	} else if len(solana) > addressCount {
+		solana = solana[:addressCount]
+	}
+	return evm, solana
+}
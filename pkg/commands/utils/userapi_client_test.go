@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	kmstypes "github.com/Layr-Labs/eigenx-kms/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuggestedPollInterval(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    time.Duration
+	}{
+		{name: "no headers", want: 0},
+		{name: "poll interval seconds", headers: map[string]string{"X-Poll-Interval": "15"}, want: 15 * time.Second},
+		{name: "poll interval duration", headers: map[string]string{"X-Poll-Interval": "1m30s"}, want: 90 * time.Second},
+		{name: "retry after seconds", headers: map[string]string{"Retry-After": "20"}, want: 20 * time.Second},
+		{name: "retry after date", headers: map[string]string{"Retry-After": now.Add(30 * time.Second).Format(http.TimeFormat)}, want: 30 * time.Second},
+		{name: "retry after date in the past", headers: map[string]string{"Retry-After": now.Add(-time.Minute).Format(http.TimeFormat)}, want: 0},
+		{name: "poll interval takes precedence", headers: map[string]string{"X-Poll-Interval": "10", "Retry-After": "40"}, want: 10 * time.Second},
+		{name: "invalid poll interval falls back", headers: map[string]string{"X-Poll-Interval": "soon", "Retry-After": "40"}, want: 40 * time.Second},
+		{name: "non-positive values", headers: map[string]string{"X-Poll-Interval": "0", "Retry-After": "-5"}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			for k, v := range tt.headers {
+				header.Set(k, v)
+			}
+			assert.Equal(t, tt.want, suggestedPollInterval(header, now))
+		})
+	}
+}
+
+func TestIsTransientUserApiError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "bad gateway", err: &UserApiError{StatusCode: http.StatusBadGateway}, want: true},
+		{name: "service unavailable", err: fmt.Errorf("wrapped: %w", &UserApiError{StatusCode: http.StatusServiceUnavailable}), want: true},
+		{name: "rate limited", err: &UserApiError{StatusCode: http.StatusTooManyRequests}, want: true},
+		{name: "internal error", err: &UserApiError{StatusCode: http.StatusInternalServerError}, want: true},
+		{name: "not found", err: &UserApiError{StatusCode: http.StatusNotFound}, want: true},
+		{name: "unauthorized", err: fmt.Errorf("wrapped: %w", &UserApiError{StatusCode: http.StatusUnauthorized}), want: false},
+		{name: "forbidden", err: &UserApiError{StatusCode: http.StatusForbidden, Message: "permission denied"}, want: false},
+		{name: "network error", err: fmt.Errorf("failed to make request: %w", &url.Error{Op: "Get", URL: "https://api.example", Err: errors.New("connection refused")}), want: true},
+		{name: "truncated body", err: fmt.Errorf("failed to read response body: %w", io.ErrUnexpectedEOF), want: true},
+		{name: "cancelled", err: &url.Error{Op: "Get", URL: "https://api.example", Err: context.Canceled}, want: false},
+		{name: "other", err: errors.New("failed to decode response"), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsTransientUserApiError(tt.err))
+		})
+	}
+}
+
+func TestUserApiErrorMessage(t *testing.T) {
+	assert.Equal(t, "userApi server error: app not found", (&UserApiError{StatusCode: 404, Message: "app not found", Body: `{"error":"app not found"}`}).Error())
+	assert.Equal(t, "userApi server returned status 502: Bad Gateway", (&UserApiError{StatusCode: 502, Body: "Bad Gateway"}).Error())
+}
+
+func TestParseAddressChains(t *testing.T) {
+	for value, want := range map[string]AddressChains{
+		"":       AddressChainsAll,
+		"all":    AddressChainsAll,
+		"evm":    AddressChainsEVM,
+		" EVM ":  AddressChainsEVM,
+		"solana": AddressChainsSolana,
+		"Solana": AddressChainsSolana,
+	} {
+		got, err := ParseAddressChains(value)
+		assert.NoError(t, err, value)
+		assert.Equal(t, want, got, value)
+	}
+
+	_, err := ParseAddressChains("bitcoin")
+	assert.ErrorContains(t, err, "must be evm, solana or all")
+}
+
+func TestSelectAddresses(t *testing.T) {
+	evm := []kmstypes.EVMAddressAndDerivationPath{{DerivationPath: "m/44'/60'/0'/0/0"}, {DerivationPath: "m/44'/60'/0'/0/1"}}
+	solana := []kmstypes.SolanaAddressAndDerivationPath{{Address: "sol0"}, {Address: "sol1"}}
+
+	gotEVM, gotSolana := selectAddresses(evm, solana, 1, AddressChainsAll)
+	assert.Equal(t, evm[:1], gotEVM)
+	assert.Equal(t, solana[:1], gotSolana)
+
+	gotEVM, gotSolana = selectAddresses(evm, solana, 5, AddressChainsEVM)
+	assert.Equal(t, evm, gotEVM)
+	assert.Nil(t, gotSolana)
+
+	gotEVM, gotSolana = selectAddresses(evm, solana, 5, AddressChainsSolana)
+	assert.Nil(t, gotEVM)
+	assert.Equal(t, solana, gotSolana)
+}
@@ -0,0 +1,207 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-cli/internal/version"
+	"github.com/Layr-Labs/eigenx-cli/pkg/common"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/websocket"
+	"github.com/urfave/cli/v2"
+)
+
+// Exec sessions connect to GET /apps/{appID}/exec with the command in repeated "cmd" query
+// parameters and, for a TTY, "tty", "cols" and "rows". They exchange terminal data as binary
+// websocket frames in both directions and control messages as JSON text frames: the client
+// sends "resize" and "eof", the server sends "exit" with the exit code or "error". Unknown
+// control messages are ignored so either side can add new ones.
+const (
+	execMessageResize = "resize"
+	execMessageEOF    = "eof"
+	execMessageExit   = "exit"
+	execMessageError  = "error"
+
+	execHandshakeTimeout = 30 * time.Second
+	execWriteTimeout     = 10 * time.Second
+)
+
+// ExecOptions configures an exec session
+type ExecOptions struct {
+	// Command to run in the app container, empty for the default shell
+	Command []string
+	// TTY requests a pseudo-terminal of the given size
+	TTY  bool
+	Cols int
+	Rows int
+}
+
+type execControlMessage struct {
+	Type  string `json:"type"`
+	Cols  int    `json:"cols,omitempty"`
+	Rows  int    `json:"rows,omitempty"`
+	Code  *int   `json:"code,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// ExecSession is an interactive session attached to a process running in an app
+type ExecSession struct {
+	conn    *websocket.Conn
+	writeMu sync.Mutex
+}
+
+// OpenExecSession opens an interactive exec session in the given app
+func (cc *UserApiClient) OpenExecSession(cCtx *cli.Context, appID ethcommon.Address, opts ExecOptions) (*ExecSession, error) {
+	endpoint, err := execSessionURL(cc.environmentConfig.UserApiServerURL, appID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := http.Header{}
+	headers.Set("x-client-id", fmt.Sprintf("eigenx-cli/%s", version.GetVersion()))
+
+	expiry := big.NewInt(time.Now().Add(5 * time.Minute).Unix())
+	authHeaders, err := GenerateAuthHeaders(cCtx, common.CanExecAppPermission, expiry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate auth headers: %w", err)
+	}
+	for key, value := range authHeaders {
+		headers.Set(key, value)
+	}
+
+	dialer := websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: execHandshakeTimeout,
+	}
+	conn, resp, err := dialer.DialContext(cCtx.Context, endpoint, headers)
+	if err != nil {
+		if resp != nil {
+			defer resp.Body.Close()
+			if resp.StatusCode == http.StatusNotFound {
+				return nil, fmt.Errorf("interactive exec is not available in environment %s", cc.environmentConfig.Name)
+			}
+			return nil, handleErrorResponse(resp)
+		}
+		return nil, fmt.Errorf("failed to open exec session: %w", err)
+	}
+
+	return &ExecSession{conn: conn}, nil
+}
+
+// execSessionURL builds the websocket URL of the exec endpoint for an app
+func execSessionURL(serverURL string, appID ethcommon.Address, opts ExecOptions) (string, error) {
+	u, err := url.Parse(fmt.Sprintf("%s/apps/%s/exec", serverURL, appID.Hex()))
+	if err != nil {
+		return "", fmt.Errorf("invalid userApi server URL: %w", err)
+	}
+
+	switch u.Scheme {
+	case "https":
+		u.Scheme = "wss"
+	case "http":
+		u.Scheme = "ws"
+	default:
+		return "", fmt.Errorf("unsupported userApi server URL scheme %q", u.Scheme)
+	}
+
+	params := url.Values{}
+	for _, arg := range opts.Command {
+		params.Add("cmd", arg)
+	}
+	if opts.TTY {
+		params.Set("tty", "true")
+		params.Set("cols", strconv.Itoa(opts.Cols))
+		params.Set("rows", strconv.Itoa(opts.Rows))
+	}
+	u.RawQuery = params.Encode()
+
+	return u.String(), nil
+}
+
+// Write sends input to the remote process
+func (s *ExecSession) Write(p []byte) (int, error) {
+	if err := s.writeMessage(websocket.BinaryMessage, p); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Resize notifies the remote terminal of a new window size
+func (s *ExecSession) Resize(cols, rows int) error {
+	return s.writeControl(execControlMessage{Type: execMessageResize, Cols: cols, Rows: rows})
+}
+
+// CloseInput signals end of input to the remote process
+func (s *ExecSession) CloseInput() error {
+	return s.writeControl(execControlMessage{Type: execMessageEOF})
+}
+
+// Run copies remote output to out until the remote process exits and returns its exit code
+func (s *ExecSession) Run(out io.Writer) (int, error) {
+	for {
+		messageType, data, err := s.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+				return 0, nil
+			}
+			return 0, fmt.Errorf("exec session closed unexpectedly: %w", err)
+		}
+
+		switch messageType {
+		case websocket.BinaryMessage:
+			if _, err := out.Write(data); err != nil {
+				return 0, fmt.Errorf("failed to write output: %w", err)
+			}
+		case websocket.TextMessage:
+			var msg execControlMessage
+			if err := json.Unmarshal(data, &msg); err != nil {
+				return 0, fmt.Errorf("failed to decode exec control message: %w", err)
+			}
+			switch msg.Type {
+			case execMessageExit:
+				if msg.Code == nil {
+					return 0, nil
+				}
+				return *msg.Code, nil
+			case execMessageError:
+				return 0, fmt.Errorf("exec session error: %s", msg.Error)
+			}
+		}
+	}
+}
+
+// Close terminates the session
+func (s *ExecSession) Close() error {
+	s.writeMu.Lock()
+	_ = s.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(execWriteTimeout))
+	s.writeMu.Unlock()
+	return s.conn.Close()
+}
+
+func (s *ExecSession) writeControl(msg execControlMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode exec control message: %w", err)
+	}
+	return s.writeMessage(websocket.TextMessage, data)
+}
+
+func (s *ExecSession) writeMessage(messageType int, data []byte) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	if err := s.conn.SetWriteDeadline(time.Now().Add(execWriteTimeout)); err != nil {
+		return err
+	}
+	if err := s.conn.WriteMessage(messageType, data); err != nil {
+		return fmt.Errorf("failed to write to exec session: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package utils
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecSessionURL(t *testing.T) {
+	appID := ethcommon.HexToAddress("0x1")
+
+	tests := []struct {
+		name      string
+		serverURL string
+		opts      ExecOptions
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "https with command and tty",
+			serverURL: "https://userapi.example",
+			opts:      ExecOptions{Command: []string{"ls", "-la"}, TTY: true, Cols: 80, Rows: 24},
+			want:      "wss://userapi.example/apps/" + appID.Hex() + "/exec?cmd=ls&cmd=-la&cols=80&rows=24&tty=true",
+		},
+		{
+			name:      "http without command",
+			serverURL: "http://localhost:8080",
+			want:      "ws://localhost:8080/apps/" + appID.Hex() + "/exec",
+		},
+		{
+			name:      "unsupported scheme",
+			serverURL: "ftp://userapi.example",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := execSessionURL(tt.serverURL, appID, tt.opts)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// newTestExecSession connects an ExecSession to a websocket server running handle
+func newTestExecSession(t *testing.T, handle func(conn *websocket.Conn)) *ExecSession {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		handle(conn)
+	}))
+	t.Cleanup(server.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return &ExecSession{conn: conn}
+}
+
+func TestExecSessionRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		frames   []string
+		closeMsg []byte
+		wantOut  string
+		wantCode int
+		wantErr  string
+	}{
+		{
+			name:     "exit with code",
+			frames:   []string{"bin:hello", `text:{"type":"exit","code":3}`},
+			wantOut:  "hello",
+			wantCode: 3,
+		},
+		{
+			name:   "exit without code",
+			frames: []string{`text:{"type":"exit"}`},
+		},
+		{
+			name:     "unknown control messages are ignored",
+			frames:   []string{`text:{"type":"ping"}`, "bin:out", `text:{"type":"exit","code":1}`},
+			wantOut:  "out",
+			wantCode: 1,
+		},
+		{
+			name:    "error message",
+			frames:  []string{`text:{"type":"error","error":"container not running"}`},
+			wantErr: "container not running",
+		},
+		{
+			name:    "invalid control message",
+			frames:  []string{"text:not json"},
+			wantErr: "failed to decode exec control message",
+		},
+		{
+			name:     "normal closure",
+			frames:   []string{"bin:bye"},
+			closeMsg: websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+			wantOut:  "bye",
+		},
+		{
+			name:     "abnormal closure",
+			closeMsg: websocket.FormatCloseMessage(websocket.CloseInternalServerErr, ""),
+			wantErr:  "exec session closed unexpectedly",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			session := newTestExecSession(t, func(conn *websocket.Conn) {
+				for _, frame := range tt.frames {
+					kind, data, _ := strings.Cut(frame, ":")
+					messageType := websocket.TextMessage
+					if kind == "bin" {
+						messageType = websocket.BinaryMessage
+					}
+					if err := conn.WriteMessage(messageType, []byte(data)); err != nil {
+						return
+					}
+				}
+				if tt.closeMsg != nil {
+					_ = conn.WriteMessage(websocket.CloseMessage, tt.closeMsg)
+				}
+				// Wait for the client to hang up
+				_, _, _ = conn.ReadMessage()
+			})
+
+			var out bytes.Buffer
+			code, err := session.Run(&out)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantCode, code)
+			assert.Equal(t, tt.wantOut, out.String())
+		})
+	}
+}
+
+func TestExecSessionWrites(t *testing.T) {
+	type frame struct {
+		messageType int
+		data        string
+	}
+	received := make(chan frame, 3)
+
+	session := newTestExecSession(t, func(conn *websocket.Conn) {
+		for {
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			received <- frame{messageType, string(data)}
+		}
+	})
+
+	_, err := session.Write([]byte("ls\n"))
+	require.NoError(t, err)
+	require.NoError(t, session.Resize(120, 40))
+	require.NoError(t, session.CloseInput())
+
+	assert.Equal(t, frame{websocket.BinaryMessage, "ls\n"}, <-received)
+	assert.Equal(t, frame{websocket.TextMessage, `{"type":"resize","cols":120,"rows":40}`}, <-received)
+	assert.Equal(t, frame{websocket.TextMessage, `{"type":"eof"}`}, <-received)
+	require.NoError(t, session.Close())
+}
@@ -1,19 +1,60 @@
 package version
 
 import (
+	"encoding/json"
 	"fmt"
+	"runtime"
+	"runtime/debug"
 
 	"github.com/Layr-Labs/eigenx-cli/internal/version"
+	"github.com/Layr-Labs/eigenx-cli/pkg/commands/utils"
 	"github.com/Layr-Labs/eigenx-cli/pkg/common"
 
 	"github.com/urfave/cli/v2"
 )
 
+const (
+	contractsModulePath = "github.com/Layr-Labs/eigenx-contracts"
+	kmsModulePath       = "github.com/Layr-Labs/eigenx-kms"
+)
+
+// versionInfo is the document printed by version --json
+type versionInfo struct {
+	Version     string              `json:"version"`
+	Commit      string              `json:"commit"`
+	GoVersion   string              `json:"goVersion"`
+	Platform    string              `json:"platform"`
+	Environment *environmentVersion `json:"environment,omitempty"`
+	Contracts   contractsVersion    `json:"contracts"`
+	KMS         moduleVersion       `json:"kms"`
+}
+
+type environmentVersion struct {
+	Name                 string `json:"name"`
+	AppControllerAddress string `json:"appControllerAddress"`
+}
+
+type moduleVersion struct {
+	Module  string `json:"module"`
+	Version string `json:"version"`
+}
+
+// contractsVersion describes the contract bindings compiled into the CLI. Reads go through
+// the V1 AppController bindings and transactions are packed with the V2 bindings.
+type contractsVersion struct {
+	moduleVersion
+	AppControllerReadBindings  string `json:"appControllerReadBindings"`
+	AppControllerWriteBindings string `json:"appControllerWriteBindings"`
+}
+
 // RunCommand defines the "run" command
 var VersionCommand = &cli.Command{
 	Name:  "version",
 	Usage: "Print the version of the EigenX CLI",
-	Flags: append([]cli.Flag{}, common.GlobalFlags...),
+	Flags: append(common.GlobalFlags, []cli.Flag{
+		common.EnvironmentFlag,
+		common.JSONFlagWithUsage("Output version, contract binding and KMS versions as JSON"),
+	}...),
 	Action: func(cCtx *cli.Context) error {
 		return VersionRun(cCtx)
 	},
@@ -23,7 +64,64 @@ func VersionRun(cCtx *cli.Context) error {
 	v := version.GetVersion()
 	commit := version.GetCommit()
 
+	if cCtx.Bool(common.JSONFlag.Name) {
+		return printVersionJSON(cCtx, v, commit)
+	}
+
 	fmt.Printf("Version: %s\nCommit: %s\n", v, commit)
 
 	return nil
 }
+
+func printVersionJSON(cCtx *cli.Context, v, commit string) error {
+	info := versionInfo{
+		Version:   v,
+		Commit:    commit,
+		GoVersion: runtime.Version(),
+		Platform:  fmt.Sprintf("%s/%s", runtime.GOOS, runtime.GOARCH),
+		Contracts: contractsVersion{
+			moduleVersion:              dependencyVersion(contractsModulePath),
+			AppControllerReadBindings:  "v1",
+			AppControllerWriteBindings: "v2",
+		},
+		KMS: dependencyVersion(kmsModulePath),
+	}
+
+	// The environment is best-effort so that version always works
+	if environmentConfig, err := utils.GetEnvironmentConfig(cCtx); err == nil {
+		info.Environment = &environmentVersion{
+			Name:                 environmentConfig.Name,
+			AppControllerAddress: environmentConfig.AppControllerAddress.Hex(),
+		}
+	}
+
+	encoded, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode version info: %w", err)
+	}
+	fmt.Println(string(encoded))
+	return nil
+}
+
+// dependencyVersion returns the version of a module dependency recorded in the binary's build info
+func dependencyVersion(modulePath string) moduleVersion {
+	result := moduleVersion{Module: modulePath, Version: "unknown"}
+
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return result
+	}
+	for _, dep := range buildInfo.Deps {
+		if dep.Path != modulePath {
+			continue
+		}
+		if dep.Replace != nil {
+			dep = dep.Replace
+		}
+		if dep.Version != "" {
+			result.Version = dep.Version
+		}
+		break
+	}
+	return result
+}
@@ -0,0 +1,64 @@
+package common
+
+import (
+	"os"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common/progress"
+	"github.com/fatih/color"
+)
+
+// ANSI SGR codes used by Style
+const (
+	StyleBold   = "1"
+	StyleDim    = "2"
+	StyleItalic = "3"
+	StyleRed    = "31"
+	StyleGreen  = "32"
+	StyleYellow = "33"
+	StyleCyan   = "36"
+)
+
+// colorDisabled is set by --no-color
+var colorDisabled bool
+
+// ColorEnabled reports whether ANSI colors and text styles should be written.
+// --no-color and NO_COLOR (https://no-color.org) disable them and take precedence;
+// CLICOLOR_FORCE enables them even when stdout is not a terminal. Otherwise they are
+// used on a TTY.
+func ColorEnabled() bool {
+	if colorDisabled {
+		return false
+	}
+	return colorEnabled(os.Getenv("NO_COLOR"), os.Getenv("CLICOLOR_FORCE"), progress.IsTTY())
+}
+
+func colorEnabled(noColor, cliColorForce string, isTTY bool) bool {
+	if noColor != "" {
+		return false
+	}
+	if cliColorForce != "" && cliColorForce != "0" {
+		return true
+	}
+	return isTTY
+}
+
+// ApplyColorPreference records --no-color and makes fatih/color output follow ColorEnabled
+func ApplyColorPreference(noColor bool) {
+	colorDisabled = noColor
+	color.NoColor = !ColorEnabled()
+}
+
+// Style wraps text in the given SGR codes (e.g. StyleBold) when colors are enabled
+func Style(text string, codes ...string) string {
+	if !ColorEnabled() || len(codes) == 0 {
+		return text
+	}
+	sequence := "\033["
+	for i, code := range codes {
+		if i > 0 {
+			sequence += ";"
+		}
+		sequence += code
+	}
+	return sequence + "m" + text + "\033[0m"
+}
@@ -0,0 +1,52 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColorEnabled(t *testing.T) {
+	tests := []struct {
+		name          string
+		noColor       string
+		cliColorForce string
+		isTTY         bool
+		want          bool
+	}{
+		{name: "tty", isTTY: true, want: true},
+		{name: "not a tty", isTTY: false, want: false},
+		{name: "NO_COLOR on tty", noColor: "1", isTTY: true, want: false},
+		{name: "CLICOLOR_FORCE without tty", cliColorForce: "1", isTTY: false, want: true},
+		{name: "CLICOLOR_FORCE=0 is ignored", cliColorForce: "0", isTTY: false, want: false},
+		{name: "NO_COLOR wins over CLICOLOR_FORCE", noColor: "1", cliColorForce: "1", isTTY: true, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, colorEnabled(tt.noColor, tt.cliColorForce, tt.isTTY))
+		})
+	}
+}
+
+func TestStyle(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("CLICOLOR_FORCE", "1")
+	assert.Equal(t, "\033[1;32mok\033[0m", Style("ok", StyleBold, StyleGreen))
+
+	t.Setenv("NO_COLOR", "1")
+	assert.Equal(t, "ok", Style("ok", StyleBold, StyleGreen))
+}
+
+func TestApplyColorPreference(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("CLICOLOR_FORCE", "1")
+	defer ApplyColorPreference(false)
+
+	ApplyColorPreference(true)
+	assert.False(t, ColorEnabled())
+	assert.Equal(t, "ok", Style("ok", StyleBold))
+
+	ApplyColorPreference(false)
+	assert.True(t, ColorEnabled())
+}
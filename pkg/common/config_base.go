@@ -1,6 +1,10 @@
 package common
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -54,3 +58,31 @@ var (
 		SepoliaChainID: "sepolia",       // Sepolia testnet
 	}
 )
+
+// EnvironmentNames returns the names of the environments available in this build, sorted
+func EnvironmentNames() []string {
+	names := make([]string, 0, len(EnvironmentConfigs))
+	for name := range EnvironmentConfigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateEnvironmentName returns an error listing the available environments if name is not one of them
+func ValidateEnvironmentName(name string) error {
+	if _, exists := EnvironmentConfigs[name]; !exists {
+		return fmt.Errorf("unknown environment %q (available: %s)", name, strings.Join(EnvironmentNames(), ", "))
+	}
+	return nil
+}
+
+// ChainIDForEnvironment returns the chain ID an environment is deployed on
+func ChainIDForEnvironment(name string) (uint64, bool) {
+	for chainID, environment := range DefaultEnvironmentForChainID {
+		if environment == name {
+			return chainID, true
+		}
+	}
+	return 0, false
+}
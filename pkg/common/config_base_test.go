@@ -0,0 +1,51 @@
+package common
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestEnvironmentNames(t *testing.T) {
+	names := EnvironmentNames()
+	if len(names) != len(EnvironmentConfigs) {
+		t.Fatalf("got %d names, want %d", len(names), len(EnvironmentConfigs))
+	}
+	if !sort.StringsAreSorted(names) {
+		t.Errorf("names are not sorted: %v", names)
+	}
+}
+
+func TestValidateEnvironmentName(t *testing.T) {
+	for _, name := range EnvironmentNames() {
+		if err := ValidateEnvironmentName(name); err != nil {
+			t.Errorf("ValidateEnvironmentName(%q) = %v, want nil", name, err)
+		}
+	}
+
+	err := ValidateEnvironmentName("sepolai")
+	if err == nil {
+		t.Fatal("expected an error for an unknown environment")
+	}
+	for _, name := range EnvironmentNames() {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("error %q does not list available environment %q", err.Error(), name)
+		}
+	}
+}
+
+func TestSetDefaultEnvironment_RejectsUnknown(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if err := SetDefaultEnvironment("not-an-environment"); err == nil {
+		t.Fatal("expected unknown environment to be rejected")
+	}
+
+	env, err := GetDefaultEnvironment()
+	if err != nil {
+		t.Fatalf("GetDefaultEnvironment: %v", err)
+	}
+	if env != "" {
+		t.Errorf("default environment = %q, want it left unset", env)
+	}
+}
@@ -0,0 +1,91 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common/iface"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// confirmationPollInterval is how often the chain head is checked while waiting for confirmations
+	confirmationPollInterval = 4 * time.Second
+
+	// confirmationTimeoutPerBlock bounds the wait for confirmations, scaled by how many are required
+	confirmationTimeoutPerBlock = time.Minute
+)
+
+// confirmationBackend is the part of the RPC client needed to follow a mined transaction
+type confirmationBackend interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// confirmationCount returns how many blocks, including its own, confirm a transaction mined in block
+func confirmationCount(head, block uint64) uint64 {
+	if head < block {
+		return 0
+	}
+	return head - block + 1
+}
+
+// waitForConfirmations polls until the transaction of receipt is buried under enough blocks to
+// have the required confirmations, following it to a new block if a reorg moves it. RPC errors
+// are retried until ctx is done. It returns the receipt the transaction ended up with.
+func waitForConfirmations(ctx context.Context, backend confirmationBackend, logger iface.Logger, txDescription string, receipt *types.Receipt, confirmations uint64, pollInterval time.Duration) (*types.Receipt, error) {
+	if confirmations <= 1 {
+		return receipt, nil
+	}
+
+	reported := uint64(1)
+	logger.Info("%s transaction: %d/%d confirmations", txDescription, reported, confirmations)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for %d confirmations of %s transaction (hash: %s): %w", confirmations, txDescription, receipt.TxHash.Hex(), ctx.Err())
+		case <-time.After(pollInterval):
+		}
+
+		current, err := backend.TransactionReceipt(ctx, receipt.TxHash)
+		if errors.Is(err, ethereum.NotFound) {
+			if reported != 0 {
+				logger.Warn("%s transaction (hash: %s) was dropped by a reorg, waiting for it to be included again", txDescription, receipt.TxHash.Hex())
+				reported = 0
+			}
+			continue
+		}
+		if err != nil {
+			logger.Debug("Failed to get %s transaction receipt, retrying: %v", txDescription, err)
+			continue
+		}
+		if current.BlockHash != receipt.BlockHash {
+			logger.Warn("%s transaction (hash: %s) moved to block %d after a reorg", txDescription, receipt.TxHash.Hex(), current.BlockNumber.Uint64())
+			receipt = current
+		}
+		if receipt.Status == types.ReceiptStatusFailed {
+			return nil, fmt.Errorf("%s transaction (hash: %s) reverted after a reorg", txDescription, receipt.TxHash.Hex())
+		}
+
+		head, err := backend.HeaderByNumber(ctx, nil)
+		if err != nil {
+			logger.Debug("Failed to get latest block, retrying: %v", err)
+			continue
+		}
+
+		count := min(confirmationCount(head.Number.Uint64(), receipt.BlockNumber.Uint64()), confirmations)
+		if count != reported && count > 0 {
+			logger.Info("%s transaction: %d/%d confirmations", txDescription, count, confirmations)
+			reported = count
+		}
+		if count >= confirmations {
+			return receipt, nil
+		}
+	}
+}
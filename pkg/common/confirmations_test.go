@@ -0,0 +1,105 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common/logger"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConfirmationBackend advances the chain head by one block per poll and serves receipts in order
+type fakeConfirmationBackend struct {
+	head     uint64
+	receipts []*types.Receipt
+	errs     []error
+}
+
+func (f *fakeConfirmationBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	f.head++
+	return &types.Header{Number: new(big.Int).SetUint64(f.head)}, nil
+}
+
+func (f *fakeConfirmationBackend) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	if len(f.errs) > 0 {
+		err := f.errs[0]
+		f.errs = f.errs[1:]
+		if err != nil {
+			return nil, err
+		}
+	}
+	receipt := f.receipts[0]
+	if len(f.receipts) > 1 {
+		f.receipts = f.receipts[1:]
+	}
+	return receipt, nil
+}
+
+func minedReceipt(block uint64, blockHash byte) *types.Receipt {
+	return &types.Receipt{
+		TxHash:      common.Hash{0x01},
+		BlockHash:   common.Hash{blockHash},
+		BlockNumber: new(big.Int).SetUint64(block),
+		Status:      types.ReceiptStatusSuccessful,
+	}
+}
+
+func TestConfirmationCount(t *testing.T) {
+	assert.Equal(t, uint64(1), confirmationCount(100, 100))
+	assert.Equal(t, uint64(3), confirmationCount(102, 100))
+	assert.Equal(t, uint64(0), confirmationCount(99, 100))
+}
+
+func TestWaitForConfirmations(t *testing.T) {
+	t.Run("single confirmation returns immediately", func(t *testing.T) {
+		receipt := minedReceipt(100, 0xaa)
+		got, err := waitForConfirmations(context.Background(), &fakeConfirmationBackend{}, logger.NewNoopLogger(), "Deploy", receipt, 1, time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, receipt, got)
+	})
+
+	t.Run("waits until buried and reports progress", func(t *testing.T) {
+		receipt := minedReceipt(100, 0xaa)
+		backend := &fakeConfirmationBackend{head: 100, receipts: []*types.Receipt{receipt}}
+		log := logger.NewNoopLogger()
+
+		got, err := waitForConfirmations(context.Background(), backend, log, "Deploy", receipt, 3, time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, receipt, got)
+		assert.Equal(t, uint64(102), backend.head)
+
+		assert.Equal(t, []string{"Deploy transaction: 1/3 confirmations", "Deploy transaction: 2/3 confirmations", "Deploy transaction: 3/3 confirmations"}, log.GetMessagesByLevel("INFO"))
+	})
+
+	t.Run("follows the transaction through a reorg", func(t *testing.T) {
+		original := minedReceipt(100, 0xaa)
+		moved := minedReceipt(101, 0xbb)
+		backend := &fakeConfirmationBackend{
+			head:     100,
+			receipts: []*types.Receipt{moved},
+			errs:     []error{ethereum.NotFound, errors.New("rpc unavailable"), nil},
+		}
+
+		got, err := waitForConfirmations(context.Background(), backend, logger.NewNoopLogger(), "Deploy", original, 2, time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, moved, got)
+	})
+
+	t.Run("times out", func(t *testing.T) {
+		receipt := minedReceipt(100, 0xaa)
+		backend := &fakeConfirmationBackend{head: 0, receipts: []*types.Receipt{receipt}}
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err := waitForConfirmations(ctx, backend, logger.NewNoopLogger(), "Deploy", receipt, 1000, time.Millisecond)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -16,6 +16,9 @@ const (
 	// ContractsMakefile is the name of the makefile used for contract level operations
 	ContractsMakefile = "Makefile"
 
+	// DefaultLogFileValue makes --log-file write to the rotating log in the global config directory
+	DefaultLogFileValue = "default"
+
 	// GlobalConfigFile is the name of the global YAML used to store global config details (eg, user_id)
 	GlobalConfigFile = "config.yaml"
 
@@ -25,15 +28,43 @@ const (
 	// Docker open retry interval in milliseconds
 	DockerOpenRetryIntervalMilliseconds = 500
 
+	// RPCTimeoutSeconds bounds each individual RPC call, unless overridden with --rpc-timeout
+	RPCTimeoutSeconds = 30
+
 	// WatchPollIntervalSeconds is the interval between watch loop polls in seconds
 	WatchPollIntervalSeconds = 5
 
+	// UpgradeByWindowSeconds is how long after assembly a release can still be submitted
+	UpgradeByWindowSeconds = 3600
+
+	// UpgradeByRefreshMarginSeconds is how close to its upgrade-by time a release is re-stamped before submission
+	UpgradeByRefreshMarginSeconds = 600
+
+	// HealthTimeoutSeconds is the default time --verify-running waits for the app to answer
+	HealthTimeoutSeconds = 180
+
+	// WatchBackoffMaxIntervalSeconds caps the poll interval when --wait-interval-backoff is set
+	// or the server suggests a longer one
+	WatchBackoffMaxIntervalSeconds = 60
+
+	// WatchSuggestedMinIntervalSeconds is the shortest server-suggested poll interval the watch loop honors
+	WatchSuggestedMinIntervalSeconds = 2
+
 	// Environment variable names
 	MnemonicEnvVar         = "MNEMONIC"                  // Filtered out, overridden by protocol
 	EigenMachineTypeEnvVar = "EIGEN_MACHINE_TYPE_PUBLIC" // Instance type configuration
 	EigenXPrivateKeyEnvVar = "EIGENX_PRIVATE_KEY"        // Private key for authentication
 )
 
+// ReservedEnvVars lists environment variables that the protocol sets for every release,
+// mapped to a short description of where their value comes from. User-provided values
+// for these names are overridden at deploy time. Variables the CLI adds for its own use
+// do not belong here.
+var ReservedEnvVars = map[string]string{
+	MnemonicEnvVar:         "provided by the protocol",
+	EigenMachineTypeEnvVar: "set from the selected instance type",
+}
+
 // API permissions constants
 var (
 	// The permission to view app logs
@@ -52,6 +83,10 @@ var (
 	// bytes4(keccak256("CAN_MANAGE_BILLING()"))
 	CanManageBillingPermission = [4]byte{0xd6, 0xb8, 0x55, 0xa1}
 
+	// The permission to open an interactive exec session in an app
+	// bytes4(keccak256("CAN_EXEC_APP()"))
+	CanExecAppPermission = [4]byte{0x84, 0x79, 0xba, 0xad}
+
 	// The address that is used to allow auth to be bypassed for certain permissions
 	// address(bytes20(keccak256("PermissionController:AnyoneCanCall")))
 	AnyoneCanCallAddress = ethcommon.HexToAddress("0x493219d9949348178af1f58740655951a8cd110c")
@@ -77,6 +112,7 @@ const (
 	AppStatusDeploying   = "Deploying"
 	AppStatusUpgrading   = "Upgrading"
 	AppStatusResuming    = "Resuming"
+	AppStatusStarting    = "Starting" // Started on-chain but not yet running according to the API
 	AppStatusRunning     = "Running"
 	AppStatusStopping    = "Stopping"
 	AppStatusStopped     = "Stopped"
@@ -86,3 +122,20 @@ const (
 	AppStatusFailed      = "Failed"
 	AppStatusExited      = "Exited"
 )
+
+// AppDisplayStatuses lists every status an app is shown with, in lifecycle order
+var AppDisplayStatuses = []string{
+	AppStatusCreated,
+	AppStatusDeploying,
+	AppStatusUpgrading,
+	AppStatusResuming,
+	AppStatusStarting,
+	AppStatusRunning,
+	AppStatusStopping,
+	AppStatusStopped,
+	AppStatusSuspended,
+	AppStatusTerminating,
+	AppStatusTerminated,
+	AppStatusFailed,
+	AppStatusExited,
+}
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"context"
 	"crypto/ecdsa"
+	"errors"
 	"fmt"
 	"math/big"
 	"strings"
+	"time"
 
 	erc7702delegatorV2 "github.com/Layr-Labs/eigenx-cli/internal/bindings/EIP7702StatelessDeleGator"
 	"github.com/Layr-Labs/eigenx-cli/pkg/common/iface"
@@ -20,7 +22,6 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/holiman/uint256"
 )
 
@@ -36,7 +37,7 @@ var (
 
 // ContractCaller provides a high-level interface for interacting with contracts
 type ContractCaller struct {
-	ethclient                   *ethclient.Client
+	ethclient                   *FailoverClient
 	privateKey                  *ecdsa.PrivateKey
 	chainID                     *big.Int
 	logger                      iface.Logger
@@ -44,10 +45,14 @@ type ContractCaller struct {
 	appControllerBinding        *appcontrollerV2.AppController
 	permissionControllerBinding *permissioncontrollerV2.IPermissionController
 	erc7702DelegatorBinding     *erc7702delegatorV2.EIP7702StatelessDeleGator
+	rpcTimeout                  time.Duration
+	reuseDelegationCheck        bool
+	confirmations               uint64
+	replacePending              func() (bool, error)
 	SelfAddress                 common.Address
 }
 
-func NewContractCaller(privateKeyHex string, chainID *big.Int, environmentConfig EnvironmentConfig, client *ethclient.Client, logger iface.Logger) (*ContractCaller, error) {
+func NewContractCaller(privateKeyHex string, chainID *big.Int, environmentConfig EnvironmentConfig, client *FailoverClient, logger iface.Logger) (*ContractCaller, error) {
 	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(privateKeyHex, "0x"))
 	if err != nil {
 		return nil, fmt.Errorf("invalid private key: %w", err)
@@ -63,30 +68,81 @@ func NewContractCaller(privateKeyHex string, chainID *big.Int, environmentConfig
 		appControllerBinding:        appcontrollerV2.NewAppController(),
 		permissionControllerBinding: permissioncontrollerV2.NewIPermissionController(),
 		erc7702DelegatorBinding:     erc7702delegatorV2.NewEIP7702StatelessDeleGator(),
+		rpcTimeout:                  RPCTimeoutSeconds * time.Second,
+		confirmations:               1,
 		SelfAddress:                 SelfAddress,
 	}, nil
 }
 
+// SetRPCTimeout sets the deadline applied to each individual RPC call (0 for no limit).
+// Waiting for a transaction to be mined is not bounded by it.
+func (cc *ContractCaller) SetRPCTimeout(timeout time.Duration) {
+	cc.rpcTimeout = timeout
+}
+
+// SetReuseDelegationCheck makes ExecuteBatch trust a delegation confirmed within
+// DelegationCacheTTL instead of checking it onchain before every transaction
+func (cc *ContractCaller) SetReuseDelegationCheck(reuse bool) {
+	cc.reuseDelegationCheck = reuse
+}
+
+// SetConfirmations sets how many blocks, including the one it is mined in, must confirm a
+// transaction before it counts as successful. Values below 1 mean 1.
+func (cc *ContractCaller) SetConfirmations(confirmations int) {
+	cc.confirmations = uint64(max(confirmations, 1))
+}
+
+// SetReplacePending sets the callback asked, when earlier transactions of the account are still
+// pending, whether to speed up the oldest one by re-sending it at a higher gas price. Without a
+// callback the next transaction queues behind them.
+func (cc *ContractCaller) SetReplacePending(replacePending func() (bool, error)) {
+	cc.replacePending = replacePending
+}
+
+// rpcContext derives the context for a single RPC call
+func (cc *ContractCaller) rpcContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return RPCContext(ctx, cc.rpcTimeout)
+}
+
 // DeployApp creates a new app via AppController contract, accepts admin permissions, and upgrades the app
-func (cc *ContractCaller) DeployApp(ctx context.Context, salt [32]byte, release appcontrollerV2.IAppControllerRelease, publicLogs bool, imageRef string) (appID common.Address, err error) {
+func (cc *ContractCaller) DeployApp(ctx context.Context, salt [32]byte, release appcontrollerV2.IAppControllerRelease, publicLogs bool, imageRef string) (appID common.Address, txHash common.Hash, err error) {
+	appAddress, executions, err := cc.deployExecutions(ctx, salt, release, publicLogs)
+	if err != nil {
+		return common.Address{}, common.Hash{}, err
+	}
+
+	// Prepare confirmation and pending messages
+	confirmationPrompt := fmt.Sprintf("Deploy new app with image: %s", imageRef)
+	pendingMessage := "Deploying new app..."
+
+	txHash, err = cc.ExecuteBatch(ctx, executions, cc.isMainnet(), confirmationPrompt, pendingMessage)
+	return appAddress, txHash, err
+}
+
+// deployExecutions returns the address of the app salt creates and the executions that create
+// it, accept its admin role and, if requested, make its logs public
+func (cc *ContractCaller) deployExecutions(ctx context.Context, salt [32]byte, release appcontrollerV2.IAppControllerRelease, publicLogs bool) (common.Address, []erc7702delegatorV2.Execution, error) {
+	release = cc.refreshUpgradeByTime(release)
 	createData, err := cc.appControllerBinding.TryPackCreateApp(salt, release)
 	if err != nil {
-		return common.Address{}, fmt.Errorf("failed to pack create app: %w", err)
+		return common.Address{}, nil, fmt.Errorf("failed to pack create app: %w", err)
 	}
 
 	appController, err := appcontrollerV1.NewAppController(cc.environmentConfig.AppControllerAddress, cc.ethclient)
 	if err != nil {
-		return common.Address{}, fmt.Errorf("failed to create app controller: %w", err)
+		return common.Address{}, nil, fmt.Errorf("failed to create app controller: %w", err)
 	}
 
-	appAddress, err := appController.CalculateAppId(&bind.CallOpts{Context: ctx}, cc.SelfAddress, salt)
+	callCtx, cancel := cc.rpcContext(ctx)
+	appAddress, err := appController.CalculateAppId(&bind.CallOpts{Context: callCtx}, cc.SelfAddress, salt)
+	cancel()
 	if err != nil {
-		return common.Address{}, fmt.Errorf("failed to calculate app id: %w", err)
+		return common.Address{}, nil, fmt.Errorf("failed to calculate app id: %w", err)
 	}
 
 	acceptAdminData, err := cc.permissionControllerBinding.TryPackAcceptAdmin(appAddress)
 	if err != nil {
-		return common.Address{}, fmt.Errorf("failed to pack accept admin: %w", err)
+		return common.Address{}, nil, fmt.Errorf("failed to pack accept admin: %w", err)
 	}
 
 	// assemble executions
@@ -107,7 +163,7 @@ func (cc *ContractCaller) DeployApp(ctx context.Context, salt [32]byte, release
 	if publicLogs {
 		anyoneCanViewLogsData, err := cc.permissionControllerBinding.TryPackSetAppointee(appAddress, AnyoneCanCallAddress, ApiPermissionsTarget, CanViewAppLogsPermission)
 		if err != nil {
-			return common.Address{}, fmt.Errorf("failed to pack anyone can view logs: %w", err)
+			return common.Address{}, nil, fmt.Errorf("failed to pack anyone can view logs: %w", err)
 		}
 		executions = append(executions, erc7702delegatorV2.Execution{
 			Target:   cc.environmentConfig.PermissionControllerAddress,
@@ -116,18 +172,37 @@ func (cc *ContractCaller) DeployApp(ctx context.Context, salt [32]byte, release
 		})
 	}
 
+	return appAddress, executions, nil
+}
+
+// UpgradeApp upgrades an app via AppController contract
+func (cc *ContractCaller) UpgradeApp(ctx context.Context, appAddress common.Address, release appcontrollerV2.IAppControllerRelease, publicLogs bool, needsPermissionChange bool, imageRef string) (common.Hash, error) {
+	executions, err := cc.upgradeExecutions(appAddress, release, publicLogs, needsPermissionChange)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
 	// Prepare confirmation and pending messages
-	confirmationPrompt := fmt.Sprintf("Deploy new app with image: %s", imageRef)
-	pendingMessage := "Deploying new app..."
+	appName := GetAppName(cc.environmentConfig.Name, appAddress.Hex())
 
-	return appAddress, cc.ExecuteBatch(ctx, executions, cc.isMainnet(), confirmationPrompt, pendingMessage)
+	confirmationPrompt := "Upgrade app"
+	pendingMessage := "Upgrading app..."
+	if appName != "" {
+		confirmationPrompt = fmt.Sprintf("%s '%s'", confirmationPrompt, appName)
+		pendingMessage = fmt.Sprintf("Upgrading app '%s'...", appName)
+	}
+	confirmationPrompt = fmt.Sprintf("%s with image: %s", confirmationPrompt, imageRef)
+
+	return cc.ExecuteBatch(ctx, executions, cc.isMainnet(), confirmationPrompt, pendingMessage)
 }
 
-// UpgradeApp upgrades an app via AppController contract
-func (cc *ContractCaller) UpgradeApp(ctx context.Context, appAddress common.Address, release appcontrollerV2.IAppControllerRelease, publicLogs bool, needsPermissionChange bool, imageRef string) error {
+// upgradeExecutions returns the executions that upgrade an app and, if needed, change who can
+// view its logs
+func (cc *ContractCaller) upgradeExecutions(appAddress common.Address, release appcontrollerV2.IAppControllerRelease, publicLogs bool, needsPermissionChange bool) ([]erc7702delegatorV2.Execution, error) {
+	release = cc.refreshUpgradeByTime(release)
 	upgradeData, err := cc.appControllerBinding.TryPackUpgradeApp(appAddress, release)
 	if err != nil {
-		return fmt.Errorf("failed to pack upgrade app: %w", err)
+		return nil, fmt.Errorf("failed to pack upgrade app: %w", err)
 	}
 
 	// Start with upgrade execution
@@ -141,43 +216,147 @@ func (cc *ContractCaller) UpgradeApp(ctx context.Context, appAddress common.Addr
 
 	// Add permission transaction if needed
 	if needsPermissionChange {
-		if publicLogs {
-			// Add public permission (private→public)
-			addLogsData, err := cc.permissionControllerBinding.TryPackSetAppointee(appAddress, AnyoneCanCallAddress, ApiPermissionsTarget, CanViewAppLogsPermission)
+		execution, err := cc.logPermissionExecution(appAddress, publicLogs)
+		if err != nil {
+			return nil, err
+		}
+		executions = append(executions, execution)
+	}
+
+	return executions, nil
+}
+
+// AppRelease is one app created or upgraded by ReleaseApps
+type AppRelease struct {
+	// AppID is the app to upgrade, or for a new app the address Salt creates it at
+	AppID   common.Address
+	Create  bool
+	Salt    [32]byte
+	Release appcontrollerV2.IAppControllerRelease
+	// PublicLogs makes the app's logs public; upgrades only change it with PermissionChange
+	PublicLogs       bool
+	PermissionChange bool
+}
+
+// ReleaseApps creates and upgrades several apps in a single batched transaction
+func (cc *ContractCaller) ReleaseApps(ctx context.Context, apps []AppRelease) (common.Hash, error) {
+	if len(apps) == 0 {
+		return common.Hash{}, fmt.Errorf("no apps to release")
+	}
+
+	var executions []erc7702delegatorV2.Execution
+	created := 0
+	for _, app := range apps {
+		if !app.Create {
+			appExecutions, err := cc.upgradeExecutions(app.AppID, app.Release, app.PublicLogs, app.PermissionChange)
 			if err != nil {
-				return fmt.Errorf("failed to pack add logs permission: %w", err)
+				return common.Hash{}, fmt.Errorf("app %s: %w", app.AppID.Hex(), err)
 			}
-			executions = append(executions, erc7702delegatorV2.Execution{
-				Target:   cc.environmentConfig.PermissionControllerAddress,
-				Value:    big.NewInt(0),
-				CallData: addLogsData,
-			})
+			executions = append(executions, appExecutions...)
+			continue
+		}
+
+		appAddress, appExecutions, err := cc.deployExecutions(ctx, app.Salt, app.Release, app.PublicLogs)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("app %s: %w", app.AppID.Hex(), err)
+		}
+		// The release encrypts the env for AppID, so it must be where the app is created
+		if appAddress != app.AppID {
+			return common.Hash{}, fmt.Errorf("salt creates app %s, not %s", appAddress.Hex(), app.AppID.Hex())
+		}
+		executions = append(executions, appExecutions...)
+		created++
+	}
+
+	// Prepare confirmation and pending messages
+	confirmationPrompt := fmt.Sprintf("Deploy %d new app(s) and upgrade %d app(s)", created, len(apps)-created)
+	pendingMessage := fmt.Sprintf("Releasing %d app(s)...", len(apps))
+
+	return cc.ExecuteBatch(ctx, executions, cc.isMainnet(), confirmationPrompt, pendingMessage)
+}
+
+// NewUpgradeByTime returns the upgrade-by time of a release assembled at now
+func NewUpgradeByTime(now time.Time) uint32 {
+	return uint32(now.Add(UpgradeByWindowSeconds * time.Second).Unix())
+}
+
+// refreshUpgradeByTime re-stamps a release whose upgrade-by time is about to lapse, e.g. after
+// a slow build or push, so the transaction isn't rejected for a stale deadline
+func (cc *ContractCaller) refreshUpgradeByTime(release appcontrollerV2.IAppControllerRelease) appcontrollerV2.IAppControllerRelease {
+	now := time.Now()
+	upgradeByTime, refreshed := upgradeByTimeForSubmission(release.RmsRelease.UpgradeByTime, now)
+	if refreshed {
+		remaining := time.Unix(int64(release.RmsRelease.UpgradeByTime), 0).Sub(now).Round(time.Second)
+		if remaining > 0 {
+			cc.logger.Warn("Release upgrade-by time is only %s away, refreshing it to %s", remaining, time.Unix(int64(upgradeByTime), 0).Format(time.RFC3339))
 		} else {
-			// Remove public permission (public→private)
-			removeLogsData, err := cc.permissionControllerBinding.TryPackRemoveAppointee(appAddress, AnyoneCanCallAddress, ApiPermissionsTarget, CanViewAppLogsPermission)
-			if err != nil {
-				return fmt.Errorf("failed to pack remove logs permission: %w", err)
-			}
-			executions = append(executions, erc7702delegatorV2.Execution{
-				Target:   cc.environmentConfig.PermissionControllerAddress,
-				Value:    big.NewInt(0),
-				CallData: removeLogsData,
-			})
+			cc.logger.Warn("Release upgrade-by time lapsed %s ago, refreshing it to %s", -remaining, time.Unix(int64(upgradeByTime), 0).Format(time.RFC3339))
 		}
+		release.RmsRelease.UpgradeByTime = upgradeByTime
+	}
+	return release
+}
+
+// upgradeByTimeForSubmission returns the upgrade-by time to submit a release with at now: the
+// assembled one while more than UpgradeByRefreshMarginSeconds of it is left, otherwise a new one
+func upgradeByTimeForSubmission(upgradeByTime uint32, now time.Time) (uint32, bool) {
+	if time.Unix(int64(upgradeByTime), 0).Sub(now) > UpgradeByRefreshMarginSeconds*time.Second {
+		return upgradeByTime, false
+	}
+	return NewUpgradeByTime(now), true
+}
+
+// SetLogVisibility makes an app's logs public or private without upgrading it
+func (cc *ContractCaller) SetLogVisibility(ctx context.Context, appAddress common.Address, publicLogs bool) error {
+	execution, err := cc.logPermissionExecution(appAddress, publicLogs)
+	if err != nil {
+		return err
+	}
+
+	visibility := "private"
+	if publicLogs {
+		visibility = "public"
 	}
 
 	// Prepare confirmation and pending messages
 	appName := GetAppName(cc.environmentConfig.Name, appAddress.Hex())
 
-	confirmationPrompt := "Upgrade app"
-	pendingMessage := "Upgrading app..."
+	confirmationPrompt := fmt.Sprintf("Make logs %s for app", visibility)
+	pendingMessage := fmt.Sprintf("Making logs %s...", visibility)
 	if appName != "" {
 		confirmationPrompt = fmt.Sprintf("%s '%s'", confirmationPrompt, appName)
-		pendingMessage = fmt.Sprintf("Upgrading app '%s'...", appName)
 	}
-	confirmationPrompt = fmt.Sprintf("%s with image: %s", confirmationPrompt, imageRef)
 
-	return cc.ExecuteBatch(ctx, executions, cc.isMainnet(), confirmationPrompt, pendingMessage)
+	_, err = cc.ExecuteBatch(ctx, []erc7702delegatorV2.Execution{execution}, cc.isMainnet(), confirmationPrompt, pendingMessage)
+	return err
+}
+
+// logPermissionExecution returns the PermissionController call that lets anyone view an app's
+// logs (publicLogs) or revokes that permission
+func (cc *ContractCaller) logPermissionExecution(appAddress common.Address, publicLogs bool) (erc7702delegatorV2.Execution, error) {
+	if publicLogs {
+		// Add public permission (private→public)
+		addLogsData, err := cc.permissionControllerBinding.TryPackSetAppointee(appAddress, AnyoneCanCallAddress, ApiPermissionsTarget, CanViewAppLogsPermission)
+		if err != nil {
+			return erc7702delegatorV2.Execution{}, fmt.Errorf("failed to pack add logs permission: %w", err)
+		}
+		return erc7702delegatorV2.Execution{
+			Target:   cc.environmentConfig.PermissionControllerAddress,
+			Value:    big.NewInt(0),
+			CallData: addLogsData,
+		}, nil
+	}
+
+	// Remove public permission (public→private)
+	removeLogsData, err := cc.permissionControllerBinding.TryPackRemoveAppointee(appAddress, AnyoneCanCallAddress, ApiPermissionsTarget, CanViewAppLogsPermission)
+	if err != nil {
+		return erc7702delegatorV2.Execution{}, fmt.Errorf("failed to pack remove logs permission: %w", err)
+	}
+	return erc7702delegatorV2.Execution{
+		Target:   cc.environmentConfig.PermissionControllerAddress,
+		Value:    big.NewInt(0),
+		CallData: removeLogsData,
+	}, nil
 }
 
 // StartApp starts a stopped app via AppController contract
@@ -203,7 +382,8 @@ func (cc *ContractCaller) StartApp(ctx context.Context, appAddress common.Addres
 		pendingMessage = fmt.Sprintf("Starting app '%s'...", appName)
 	}
 
-	return cc.SendAndWaitForTransaction(ctx, "StartApp", callMsg, cc.isMainnet(), confirmationPrompt, pendingMessage)
+	_, err = cc.SendAndWaitForTransaction(ctx, "StartApp", callMsg, cc.isMainnet(), confirmationPrompt, pendingMessage)
+	return err
 }
 
 // StopApp stops a running app via AppController contract
@@ -229,7 +409,8 @@ func (cc *ContractCaller) StopApp(ctx context.Context, appAddress common.Address
 		pendingMessage = fmt.Sprintf("Stopping app '%s'...", appName)
 	}
 
-	return cc.SendAndWaitForTransaction(ctx, "StopApp", callMsg, cc.isMainnet(), confirmationPrompt, pendingMessage)
+	_, err = cc.SendAndWaitForTransaction(ctx, "StopApp", callMsg, cc.isMainnet(), confirmationPrompt, pendingMessage)
+	return err
 }
 
 // TerminateApp terminates an app permanently via AppController contract
@@ -248,7 +429,7 @@ func (cc *ContractCaller) TerminateApp(ctx context.Context, appAddress common.Ad
 	// Prepare confirmation and pending messages
 	appName := GetAppName(cc.environmentConfig.Name, appAddress.Hex())
 
-	confirmationPrompt := "⚠️  \033[1mPermanently\033[0m destroy app"
+	confirmationPrompt := fmt.Sprintf("⚠️  %s destroy app", Style("Permanently", StyleBold))
 	pendingMessage := "Terminating app..."
 	if appName != "" {
 		confirmationPrompt = fmt.Sprintf("%s '%s'", confirmationPrompt, appName)
@@ -256,7 +437,36 @@ func (cc *ContractCaller) TerminateApp(ctx context.Context, appAddress common.Ad
 	}
 
 	// Note: Terminate always needs confirmation unless force is specified
-	return cc.SendAndWaitForTransaction(ctx, "TerminateApp", callMsg, !force, confirmationPrompt, pendingMessage)
+	_, err = cc.SendAndWaitForTransaction(ctx, "TerminateApp", callMsg, !force, confirmationPrompt, pendingMessage)
+	return err
+}
+
+// TerminateApps permanently terminates multiple apps in a single batched transaction
+func (cc *ContractCaller) TerminateApps(ctx context.Context, appAddresses []common.Address, force bool) error {
+	if len(appAddresses) == 0 {
+		return fmt.Errorf("no apps to terminate")
+	}
+
+	executions := make([]erc7702delegatorV2.Execution, 0, len(appAddresses))
+	for _, appAddress := range appAddresses {
+		data, err := cc.appControllerBinding.TryPackTerminateApp(appAddress)
+		if err != nil {
+			return fmt.Errorf("failed to pack terminate app %s: %w", appAddress.Hex(), err)
+		}
+		executions = append(executions, erc7702delegatorV2.Execution{
+			Target:   cc.environmentConfig.AppControllerAddress,
+			Value:    big.NewInt(0),
+			CallData: data,
+		})
+	}
+
+	// Prepare confirmation and pending messages
+	confirmationPrompt := fmt.Sprintf("⚠️  %s destroy %d app(s)", Style("Permanently", StyleBold), len(appAddresses))
+	pendingMessage := fmt.Sprintf("Terminating %d app(s)...", len(appAddresses))
+
+	// Note: Terminate always needs confirmation unless force is specified
+	_, err := cc.ExecuteBatch(ctx, executions, !force, confirmationPrompt, pendingMessage)
+	return err
 }
 
 // GetActiveAppCount returns the number of active apps (STARTED or STOPPED) for a user
@@ -266,7 +476,10 @@ func (cc *ContractCaller) GetActiveAppCount(ctx context.Context, user common.Add
 		return 0, fmt.Errorf("failed to create app controller: %w", err)
 	}
 
-	count, err := appController.GetActiveAppCount(&bind.CallOpts{Context: ctx}, user)
+	callCtx, cancel := cc.rpcContext(ctx)
+	defer cancel()
+
+	count, err := appController.GetActiveAppCount(&bind.CallOpts{Context: callCtx}, user)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get active app count: %w", err)
 	}
@@ -281,7 +494,10 @@ func (cc *ContractCaller) GetMaxActiveAppsPerUser(ctx context.Context, user comm
 		return 0, fmt.Errorf("failed to create app controller: %w", err)
 	}
 
-	quota, err := appController.GetMaxActiveAppsPerUser(&bind.CallOpts{Context: ctx}, user)
+	callCtx, cancel := cc.rpcContext(ctx)
+	defer cancel()
+
+	quota, err := appController.GetMaxActiveAppsPerUser(&bind.CallOpts{Context: callCtx}, user)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get max active apps: %w", err)
 	}
@@ -289,6 +505,67 @@ func (cc *ContractCaller) GetMaxActiveAppsPerUser(ctx context.Context, user comm
 	return quota, nil
 }
 
+// ErrNoReleases is returned by GetLatestRelease for apps that were never deployed
+var ErrNoReleases = errors.New("no releases")
+
+// GetLatestRelease returns the release most recently published for an app, read from the
+// AppUpgraded event emitted in the app's latest release block
+func (cc *ContractCaller) GetLatestRelease(ctx context.Context, appAddress common.Address) (*appcontrollerV1.IAppControllerRelease, error) {
+	appController, err := appcontrollerV1.NewAppController(cc.environmentConfig.AppControllerAddress, cc.ethclient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create app controller: %w", err)
+	}
+
+	callCtx, cancel := cc.rpcContext(ctx)
+	blockNumber, err := appController.GetAppLatestReleaseBlockNumber(&bind.CallOpts{Context: callCtx}, appAddress)
+	cancel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest release block number: %w", err)
+	}
+	if blockNumber == 0 {
+		return nil, fmt.Errorf("app %s has %w", appAddress.Hex(), ErrNoReleases)
+	}
+
+	block := uint64(blockNumber)
+	callCtx, cancel = cc.rpcContext(ctx)
+	defer cancel()
+
+	iter, err := appController.FilterAppUpgraded(&bind.FilterOpts{Start: block, End: &block, Context: callCtx}, []common.Address{appAddress})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query release events: %w", err)
+	}
+	defer iter.Close()
+
+	var release *appcontrollerV1.IAppControllerRelease
+	for iter.Next() {
+		release = &iter.Event.Release
+	}
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("failed to read release events: %w", err)
+	}
+	if release == nil {
+		return nil, fmt.Errorf("no release found for app %s in block %d", appAddress.Hex(), block)
+	}
+
+	return release, nil
+}
+
+// GetAppStatus returns the onchain status of an app
+func (cc *ContractCaller) GetAppStatus(ctx context.Context, appAddress common.Address) (AppStatus, error) {
+	appController, err := appcontrollerV1.NewAppController(cc.environmentConfig.AppControllerAddress, cc.ethclient)
+	if err != nil {
+		return ContractAppStatusNone, fmt.Errorf("failed to create app controller: %w", err)
+	}
+
+	callCtx, cancel := cc.rpcContext(ctx)
+	defer cancel()
+	status, err := appController.GetAppStatus(&bind.CallOpts{Context: callCtx}, appAddress)
+	if err != nil {
+		return ContractAppStatusNone, fmt.Errorf("failed to get app status: %w", err)
+	}
+	return AppStatus(status), nil
+}
+
 // GetAppsByCreator retrieves a paginated list of apps created by the specified address
 func (cc *ContractCaller) GetAppsByCreator(ctx context.Context, creator common.Address, offset uint64, limit uint64) ([]common.Address, []appcontrollerV1.IAppControllerAppConfig, error) {
 	appController, err := appcontrollerV1.NewAppController(cc.environmentConfig.AppControllerAddress, cc.ethclient)
@@ -296,8 +573,11 @@ func (cc *ContractCaller) GetAppsByCreator(ctx context.Context, creator common.A
 		return nil, nil, fmt.Errorf("failed to create app controller: %w", err)
 	}
 
+	callCtx, cancel := cc.rpcContext(ctx)
+	defer cancel()
+
 	result, err := appController.GetAppsByCreator(
-		&bind.CallOpts{Context: ctx},
+		&bind.CallOpts{Context: callCtx},
 		creator,
 		new(big.Int).SetUint64(offset),
 		new(big.Int).SetUint64(limit),
@@ -309,6 +589,48 @@ func (cc *ContractCaller) GetAppsByCreator(ctx context.Context, creator common.A
 	return result.Apps, result.AppConfigsMem, nil
 }
 
+// appsByCreatorPageSize is the number of apps requested per GetAppsByCreator call
+const appsByCreatorPageSize = 50
+
+// getAllAppsByCreator pages through GetAppsByCreator and returns every app of creator with its config
+func (cc *ContractCaller) getAllAppsByCreator(ctx context.Context, creator common.Address) ([]common.Address, []appcontrollerV1.IAppControllerAppConfig, error) {
+	var apps []common.Address
+	var configs []appcontrollerV1.IAppControllerAppConfig
+	for offset := uint64(0); ; offset += appsByCreatorPageSize {
+		page, pageConfigs, err := cc.GetAppsByCreator(ctx, creator, offset, appsByCreatorPageSize)
+		if err != nil {
+			return nil, nil, err
+		}
+		apps = append(apps, page...)
+		configs = append(configs, pageConfigs...)
+		if len(page) < appsByCreatorPageSize {
+			return apps, configs, nil
+		}
+	}
+}
+
+// canCallApp reports whether this account may make the call in data on the AppController for
+// app, which AppController checks with the PermissionController before acting on an app
+func (cc *ContractCaller) canCallApp(ctx context.Context, app common.Address, data []byte) (bool, error) {
+	var selector [4]byte
+	copy(selector[:], data)
+
+	callCtx, cancel := cc.rpcContext(ctx)
+	defer cancel()
+	result, err := cc.ethclient.CallContract(callCtx, ethereum.CallMsg{
+		To:   &cc.environmentConfig.PermissionControllerAddress,
+		Data: cc.permissionControllerBinding.PackCanCall(app, cc.SelfAddress, cc.environmentConfig.AppControllerAddress, selector),
+	}, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to check permission: %w", err)
+	}
+	canCall, err := cc.permissionControllerBinding.UnpackCanCall(result)
+	if err != nil {
+		return false, fmt.Errorf("failed to unpack result: %w", err)
+	}
+	return canCall, nil
+}
+
 // Suspend suspends all active apps for an account and sets their max active apps to 0
 func (cc *ContractCaller) Suspend(ctx context.Context, account common.Address, apps []common.Address) error {
 	data, err := cc.appControllerBinding.TryPackSuspend(account, apps)
@@ -326,14 +648,82 @@ func (cc *ContractCaller) Suspend(ctx context.Context, account common.Address, a
 	pendingMessage := fmt.Sprintf("Suspending %d app(s)...", len(apps))
 	confirmationPrompt := fmt.Sprintf("Suspend %d app(s) for account %s", len(apps), account.Hex())
 
-	return cc.SendAndWaitForTransaction(ctx, "Suspend", callMsg, cc.isMainnet(), confirmationPrompt, pendingMessage)
+	_, err = cc.SendAndWaitForTransaction(ctx, "Suspend", callMsg, cc.isMainnet(), confirmationPrompt, pendingMessage)
+	return err
+}
+
+// Unsuspend restores an account's active app quota and restarts its suspended apps.
+// It is the admin counterpart to Suspend; the quota update and app restarts are sent as a single batch.
+// At most maxApps suspended apps are restarted so the account stays within its restored quota.
+// Apps the signer has no permission to start are left for their owners to start, as one failing
+// start would revert the quota update with it.
+func (cc *ContractCaller) Unsuspend(ctx context.Context, account common.Address, maxApps uint32) ([]common.Address, error) {
+	setQuotaData, err := cc.appControllerBinding.TryPackSetMaxActiveAppsPerUser(account, maxApps)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack set max active apps: %w", err)
+	}
+
+	executions := []erc7702delegatorV2.Execution{
+		{
+			Target:   cc.environmentConfig.AppControllerAddress,
+			Value:    big.NewInt(0),
+			CallData: setQuotaData,
+		},
+	}
+
+	apps, configs, err := cc.getAllAppsByCreator(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+
+	var restarted []common.Address
+	for i, app := range apps {
+		if AppStatus(configs[i].Status) != ContractAppStatusSuspended {
+			continue
+		}
+		if uint32(len(restarted)) >= maxApps {
+			cc.logger.Warn("Quota of %d reached, leaving app %s suspended", maxApps, app.Hex())
+			continue
+		}
+
+		startData, err := cc.appControllerBinding.TryPackStartApp(app)
+		if err != nil {
+			return nil, fmt.Errorf("failed to pack start app %s: %w", app.Hex(), err)
+		}
+		canStart, err := cc.canCallApp(ctx, app, startData)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check permission to start app %s: %w", app.Hex(), err)
+		}
+		if !canStart {
+			cc.logger.Warn("No permission to start app %s, leaving it for its owner to start", app.Hex())
+			continue
+		}
+		executions = append(executions, erc7702delegatorV2.Execution{
+			Target:   cc.environmentConfig.AppControllerAddress,
+			Value:    big.NewInt(0),
+			CallData: startData,
+		})
+		restarted = append(restarted, app)
+	}
+
+	// Prepare messages
+	pendingMessage := fmt.Sprintf("Unsuspending account and restarting %d app(s)...", len(restarted))
+	confirmationPrompt := fmt.Sprintf("Restore quota to %d and restart %d app(s) for account %s", maxApps, len(restarted), account.Hex())
+
+	if _, err := cc.ExecuteBatch(ctx, executions, cc.isMainnet(), confirmationPrompt, pendingMessage); err != nil {
+		return restarted, err
+	}
+	return restarted, nil
 }
 
 // EIP 7702 Utility Functions
 
 // CheckERC7702Delegation checks if the given account already delegates to the ERC-7702 delegator
 func (cc *ContractCaller) CheckERC7702Delegation(ctx context.Context, account common.Address) (bool, error) {
-	code, err := cc.ethclient.CodeAt(ctx, account, nil)
+	callCtx, cancel := cc.rpcContext(ctx)
+	defer cancel()
+
+	code, err := cc.ethclient.CodeAt(callCtx, account, nil)
 	if err != nil {
 		return false, fmt.Errorf("failed to get account code: %w", err)
 	}
@@ -360,14 +750,23 @@ func (cc *ContractCaller) Undelegate(ctx context.Context) error {
 	confirmationPrompt := "Undelegate account (removes EIP-7702 delegation)"
 	pendingMessage := "Undelegating account..."
 
-	return cc.SendAndWaitForTransaction(ctx, "Undelegate", callMsg, cc.isMainnet(), confirmationPrompt, pendingMessage)
+	_, err = cc.SendAndWaitForTransaction(ctx, "Undelegate", callMsg, cc.isMainnet(), confirmationPrompt, pendingMessage)
+	if err != nil {
+		return err
+	}
+
+	// A cached delegation would otherwise let --reuse-delegation-check skip re-delegating
+	if err := ForgetDelegation(cc.environmentConfig.Name, cc.SelfAddress.Hex()); err != nil {
+		cc.logger.Debug("Failed to clear cached delegation: %v", err)
+	}
+	return nil
 }
 
 // ExecuteBatch executes a batch of executions. It sets the code of the EOA to the delegator contract if not already set.
-func (cc *ContractCaller) ExecuteBatch(ctx context.Context, executions []erc7702delegatorV2.Execution, needsConfirmation bool, confirmationPrompt string, pendingMessage string) error {
+func (cc *ContractCaller) ExecuteBatch(ctx context.Context, executions []erc7702delegatorV2.Execution, needsConfirmation bool, confirmationPrompt string, pendingMessage string) (common.Hash, error) {
 	encodedExecutions, err := EncodeExecutions(executions)
 	if err != nil {
-		return fmt.Errorf("failed to encode executions: %w", err)
+		return common.Hash{}, fmt.Errorf("failed to encode executions: %w", err)
 	}
 	data := cc.erc7702DelegatorBinding.PackExecute0(executeBatchMode, encodedExecutions)
 	callMsg := ethereum.CallMsg{
@@ -375,28 +774,90 @@ func (cc *ContractCaller) ExecuteBatch(ctx context.Context, executions []erc7702
 		Data: data,
 	}
 
+	// A recently confirmed delegation lets the transaction be prepared without waiting for the
+	// onchain check, which runs alongside the gas estimate and must pass before anything is sent
+	environment, account := cc.environmentConfig.Name, cc.SelfAddress.Hex()
+	if cc.reuseDelegationCheck && IsDelegationCached(environment, account, time.Now()) {
+		cc.logger.Debug("%s was confirmed as delegated recently, checking it alongside the gas estimate", account)
+		txHash, err := cc.sendBatch(ctx, callMsg, true, cc.checkDelegationAsync(ctx), needsConfirmation, confirmationPrompt, pendingMessage)
+		if !errors.Is(err, errDelegationLost) {
+			return txHash, err
+		}
+		if forgetErr := ForgetDelegation(environment, account); forgetErr != nil {
+			cc.logger.Debug("Failed to clear cached delegation: %v", forgetErr)
+		}
+		cc.logger.Warn("Account %s is no longer delegated, adding a new authorization", account)
+		return cc.sendBatch(ctx, callMsg, false, nil, needsConfirmation, confirmationPrompt, pendingMessage)
+	}
+
+	isDelegated, err := cc.checkAndCacheDelegation(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return cc.sendBatch(ctx, callMsg, isDelegated, nil, needsConfirmation, confirmationPrompt, pendingMessage)
+}
+
+// errDelegationLost is returned when an account cached as delegated is no longer delegated
+var errDelegationLost = errors.New("account is no longer delegated")
+
+// checkDelegationAsync starts checking the delegation onchain and returns a function that waits
+// for the result: nil when the account is still delegated, which refreshes the cache, and
+// errDelegationLost when it is not
+func (cc *ContractCaller) checkDelegationAsync(ctx context.Context) func() error {
+	result := make(chan error, 1)
+	go func() {
+		isDelegated, err := cc.checkAndCacheDelegation(ctx)
+		switch {
+		case err != nil:
+			result <- err
+		case !isDelegated:
+			result <- errDelegationLost
+		default:
+			result <- nil
+		}
+	}()
+	return func() error { return <-result }
+}
+
+// checkAndCacheDelegation checks the delegation onchain, caching a confirmed delegation when
+// --reuse-delegation-check is set
+func (cc *ContractCaller) checkAndCacheDelegation(ctx context.Context) (bool, error) {
 	isDelegated, err := cc.CheckERC7702Delegation(ctx, cc.SelfAddress)
 	if err != nil {
-		return fmt.Errorf("failed to check delegation status: %w", err)
+		return false, fmt.Errorf("failed to check delegation status: %w", err)
+	}
+	if isDelegated && cc.reuseDelegationCheck {
+		if err := CacheDelegation(cc.environmentConfig.Name, cc.SelfAddress.Hex(), time.Now()); err != nil {
+			cc.logger.Debug("Failed to cache delegation status: %v", err)
+		}
 	}
+	return isDelegated, nil
+}
 
+// sendBatch sends the batch call, adding an authorization that delegates the account first
+// when it is not delegated yet. checkDelegation, when set, confirms the delegation before the
+// confirmation prompt.
+func (cc *ContractCaller) sendBatch(ctx context.Context, callMsg ethereum.CallMsg, isDelegated bool, checkDelegation func() error, needsConfirmation bool, confirmationPrompt string, pendingMessage string) (common.Hash, error) {
 	// If not delegated, set the authorization list
 	if !isDelegated {
 		signedAuth, err := cc.createAuthorization(ctx, cc.environmentConfig.ERC7702DelegatorAddress)
 		if err != nil {
-			return fmt.Errorf("failed to create authorization: %w", err)
+			return common.Hash{}, fmt.Errorf("failed to create authorization: %w", err)
 		}
 
 		// Set the authorization list
 		callMsg.AuthorizationList = []types.SetCodeAuthorization{signedAuth}
 	}
 
-	return cc.SendAndWaitForTransaction(ctx, "ExecuteBatch", &callMsg, needsConfirmation, confirmationPrompt, pendingMessage)
+	return cc.sendAndWaitForCall(ctx, "ExecuteBatch", &callMsg, checkDelegation, needsConfirmation, confirmationPrompt, pendingMessage)
 }
 
 func (cc *ContractCaller) createAuthorization(ctx context.Context, delegator common.Address) (types.SetCodeAuthorization, error) {
 	// Get current nonce for the account
-	nonce, err := cc.ethclient.PendingNonceAt(ctx, cc.SelfAddress)
+	callCtx, cancel := cc.rpcContext(ctx)
+	defer cancel()
+
+	nonce, err := cc.ethclient.PendingNonceAt(callCtx, cc.SelfAddress)
 	if err != nil {
 		return types.SetCodeAuthorization{}, fmt.Errorf("failed to get account nonce: %w", err)
 	}
@@ -443,15 +904,36 @@ func EncodeExecutions(executions []erc7702delegatorV2.Execution) ([]byte, error)
 
 /// TX SENDING
 
-func (cc *ContractCaller) SendAndWaitForTransaction(ctx context.Context, txDescription string, callMsg *ethereum.CallMsg, needsConfirmation bool, confirmationPrompt string, pendingMessage string) error {
+func (cc *ContractCaller) SendAndWaitForTransaction(ctx context.Context, txDescription string, callMsg *ethereum.CallMsg, needsConfirmation bool, confirmationPrompt string, pendingMessage string) (common.Hash, error) {
+	return cc.sendAndWaitForCall(ctx, txDescription, callMsg, nil, needsConfirmation, confirmationPrompt, pendingMessage)
+}
+
+// sendAndWaitForCall sends callMsg and waits for it to be mined. checkDelegation, when set, is
+// waited on after the gas estimate and before the confirmation prompt, and its error is returned
+// even when the estimate failed, as a lost delegation also breaks the estimate.
+func (cc *ContractCaller) sendAndWaitForCall(ctx context.Context, txDescription string, callMsg *ethereum.CallMsg, checkDelegation func() error, needsConfirmation bool, confirmationPrompt string, pendingMessage string) (common.Hash, error) {
 	// if from is not set, use self address
 	if callMsg.From.Cmp(common.Address{}) == 0 {
 		callMsg.From = cc.SelfAddress
 	}
 
+	if err := cc.checkPendingTransactions(ctx); err != nil {
+		return common.Hash{}, err
+	}
+
+	endpoint := cc.ethclient.Endpoint()
 	nonce, gasTipCap, gasPrice, gasEstimate, err := cc.getTxParams(ctx, *callMsg)
+	// Keep the nonce and gas consistent by reading them all again after a mid-way failover
+	if err == nil && cc.ethclient.Endpoint() != endpoint {
+		nonce, gasTipCap, gasPrice, gasEstimate, err = cc.getTxParams(ctx, *callMsg)
+	}
+	if checkDelegation != nil {
+		if checkErr := checkDelegation(); checkErr != nil {
+			return common.Hash{}, checkErr
+		}
+	}
 	if err != nil {
-		return err
+		return common.Hash{}, err
 	}
 
 	// Handle confirmation if needed
@@ -461,7 +943,7 @@ func (cc *ContractCaller) SendAndWaitForTransaction(ctx context.Context, txDescr
 		cost := FormatETH(maxCostWei)
 		err = cc.showConfirmationPrompt(confirmationPrompt, cost)
 		if err != nil {
-			return err
+			return common.Hash{}, err
 		}
 	}
 
@@ -498,17 +980,17 @@ func (cc *ContractCaller) SendAndWaitForTransaction(ctx context.Context, txDescr
 		})
 	}
 
-	err = cc.sendAndWaitForTransaction(ctx, txDescription, tx)
+	txHash, err := cc.sendAndWaitForTransaction(ctx, txDescription, tx)
 	if err != nil {
-		return fmt.Errorf("failed to send and wait for transaction: %w", err)
+		return common.Hash{}, fmt.Errorf("failed to send and wait for transaction: %w", err)
 	}
-	return nil
+	return txHash, nil
 }
 
 // showConfirmationPrompt displays a simplified confirmation dialog
 func (cc *ContractCaller) showConfirmationPrompt(confirmationPrompt string, cost string) error {
 	fmt.Println()
-	fmt.Printf("%s on \033[1m%s\033[0m (max cost: %s ETH)\n", confirmationPrompt, cc.environmentConfig.Name, cost)
+	fmt.Printf("%s on %s (max cost: %s ETH)\n", confirmationPrompt, Style(cc.environmentConfig.Name, StyleBold), cost)
 	fmt.Println()
 
 	confirmed, err := output.Confirm("Continue?")
@@ -527,51 +1009,64 @@ func (cc *ContractCaller) sendAndWaitForTransaction(
 	ctx context.Context,
 	txDescription string,
 	tx *types.Transaction,
-) error {
+) (common.Hash, error) {
 	// sign the transaction
 	signer := types.LatestSignerForChainID(cc.chainID)
 	signedTx, err := types.SignTx(tx, signer, cc.privateKey)
 	if err != nil {
-		return fmt.Errorf("failed to sign transaction: %w", err)
+		return common.Hash{}, fmt.Errorf("failed to sign transaction: %w", err)
 	}
 
-	err = cc.ethclient.SendTransaction(ctx, signedTx)
+	sendCtx, cancel := cc.rpcContext(ctx)
+	err = cc.ethclient.SendTransaction(sendCtx, signedTx)
+	cancel()
 	if err != nil {
-		return fmt.Errorf("failed to send transaction: %w", err)
+		if strings.Contains(strings.ToLower(err.Error()), "replacement transaction underpriced") {
+			return common.Hash{}, fmt.Errorf("failed to send transaction: %w (the pending transaction pays a higher gas price; wait for it to be mined or try again when fees rise)", err)
+		}
+		return common.Hash{}, fmt.Errorf("failed to send transaction: %w", err)
 	}
+	cc.logger.Debug("Sent %s transaction (hash: %s, nonce: %d)", txDescription, signedTx.Hash().Hex(), signedTx.Nonce())
 
+	// Mining can take longer than a single RPC call, so only ctx bounds the wait
 	receipt, err := bind.WaitMined(ctx, cc.ethclient, signedTx)
 	if err != nil {
-		cc.logger.Error("Waiting for %s transaction (hash: %s) failed: %v", txDescription, tx.Hash().Hex(), err)
-		return fmt.Errorf("waiting for %s transaction (hash: %s): %w", txDescription, tx.Hash().Hex(), err)
+		cc.logger.Error("Waiting for %s transaction (hash: %s) failed: %v", txDescription, signedTx.Hash().Hex(), err)
+		return common.Hash{}, fmt.Errorf("waiting for %s transaction (hash: %s): %w", txDescription, signedTx.Hash().Hex(), err)
 	}
 	if receipt.Status == 0 {
-		cc.logger.Error("%s transaction (hash: %s) reverted", txDescription, tx.Hash().Hex())
-		return fmt.Errorf("%s transaction (hash: %s) reverted", txDescription, tx.Hash().Hex())
+		cc.logger.Error("%s transaction (hash: %s) reverted", txDescription, signedTx.Hash().Hex())
+		return common.Hash{}, fmt.Errorf("%s transaction (hash: %s) reverted", txDescription, signedTx.Hash().Hex())
 	}
-	return nil
-}
 
-func (cc *ContractCaller) getTxParams(ctx context.Context, callMsg ethereum.CallMsg) (uint64, *big.Int, *big.Int, uint64, error) {
-	nonce, err := cc.ethclient.PendingNonceAt(ctx, cc.SelfAddress)
+	// Guard against reorgs by waiting until the block is buried deep enough
+	confirmCtx, cancel := context.WithTimeout(ctx, time.Duration(cc.confirmations)*confirmationTimeoutPerBlock)
+	receipt, err = waitForConfirmations(confirmCtx, cc.ethclient, cc.logger, txDescription, receipt, cc.confirmations, confirmationPollInterval)
+	cancel()
 	if err != nil {
-		return 0, nil, nil, 0, fmt.Errorf("failed to get nonce: %w", err)
+		return common.Hash{}, err
 	}
+	cc.logger.Debug("%s transaction (hash: %s) confirmed in block %d (gas used: %d)", txDescription, signedTx.Hash().Hex(), receipt.BlockNumber.Uint64(), receipt.GasUsed)
+	return signedTx.Hash(), nil
+}
 
-	gasTipCap, err := cc.ethclient.SuggestGasTipCap(ctx)
+// getTxParams returns the nonce, tip, fee cap and gas limit for a transaction
+func (cc *ContractCaller) getTxParams(ctx context.Context, callMsg ethereum.CallMsg) (uint64, *big.Int, *big.Int, uint64, error) {
+	callCtx, cancel := cc.rpcContext(ctx)
+	nonce, err := cc.ethclient.PendingNonceAt(callCtx, cc.SelfAddress)
+	cancel()
 	if err != nil {
-		return 0, nil, nil, 0, fmt.Errorf("failed to suggest gas tip cap: %w", err)
+		return 0, nil, nil, 0, fmt.Errorf("failed to get nonce: %w", err)
 	}
 
-	head, err := cc.ethclient.HeaderByNumber(ctx, nil)
+	gasTipCap, gasPrice, err := cc.suggestFees(ctx)
 	if err != nil {
-		return 0, nil, nil, 0, fmt.Errorf("failed to get block by number: %w", err)
+		return 0, nil, nil, 0, err
 	}
-	gasPrice := new(big.Int).Add(head.BaseFee, gasTipCap)
-	gasPrice = new(big.Int).Mul(gasPrice, big.NewInt(100+gasPriceOverestimationPercentage))
-	gasPrice = new(big.Int).Div(gasPrice, big.NewInt(100))
 
-	gasEstimate, err := cc.ethclient.EstimateGas(ctx, callMsg)
+	callCtx, cancel = cc.rpcContext(ctx)
+	gasEstimate, err := cc.ethclient.EstimateGas(callCtx, callMsg)
+	cancel()
 	if err != nil {
 		// Try to parse custom contract errors
 		if parsedErr := cc.parseEstimateGasError(err); parsedErr != nil {
@@ -584,6 +1079,27 @@ func (cc *ContractCaller) getTxParams(ctx context.Context, callMsg ethereum.Call
 	return nonce, gasTipCap, gasPrice, gasEstimate, nil
 }
 
+// suggestFees returns the suggested tip and a fee cap over the current base fee plus that tip
+func (cc *ContractCaller) suggestFees(ctx context.Context) (*big.Int, *big.Int, error) {
+	callCtx, cancel := cc.rpcContext(ctx)
+	gasTipCap, err := cc.ethclient.SuggestGasTipCap(callCtx)
+	cancel()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to suggest gas tip cap: %w", err)
+	}
+
+	callCtx, cancel = cc.rpcContext(ctx)
+	head, err := cc.ethclient.HeaderByNumber(callCtx, nil)
+	cancel()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get block by number: %w", err)
+	}
+	gasPrice := new(big.Int).Add(head.BaseFee, gasTipCap)
+	gasPrice = new(big.Int).Mul(gasPrice, big.NewInt(100+gasPriceOverestimationPercentage))
+	gasPrice = new(big.Int).Div(gasPrice, big.NewInt(100))
+	return gasTipCap, gasPrice, nil
+}
+
 // parseEstimateGasError attempts to parse custom contract errors from EstimateGas failures
 func (cc *ContractCaller) parseEstimateGasError(err error) error {
 	if err == nil {
@@ -0,0 +1,228 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common/iface"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// rpcLimitExceededCode is the JSON-RPC error code providers use for rate limiting
+const rpcLimitExceededCode = -32005
+
+// FailoverClient is an Ethereum RPC client over one or more endpoints. Calls go to the current
+// endpoint and move on to the next one when it is unreachable or rate limits. The client stays
+// on the endpoint that answered, so reads that belong together (nonce, gas, send) hit one node.
+type FailoverClient struct {
+	mu      sync.Mutex
+	urls    []string
+	clients []*ethclient.Client
+	current int
+	logger  iface.Logger
+}
+
+// NewFailoverClient creates a client over urls, tried in order. Endpoints are dialed on first use.
+func NewFailoverClient(urls []string, logger iface.Logger) (*FailoverClient, error) {
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("no RPC URL provided")
+	}
+	logger.Debug("Using RPC endpoint %s", urls[0])
+	return &FailoverClient{
+		urls:    urls,
+		clients: make([]*ethclient.Client, len(urls)),
+		logger:  logger,
+	}, nil
+}
+
+// Endpoint returns the URL of the endpoint calls currently go to
+func (fc *FailoverClient) Endpoint() string {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.urls[fc.current]
+}
+
+// Close closes every dialed endpoint
+func (fc *FailoverClient) Close() {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	for _, client := range fc.clients {
+		if client != nil {
+			client.Close()
+		}
+	}
+}
+
+// dial returns the client for endpoint i, dialing it if needed
+func (fc *FailoverClient) dial(i int) (*ethclient.Client, error) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	if fc.clients[i] == nil {
+		client, err := ethclient.Dial(fc.urls[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to RPC endpoint %s: %w", fc.urls[i], err)
+		}
+		fc.clients[i] = client
+	}
+	return fc.clients[i], nil
+}
+
+// switchTo makes endpoint i the current one
+func (fc *FailoverClient) switchTo(i int) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	if fc.current != i {
+		fc.current = i
+		fc.logger.Debug("Using RPC endpoint %s", fc.urls[i])
+	}
+}
+
+// withFailover runs call against the current endpoint, then each following one, until an
+// endpoint answers or fails with an error that failing over would not fix
+func withFailover[T any](ctx context.Context, fc *FailoverClient, call func(*ethclient.Client) (T, error)) (T, error) {
+	fc.mu.Lock()
+	start := fc.current
+	fc.mu.Unlock()
+
+	var result T
+	var err error
+	for n := range len(fc.urls) {
+		i := (start + n) % len(fc.urls)
+
+		var client *ethclient.Client
+		client, err = fc.dial(i)
+		if err == nil {
+			result, err = call(client)
+			if err == nil || !isFailoverError(ctx, err) {
+				fc.switchTo(i)
+				return result, err
+			}
+		}
+
+		if n < len(fc.urls)-1 {
+			fc.logger.Warn("RPC endpoint %d of %d failed, failing over to the next one: %v", i+1, len(fc.urls), err)
+		}
+	}
+	return result, err
+}
+
+// isFailoverError reports whether err means the endpoint is unreachable or rate limiting, as
+// opposed to a valid answer such as a revert. Errors caused by ctx ending are never retried.
+func isFailoverError(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= http.StatusInternalServerError
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == rpcLimitExceededCode {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "too many requests") || strings.Contains(message, "rate limit")
+}
+
+func (fc *FailoverClient) ChainID(ctx context.Context) (*big.Int, error) {
+	return withFailover(ctx, fc, func(c *ethclient.Client) (*big.Int, error) { return c.ChainID(ctx) })
+}
+
+func (fc *FailoverClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return withFailover(ctx, fc, func(c *ethclient.Client) ([]byte, error) { return c.CodeAt(ctx, account, blockNumber) })
+}
+
+func (fc *FailoverClient) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return withFailover(ctx, fc, func(c *ethclient.Client) ([]byte, error) { return c.PendingCodeAt(ctx, account) })
+}
+
+func (fc *FailoverClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return withFailover(ctx, fc, func(c *ethclient.Client) ([]byte, error) { return c.CallContract(ctx, msg, blockNumber) })
+}
+
+func (fc *FailoverClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return withFailover(ctx, fc, func(c *ethclient.Client) (*types.Header, error) { return c.HeaderByNumber(ctx, number) })
+}
+
+func (fc *FailoverClient) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	return withFailover(ctx, fc, func(c *ethclient.Client) (*types.Block, error) { return c.BlockByNumber(ctx, number) })
+}
+
+func (fc *FailoverClient) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return withFailover(ctx, fc, func(c *ethclient.Client) (uint64, error) { return c.PendingNonceAt(ctx, account) })
+}
+
+func (fc *FailoverClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return withFailover(ctx, fc, func(c *ethclient.Client) (uint64, error) { return c.NonceAt(ctx, account, blockNumber) })
+}
+
+func (fc *FailoverClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return withFailover(ctx, fc, func(c *ethclient.Client) (*big.Int, error) { return c.SuggestGasPrice(ctx) })
+}
+
+func (fc *FailoverClient) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return withFailover(ctx, fc, func(c *ethclient.Client) (*big.Int, error) { return c.SuggestGasTipCap(ctx) })
+}
+
+func (fc *FailoverClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	return withFailover(ctx, fc, func(c *ethclient.Client) (uint64, error) { return c.EstimateGas(ctx, msg) })
+}
+
+// SendTransaction broadcasts tx. A node that already knows tx means an earlier attempt reached
+// the network before its endpoint failed, so that counts as sent.
+func (fc *FailoverClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	_, err := withFailover(ctx, fc, func(c *ethclient.Client) (struct{}, error) {
+		err := c.SendTransaction(ctx, tx)
+		if err != nil && strings.Contains(strings.ToLower(err.Error()), "already known") {
+			return struct{}{}, nil
+		}
+		return struct{}{}, err
+	})
+	return err
+}
+
+// PendingTransaction returns the transaction of account with nonce that waits in the node's
+// mempool, or nil if the node holds none. It uses txpool_contentFrom, which not every node serves.
+func (fc *FailoverClient) PendingTransaction(ctx context.Context, account common.Address, nonce uint64) (*types.Transaction, error) {
+	return withFailover(ctx, fc, func(c *ethclient.Client) (*types.Transaction, error) {
+		var content map[string]map[string]*types.Transaction
+		if err := c.Client().CallContext(ctx, &content, "txpool_contentFrom", account); err != nil {
+			return nil, err
+		}
+		return content["pending"][strconv.FormatUint(nonce, 10)], nil
+	})
+}
+
+func (fc *FailoverClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return withFailover(ctx, fc, func(c *ethclient.Client) (*types.Receipt, error) { return c.TransactionReceipt(ctx, txHash) })
+}
+
+func (fc *FailoverClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	return withFailover(ctx, fc, func(c *ethclient.Client) ([]types.Log, error) { return c.FilterLogs(ctx, q) })
+}
+
+func (fc *FailoverClient) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return withFailover(ctx, fc, func(c *ethclient.Client) (ethereum.Subscription, error) { return c.SubscribeFilterLogs(ctx, q, ch) })
+}
@@ -0,0 +1,79 @@
+package common
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common/logger"
+)
+
+// newRPCServer returns a JSON-RPC endpoint that answers every request with body and status
+func newRPCServer(t *testing.T, status int, body string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFailoverClient(t *testing.T) {
+	rateLimited := newRPCServer(t, http.StatusTooManyRequests, "rate limited")
+	healthy := newRPCServer(t, http.StatusOK, `{"jsonrpc":"2.0","id":1,"result":"0xaa36a7"}`)
+	reverting := newRPCServer(t, http.StatusOK, `{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"execution reverted"}}`)
+
+	t.Run("fails over on rate limiting and stays on the healthy endpoint", func(t *testing.T) {
+		client, err := NewFailoverClient([]string{rateLimited.URL, healthy.URL}, logger.NewNoopLogger())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer client.Close()
+
+		chainID, err := client.ChainID(context.Background())
+		if err != nil {
+			t.Fatalf("ChainID failed: %v", err)
+		}
+		if chainID.Uint64() != SepoliaChainID {
+			t.Errorf("ChainID = %s, expected %d", chainID, SepoliaChainID)
+		}
+		if client.Endpoint() != healthy.URL {
+			t.Errorf("Endpoint = %s, expected %s", client.Endpoint(), healthy.URL)
+		}
+	})
+
+	t.Run("does not fail over on a valid error response", func(t *testing.T) {
+		client, err := NewFailoverClient([]string{reverting.URL, healthy.URL}, logger.NewNoopLogger())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer client.Close()
+
+		if _, err := client.ChainID(context.Background()); err == nil {
+			t.Error("expected the first endpoint's error to be returned")
+		}
+		if client.Endpoint() != reverting.URL {
+			t.Errorf("Endpoint = %s, expected %s", client.Endpoint(), reverting.URL)
+		}
+	})
+
+	t.Run("returns the last error when every endpoint fails", func(t *testing.T) {
+		client, err := NewFailoverClient([]string{rateLimited.URL, rateLimited.URL}, logger.NewNoopLogger())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer client.Close()
+
+		if _, err := client.ChainID(context.Background()); err == nil {
+			t.Error("expected an error")
+		}
+	})
+
+	t.Run("requires a URL", func(t *testing.T) {
+		if _, err := NewFailoverClient(nil, logger.NewNoopLogger()); err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
@@ -1,6 +1,10 @@
 package common
 
-import "github.com/urfave/cli/v2"
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
 
 // Common flag definitions
 var (
@@ -12,7 +16,7 @@ var (
 
 	RpcUrlFlag = &cli.StringFlag{
 		Name:    "rpc-url",
-		Usage:   "RPC URL to connect to blockchain",
+		Usage:   "RPC URL to connect to blockchain. Pass several comma-separated URLs to fail over when one is unreachable or rate limits",
 		EnvVars: []string{"EIGENX_RPC_URL"},
 	}
 
@@ -23,8 +27,14 @@ var (
 	}
 
 	ForceFlag = &cli.BoolFlag{
-		Name:  "force",
-		Usage: "Force operation without confirmation",
+		Name:    "force",
+		Aliases: []string{"yes"},
+		Usage:   "Force operation without confirmation",
+	}
+
+	JSONFlag = &cli.BoolFlag{
+		Name:  "json",
+		Usage: "Output as JSON",
 	}
 
 	EnvFlag = &cli.StringFlag{
@@ -33,6 +43,16 @@ var (
 		Value: ".env",
 	}
 
+	PublicEnvFileFlag = &cli.StringFlag{
+		Name:  "public-env-file",
+		Usage: "Environment file whose variables are all public (plaintext onchain), instead of --env-file suffix categorization",
+	}
+
+	PrivateEnvFileFlag = &cli.StringFlag{
+		Name:  "private-env-file",
+		Usage: "Environment file whose variables are all private (encrypted onchain), instead of --env-file suffix categorization",
+	}
+
 	ImageNameFlag = &cli.StringFlag{
 		Name:  "image-name",
 		Usage: "Override app/image name (auto-detected from context if not provided)",
@@ -54,6 +74,11 @@ var (
 		Usage: "Template version/tag to use",
 	}
 
+	TemplateVarFlag = &cli.StringSliceFlag{
+		Name:  "var",
+		Usage: "Set a template variable as NAME=VALUE (repeatable); variables the template declares that are not set are prompted for",
+	}
+
 	AllFlag = &cli.BoolFlag{
 		Name:  "all",
 		Usage: "Show all apps including terminated ones",
@@ -65,6 +90,12 @@ var (
 		Value: 1,
 	}
 
+	AddressChainsFlag = &cli.StringFlag{
+		Name:  "chains",
+		Usage: "Address families to fetch and show: evm, solana or all",
+		Value: "all",
+	}
+
 	LogVisibilityFlag = &cli.StringFlag{
 		Name:  "log-visibility",
 		Usage: "Log visibility setting: public, private, or off",
@@ -75,12 +106,257 @@ var (
 		Usage: "Machine instance type to use e.g. g1-standard-4t, g1-standard-8t",
 	}
 
+	TLSFlag = &cli.BoolFlag{
+		Name:  "tls",
+		Usage: "Always include TLS components (Caddy, tls-keygen) in the layered image, regardless of DOMAIN",
+	}
+
+	NoTLSFlag = &cli.BoolFlag{
+		Name:  "no-tls",
+		Usage: "Never include TLS components in the layered image, even if DOMAIN is set in the env file",
+	}
+
+	TLSEmailFlag = &cli.StringFlag{
+		Name:  "tls-email",
+		Usage: "Email address for ACME (Let's Encrypt) account registration, baked into the image as ACME_EMAIL",
+	}
+
+	TLSStagingFlag = &cli.BoolFlag{
+		Name:  "tls-staging",
+		Usage: "Use the Let's Encrypt staging environment (untrusted certificates, for testing), baked into the image as ACME_STAGING",
+	}
+
+	FailOnPlatformMismatchFlag = &cli.BoolFlag{
+		Name:  "fail-on-platform-mismatch",
+		Usage: "Fail if the image has no variant for the target platform; set to false to rebuild from the local Dockerfile and push it to <image>:<tag>-eigenx instead",
+		Value: true,
+	}
+
+	PlatformFlag = &cli.StringFlag{
+		Name:  "platform",
+		Usage: "Target platform of the TEE image: linux/amd64 or linux/arm64 (experimental, TEE and KMS support pending)",
+		Value: "linux/amd64",
+	}
+
+	BuildTimeoutFlag = &cli.DurationFlag{
+		Name:  "build-timeout",
+		Usage: "Maximum time to spend building and pushing the image, e.g. 15m (0 for no limit)",
+	}
+
+	EnvCheckEntropyFlag = &cli.BoolFlag{
+		Name:  "env-check-entropy",
+		Usage: "Warn when a _PUBLIC env value looks like a high-entropy secret (API key, token)",
+	}
+
+	EnvEntropyThresholdFlag = &cli.Float64Flag{
+		Name:  "env-entropy-threshold",
+		Usage: "Shannon entropy (bits per character) at or above which --env-check-entropy flags a value",
+		Value: 4.0,
+	}
+
+	BuildSecretFlag = &cli.StringSliceFlag{
+		Name:  "build-secret",
+		Usage: "BuildKit secret for the app image build, e.g. id=npmrc,src=$HOME/.npmrc (repeatable)",
+	}
+
+	BuildArgFlag = &cli.StringSliceFlag{
+		Name:  "build-arg",
+		Usage: "Build-time variable for the app image build, e.g. VERSION=1.2.3 (repeatable)",
+	}
+
+	DockerfileTargetFlag = &cli.StringFlag{
+		Name:    "dockerfile-target",
+		Aliases: []string{"target"},
+		Usage:   "Stage of a multi-stage Dockerfile to build for the app image, e.g. production (default: the last stage)",
+	}
+
+	RegistryRetrySameFlag = &cli.BoolFlag{
+		Name:  "registry-retry-same",
+		Usage: "On registry permission errors, retry pushing the same image after re-authenticating instead of choosing a different registry",
+	}
+
+	PropagationWaitFlag = &cli.DurationFlag{
+		Name:  "propagation-wait",
+		Usage: "Maximum time to wait for the registry to serve a pushed image, e.g. 10s (default 3s, 0 disables the wait)",
+	}
+
+	KeepBaseImageFlag = &cli.BoolFlag{
+		Name:  "keep-base-image",
+		Usage: "Keep the intermediate base and layered images in the local Docker store after a successful push",
+	}
+
+	SummaryOnlyFlag = &cli.BoolFlag{
+		Name:  "summary-only",
+		Usage: "Only print a one-line summary when done; progress logs are shown with --verbose",
+	}
+
+	GitDirtyCheckFlag = &cli.BoolFlag{
+		Name:  "git-dirty-check",
+		Usage: "Refuse to build when the build context has uncommitted git changes (default: on for mainnet)",
+	}
+
+	AllowDirtyFlag = &cli.BoolFlag{
+		Name:  "allow-dirty",
+		Usage: "Build even if the git dirty check finds uncommitted changes",
+	}
+
+	RegistryScopeCheckFlag = &cli.BoolFlag{
+		Name:  "registry-scope-check",
+		Usage: "Before building, warn if the stored GHCR token lacks the write:packages scope needed to push",
+	}
+
+	RegistryAuthFileFlag = &cli.StringFlag{
+		Name:    "registry-auth-file",
+		Usage:   "Docker config file with registry credentials to use instead of ~/.docker/config.json",
+		EnvVars: []string{"EIGENX_REGISTRY_AUTH_FILE"},
+	}
+
+	RegistryCACertFlag = &cli.StringFlag{
+		Name:    "registry-ca-cert",
+		Usage:   "PEM file with CA certificates to trust, in addition to the system CAs, when querying image registries",
+		EnvVars: []string{"EIGENX_REGISTRY_CA_CERT"},
+	}
+
+	VerifySignatureFlag = &cli.BoolFlag{
+		Name:  "verify-signature",
+		Usage: "Verify the cosign signature of a published image before layering it (requires --cosign-key and the cosign CLI; not available when building from a Dockerfile)",
+	}
+
+	CosignKeyFlag = &cli.StringFlag{
+		Name:  "cosign-key",
+		Usage: "Path (or KMS URI) of the cosign public key used by --verify-signature",
+	}
+
+	DiffOnlyFlag = &cli.StringFlag{
+		Name:  "diff-only",
+		Usage: "Compare the public env and instance type that would be deployed with the release of this app (id or name) and exit non-zero if they differ, without building or deploying",
+	}
+
+	SkipBillingCheckFlag = &cli.BoolFlag{
+		Name:    "skip-billing-check",
+		Usage:   "Don't look up the billing subscription when the account has no app quota, e.g. on environments without billing",
+		EnvVars: []string{"EIGENX_SKIP_BILLING_CHECK"},
+	}
+
+	NameFromDirFlag = &cli.BoolFlag{
+		Name:  "name-from-dir",
+		Usage: "Name the app after the current directory (lowercased, invalid characters replaced with hyphens); fails if the name is taken",
+	}
+
+	ManifestOutFlag = &cli.StringFlag{
+		Name:  "manifest-out",
+		Usage: "Write a release manifest (lockfile) with the resolved image digest, env and settings to this path, e.g. eigenx.lock.json",
+	}
+
+	AppManifestFlag = &cli.StringFlag{
+		Name:  "manifest",
+		Usage: "Deploy the image, public env, instance type and log visibility from a manifest written by 'app export'",
+	}
+
+	BatchManifestFlag = &cli.StringFlag{
+		Name:  "batch",
+		Usage: "Build every app of a batch manifest and create or upgrade them all in a single transaction",
+	}
+
+	ParallelFlag = &cli.IntFlag{
+		Name:  "parallel",
+		Usage: "Maximum number of images built and pushed at once for --batch",
+		Value: 3,
+	}
+
+	EnvPrefixFlag = &cli.StringFlag{
+		Name:  "env-prefix",
+		Usage: "Prefix added to every variable name from the env file(s), e.g. MYAPP_ turns DATABASE_URL into MYAPP_DATABASE_URL inside the TEE. _PUBLIC is detected on the original name",
+	}
+
+	ShowValuesFlag = &cli.BoolFlag{
+		Name:  "show-values",
+		Usage: "Show private variable values in the env categorization table instead of masking them",
+	}
+
+	DryRunEnvFlag = &cli.BoolFlag{
+		Name:  "dry-run-env",
+		Usage: "Print how the env file(s) will be split into public and private variables and exit without deploying",
+	}
+
+	MaxEnvSizeFlag = &cli.IntFlag{
+		Name:  "max-env-size",
+		Usage: "Fail when the serialized public and private env exceed this many bytes (without it, only warn above 16 KiB)",
+	}
+
+	OutputTxHashFileFlag = &cli.StringFlag{
+		Name:  "output-tx-hash-file",
+		Usage: "After the transaction confirms, write its hash to this path, e.g. for explorer links or audit trails",
+	}
+
+	OutputEnvTemplateFlag = &cli.StringFlag{
+		Name:  "output-env-template",
+		Usage: "After deploying, write a .env.example-style template of the app's public variables and private variable names to this path",
+	}
+
+	WaitFlag = &cli.BoolFlag{
+		Name:  "wait",
+		Usage: "Wait until the app is running after the transaction confirms; --wait=false returns once it is confirmed onchain",
+		Value: true,
+	}
+
+	VerifyRunningFlag = &cli.BoolFlag{
+		Name:  "verify-running",
+		Usage: "After the app is running, fail unless it answers HTTP(S) requests on --health-path within --health-timeout",
+	}
+
+	HealthPathFlag = &cli.StringFlag{
+		Name:  "health-path",
+		Usage: "Path requested by --verify-running, e.g. /healthz",
+		Value: "/",
+	}
+
+	HealthTimeoutFlag = &cli.DurationFlag{
+		Name:  "health-timeout",
+		Usage: "Maximum time --verify-running waits for a successful response, e.g. 5m",
+		Value: HealthTimeoutSeconds * time.Second,
+	}
+
 	WatchFlag = &cli.BoolFlag{
 		Name:    "watch",
 		Aliases: []string{"w"},
 		Usage:   "Continuously fetch and display updates",
 	}
 
+	ReuseDelegationCheckFlag = &cli.BoolFlag{
+		Name:  "reuse-delegation-check",
+		Usage: "When this account was confirmed as delegated in the last 10 minutes, check the ERC-7702 delegation onchain alongside the gas estimate instead of before it",
+	}
+
+	RpcTimeoutFlag = &cli.DurationFlag{
+		Name:    "rpc-timeout",
+		Usage:   "Maximum time to wait for a single RPC call, e.g. 10s (0 for no limit)",
+		EnvVars: []string{"EIGENX_RPC_TIMEOUT"},
+		Value:   RPCTimeoutSeconds * time.Second,
+	}
+
+	PollIntervalFlag = &cli.DurationFlag{
+		Name:  "poll-interval",
+		Usage: "Refresh interval in watch mode, e.g. 10s",
+		Value: WatchPollIntervalSeconds * time.Second,
+	}
+
+	WaitIntervalBackoffFlag = &cli.BoolFlag{
+		Name:  "wait-interval-backoff",
+		Usage: "While waiting for the app to come up, poll less often the longer it takes (up to 60s between polls)",
+	}
+
+	ProgressFlag = &cli.StringFlag{
+		Name:  "progress",
+		Usage: "Progress output: plain (raw build logs), tty (progress bars) or none (warnings and errors only)",
+	}
+
+	ProgressFormatFlag = &cli.StringFlag{
+		Name:  "progress-format",
+		Usage: "Deploy stage reporting: text, or json to also write one JSON event per stage update to stderr",
+		Value: "text",
+	}
+
 	// Profile-related flags
 	NameFlag = &cli.StringFlag{
 		Name:  "name",
@@ -106,6 +382,39 @@ var (
 		Name:  "image",
 		Usage: "Path to app icon/logo image - JPG/PNG, max 4MB, square recommended (optional)",
 	}
+
+	ReplacePendingFlag = &cli.BoolFlag{
+		Name:  "replace-pending",
+		Usage: "When earlier transactions from your account are stuck pending, speed up the oldest one by re-sending it at a higher gas price without asking",
+	}
+
+	ConfirmationsFlag = &cli.IntFlag{
+		Name:  "confirmations",
+		Usage: "Number of block confirmations to wait for before a transaction counts as successful, to guard against reorgs",
+		Value: 1,
+	}
+
+	SkipUnchangedFlag = &cli.BoolFlag{
+		Name:  "skip-unchanged",
+		Usage: "Skip the upgrade when the app's current release already has the same image digest, public env and log visibility. Private env changes are not detected; set to false to upgrade anyway",
+		Value: true,
+	}
+
+	SaltFlag = &cli.StringFlag{
+		Name:  "salt",
+		Usage: "Salt for a deterministic app id: a 0x-prefixed 32-byte hex value is used as-is, any other value is hashed with keccak256 (default: random)",
+	}
+
+	LogFileFlag = &cli.StringFlag{
+		Name:    "log-file",
+		Usage:   "Append an audit log of all output (including debug lines, tx hashes and confirmations) to this file; use \"default\" for a rotating log in the eigenx config directory. Private keys and private env values are redacted",
+		EnvVars: []string{"EIGENX_LOG_FILE"},
+	}
+
+	ResizeImageFlag = &cli.BoolFlag{
+		Name:  "resize",
+		Usage: "Center-crop the app icon/logo to a square and scale it down to 512x512 PNG before uploading",
+	}
 )
 
 // GlobalFlags defines flags that apply to the entire application (global flags).
@@ -123,6 +432,22 @@ var GlobalFlags = []cli.Flag{
 		Name:  "disable-telemetry",
 		Usage: "Disable telemetry collection on first run without prompting",
 	},
+	&cli.BoolFlag{
+		Name:  "no-telemetry",
+		Usage: "Disable telemetry for this invocation without changing the saved preference",
+	},
+	&cli.BoolFlag{
+		Name:  "no-color",
+		Usage: "Disable colored output (also set by the NO_COLOR environment variable)",
+	},
+	&cli.BoolFlag{
+		Name:  "quiet",
+		Usage: "Only print warnings, errors, prompts and command results (hides banners, countdowns and update notices)",
+	},
+	LogFileFlag,
+	RpcTimeoutFlag,
+	ConfirmationsFlag,
+	ReplacePendingFlag,
 }
 
 func ForceFlagWithUsage(usage string) *cli.BoolFlag {
@@ -130,3 +455,17 @@ func ForceFlagWithUsage(usage string) *cli.BoolFlag {
 	requiredFlag.Usage = usage
 	return &requiredFlag
 }
+
+// JSONFlagWithUsage returns a copy of JSONFlag with a command-specific usage
+func JSONFlagWithUsage(usage string) *cli.BoolFlag {
+	jsonFlag := *JSONFlag
+	jsonFlag.Usage = usage
+	return &jsonFlag
+}
+
+// SkipUnchangedFlagWithUsage returns a copy of SkipUnchangedFlag with a command-specific usage
+func SkipUnchangedFlagWithUsage(usage string) *cli.BoolFlag {
+	skipFlag := *SkipUnchangedFlag
+	skipFlag.Usage = usage
+	return &skipFlag
+}
@@ -4,10 +4,16 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
+// DelegationCacheTTL is how long a confirmed ERC-7702 delegation is trusted without re-checking
+// it onchain when --reuse-delegation-check is set
+const DelegationCacheTTL = 10 * time.Minute
+
 // GlobalConfig contains user-level configuration that persists across all devkit usage
 type GlobalConfig struct {
 	// FirstRun tracks if this is the user's first time running devkit
@@ -22,6 +28,10 @@ type GlobalConfig struct {
 	LastVersionCheck int64 `yaml:"last_version_check,omitempty"`
 	// LastKnownVersion stores the last known latest version from the server
 	LastKnownVersion string `yaml:"last_known_version,omitempty"`
+	// DelegationChecks stores when each environment:account was last confirmed as delegated
+	DelegationChecks map[string]int64 `yaml:"delegation_checks,omitempty"`
+	// RPCURLs stores the user's RPC URL per environment, used instead of the built-in default
+	RPCURLs map[string]string `yaml:"rpc_urls,omitempty"`
 }
 
 // GetGlobalConfigDir returns the XDG-compliant directory where global eigenx config should be stored
@@ -164,8 +174,13 @@ func GetDefaultEnvironment() (string, error) {
 	return config.DefaultEnvironment, nil
 }
 
-// SetDefaultEnvironment sets the user's preferred deployment environment
+// SetDefaultEnvironment sets the user's preferred deployment environment. Unknown environments are
+// refused so a typo can't leave every later command pointing at a missing environment.
 func SetDefaultEnvironment(environment string) error {
+	if err := ValidateEnvironmentName(environment); err != nil {
+		return err
+	}
+
 	config, err := LoadGlobalConfig()
 	if err != nil {
 		return err
@@ -175,3 +190,82 @@ func SetDefaultEnvironment(environment string) error {
 
 	return SaveGlobalConfig(config)
 }
+
+// GetRPCURLOverride returns the RPC URL saved for environment with 'environment set-rpc', or ""
+func GetRPCURLOverride(environment string) string {
+	config, err := LoadGlobalConfig()
+	if err != nil {
+		return ""
+	}
+	return config.RPCURLs[environment]
+}
+
+// SetRPCURLOverride saves the RPC URL to use for environment. An empty rpcURL removes it.
+func SetRPCURLOverride(environment, rpcURL string) error {
+	config, err := LoadGlobalConfig()
+	if err != nil {
+		return err
+	}
+
+	if rpcURL == "" {
+		delete(config.RPCURLs, environment)
+	} else {
+		if config.RPCURLs == nil {
+			config.RPCURLs = map[string]string{}
+		}
+		config.RPCURLs[environment] = rpcURL
+	}
+
+	return SaveGlobalConfig(config)
+}
+
+// delegationCacheKey identifies an account in an environment in GlobalConfig.DelegationChecks
+func delegationCacheKey(environment, account string) string {
+	return environment + ":" + strings.ToLower(account)
+}
+
+// IsDelegationCached reports whether account was confirmed as delegated in environment within
+// DelegationCacheTTL of now
+func IsDelegationCached(environment, account string, now time.Time) bool {
+	config, err := LoadGlobalConfig()
+	if err != nil {
+		return false
+	}
+	checkedAt, ok := config.DelegationChecks[delegationCacheKey(environment, account)]
+	if !ok {
+		return false
+	}
+	age := now.Sub(time.Unix(checkedAt, 0))
+	return age >= 0 && age < DelegationCacheTTL
+}
+
+// CacheDelegation records that account was confirmed as delegated in environment at now
+func CacheDelegation(environment, account string, now time.Time) error {
+	config, err := LoadGlobalConfig()
+	if err != nil {
+		return err
+	}
+
+	if config.DelegationChecks == nil {
+		config.DelegationChecks = map[string]int64{}
+	}
+	config.DelegationChecks[delegationCacheKey(environment, account)] = now.Unix()
+
+	return SaveGlobalConfig(config)
+}
+
+// ForgetDelegation removes any cached delegation for account in environment
+func ForgetDelegation(environment, account string) error {
+	config, err := LoadGlobalConfig()
+	if err != nil {
+		return err
+	}
+
+	key := delegationCacheKey(environment, account)
+	if _, ok := config.DelegationChecks[key]; !ok {
+		return nil
+	}
+	delete(config.DelegationChecks, key)
+
+	return SaveGlobalConfig(config)
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -183,3 +184,36 @@ func TestGlobalConfigWithHomeDir(t *testing.T) {
 func boolPtr(b bool) *bool {
 	return &b
 }
+
+func TestDelegationCache(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	account := "0xAbC0000000000000000000000000000000000001"
+	now := time.Unix(1700000000, 0)
+
+	assert.False(t, IsDelegationCached("sepolia", account, now))
+
+	require.NoError(t, CacheDelegation("sepolia", account, now))
+	assert.True(t, IsDelegationCached("sepolia", account, now.Add(DelegationCacheTTL-time.Second)))
+	assert.True(t, IsDelegationCached("sepolia", "0xabc0000000000000000000000000000000000001", now), "addresses match case-insensitively")
+	assert.False(t, IsDelegationCached("sepolia", account, now.Add(DelegationCacheTTL)), "expired")
+	assert.False(t, IsDelegationCached("mainnet-alpha", account, now), "cached per environment")
+
+	require.NoError(t, ForgetDelegation("sepolia", account))
+	assert.False(t, IsDelegationCached("sepolia", account, now))
+	require.NoError(t, ForgetDelegation("sepolia", account))
+}
+
+func TestRPCURLOverride(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	assert.Equal(t, "", GetRPCURLOverride("sepolia"))
+
+	require.NoError(t, SetRPCURLOverride("sepolia", "https://rpc.example.com"))
+	assert.Equal(t, "https://rpc.example.com", GetRPCURLOverride("sepolia"))
+	assert.Equal(t, "", GetRPCURLOverride("mainnet-alpha"), "saved per environment")
+
+	require.NoError(t, SetRPCURLOverride("sepolia", ""))
+	assert.Equal(t, "", GetRPCURLOverride("sepolia"))
+	require.NoError(t, SetRPCURLOverride("sepolia", ""), "clearing twice is fine")
+}
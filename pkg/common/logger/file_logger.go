@@ -0,0 +1,204 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common/iface"
+)
+
+const (
+	// LogFileMaxBytes is the size at which a log file is rotated when it is opened
+	LogFileMaxBytes = 10 * 1024 * 1024
+
+	// LogFileBackups is how many rotated log files are kept (eigenx.log.1 ... eigenx.log.N)
+	LogFileBackups = 5
+
+	redactedSecret = "[REDACTED]"
+
+	// minSecretLength is the length from which secrets are redacted wherever they appear.
+	// Shorter ones such as "8080" would also match inside tx hashes and addresses, so they
+	// are only redacted as whole tokens.
+	minSecretLength = 8
+)
+
+var (
+	secretsMu sync.RWMutex
+	// secrets is kept longest first, so a secret that contains a shorter one is redacted whole
+	secrets []string
+)
+
+// RegisterSecret marks value as sensitive so file loggers never write it. Values shorter than
+// minSecretLength are only redacted where they stand alone, as in KEY=value.
+func RegisterSecret(value string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return
+	}
+	secretsMu.Lock()
+	defer secretsMu.Unlock()
+	addSecret(value)
+	// Private keys are accepted with or without the 0x prefix
+	if trimmed := strings.TrimPrefix(value, "0x"); trimmed != "" {
+		addSecret(trimmed)
+	}
+}
+
+// addSecret inserts secret into secrets, keeping the longest first. secretsMu must be held.
+func addSecret(secret string) {
+	if slices.Contains(secrets, secret) {
+		return
+	}
+	i, _ := slices.BinarySearchFunc(secrets, secret, func(a, b string) int { return len(b) - len(a) })
+	secrets = slices.Insert(secrets, i, secret)
+}
+
+// Redact replaces every registered secret in s with [REDACTED]
+func Redact(s string) string {
+	secretsMu.RLock()
+	defer secretsMu.RUnlock()
+	for _, secret := range secrets {
+		if len(secret) >= minSecretLength {
+			s = strings.ReplaceAll(s, secret, redactedSecret)
+		} else {
+			s = replaceToken(s, secret, redactedSecret)
+		}
+	}
+	return s
+}
+
+// replaceToken replaces each occurrence of token in s that is not part of a longer word,
+// i.e. not preceded or followed by a letter, digit or underscore
+func replaceToken(s, token, replacement string) string {
+	var b strings.Builder
+	for {
+		i := strings.Index(s, token)
+		if i == -1 {
+			b.WriteString(s)
+			return b.String()
+		}
+		end := i + len(token)
+		if (i > 0 && isWordByte(s[i-1])) || (end < len(s) && isWordByte(s[end])) {
+			b.WriteString(s[:i+1])
+			s = s[i+1:]
+			continue
+		}
+		b.WriteString(s[:i])
+		b.WriteString(replacement)
+		s = s[end:]
+	}
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || ('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// FileLogger forwards every message to inner and also appends it, timestamped and with
+// registered secrets redacted, to a writer such as an audit log file. Every message is
+// written to the file at its own level, whether or not inner shows it.
+type FileLogger struct {
+	inner iface.Logger
+	mu    *sync.Mutex
+	out   io.Writer
+	now   func() time.Time
+}
+
+// NewFileLogger tees inner's output to out
+func NewFileLogger(inner iface.Logger, out io.Writer) *FileLogger {
+	return &FileLogger{inner: inner, mu: &sync.Mutex{}, out: out, now: time.Now}
+}
+
+// Quiet returns l with titles and info messages demoted to debug, as NewQuietLogger does. A
+// FileLogger only quiets its inner logger, so its file still gets every message at its level.
+func Quiet(l iface.Logger) iface.Logger {
+	if file, ok := l.(*FileLogger); ok {
+		quiet := *file
+		quiet.inner = NewQuietLogger(file.inner)
+		return &quiet
+	}
+	return NewQuietLogger(l)
+}
+
+func (l *FileLogger) Title(msg string, args ...any) {
+	l.inner.Title(msg, args...)
+	l.write("INFO", msg, args...)
+}
+
+func (l *FileLogger) Info(msg string, args ...any) {
+	l.inner.Info(msg, args...)
+	l.write("INFO", msg, args...)
+}
+
+func (l *FileLogger) Warn(msg string, args ...any) {
+	l.inner.Warn(msg, args...)
+	l.write("WARN", msg, args...)
+}
+
+func (l *FileLogger) Error(msg string, args ...any) {
+	l.inner.Error(msg, args...)
+	l.write("ERROR", msg, args...)
+}
+
+func (l *FileLogger) Debug(msg string, args ...any) {
+	l.inner.Debug(msg, args...)
+	l.write("DEBUG", msg, args...)
+}
+
+// write appends one line per message line, so multi-line messages keep their level and time
+func (l *FileLogger) write(level, msg string, args ...any) {
+	formatted := Redact(fmt.Sprintf(msg, args...))
+	timestamp := l.now().UTC().Format(time.RFC3339)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, line := range strings.Split(strings.Trim(formatted, "\n"), "\n") {
+		// Logging must never break the command, so write errors are ignored
+		_, _ = fmt.Fprintf(l.out, "%s %-5s %s\n", timestamp, level, line)
+	}
+}
+
+// OpenLogFile opens path for appending, creating its directory. A file already larger than
+// maxBytes is first rotated to path.1, shifting older backups and keeping at most backups.
+func OpenLogFile(path string, maxBytes int64, backups int) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	if info, err := os.Stat(path); err == nil && info.Size() >= maxBytes {
+		if err := rotateLogFile(path, backups); err != nil {
+			return nil, err
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	return file, nil
+}
+
+// rotateLogFile renames path to path.1, path.1 to path.2 and so on, dropping the oldest backup
+func rotateLogFile(path string, backups int) error {
+	if backups < 1 {
+		return os.Remove(path)
+	}
+	_ = os.Remove(fmt.Sprintf("%s.%d", path, backups))
+	for i := backups - 1; i >= 1; i-- {
+		older := fmt.Sprintf("%s.%d", path, i)
+		if _, err := os.Stat(older); err == nil {
+			if err := os.Rename(older, fmt.Sprintf("%s.%d", path, i+1)); err != nil {
+				return fmt.Errorf("failed to rotate log file: %w", err)
+			}
+		}
+	}
+	if err := os.Rename(path, path+".1"); err != nil {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+	return nil
+}
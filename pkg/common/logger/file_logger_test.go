@@ -0,0 +1,103 @@
+package logger
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileLogger_TeesToInnerAndWriter(t *testing.T) {
+	inner := NewNoopLogger()
+	var buf bytes.Buffer
+	logger := NewFileLogger(inner, &buf)
+	logger.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	logger.Info("Transaction sent: %s", "0xabc")
+	logger.Debug("line one\nline two")
+
+	assert.Equal(t, 2, inner.Len())
+	assert.Equal(t, "2025-01-02T03:04:05Z INFO  Transaction sent: 0xabc\n"+
+		"2025-01-02T03:04:05Z DEBUG line one\n"+
+		"2025-01-02T03:04:05Z DEBUG line two\n", buf.String())
+}
+
+func TestFileLogger_RedactsRegisteredSecrets(t *testing.T) {
+	key := "0x" + strings.Repeat("ab", 32)
+	RegisterSecret(key)
+	RegisterSecret("pw7")
+	RegisterSecret("pw7-extended")
+
+	var buf bytes.Buffer
+	logger := NewFileLogger(NewNoopLogger(), &buf)
+	logger.Info("key=%s bare=%s short=%s long=%s", key, strings.TrimPrefix(key, "0x"), "pw7", "pw7-extended")
+
+	out := buf.String()
+	assert.NotContains(t, out, strings.Repeat("ab", 32))
+	assert.Contains(t, out, "key=[REDACTED] bare=[REDACTED] short=[REDACTED] long=[REDACTED]\n")
+}
+
+func TestFileLogger_ShortSecretsOnlyRedactedAsTokens(t *testing.T) {
+	RegisterSecret("beef")
+
+	var buf bytes.Buffer
+	logger := NewFileLogger(NewNoopLogger(), &buf)
+	txHash := "0x" + strings.Repeat("1234beef", 8)
+	logger.Info("Transaction sent: %s", txHash)
+	logger.Info("DB_PASSWORD=%s port=%s", "beef", "beef8080")
+
+	out := buf.String()
+	assert.Contains(t, out, "Transaction sent: "+txHash+"\n")
+	assert.Contains(t, out, "DB_PASSWORD=[REDACTED] port=beef8080\n")
+}
+
+func TestQuiet_KeepsFileLevels(t *testing.T) {
+	inner := NewNoopLogger()
+	var buf bytes.Buffer
+	file := NewFileLogger(inner, &buf)
+	file.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+
+	logger := Quiet(file)
+	logger.Title("Deploying")
+	logger.Info("Transaction sent")
+	logger.Warn("Low balance")
+
+	assert.Equal(t, "2025-01-02T03:04:05Z INFO  Deploying\n"+
+		"2025-01-02T03:04:05Z INFO  Transaction sent\n"+
+		"2025-01-02T03:04:05Z WARN  Low balance\n", buf.String())
+	assert.Equal(t, []string{"Deploying", "Transaction sent"}, inner.GetMessagesByLevel("DEBUG"))
+	assert.IsType(t, &QuietLogger{}, Quiet(inner))
+}
+
+func TestOpenLogFile_Rotates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "eigenx.log")
+
+	file, err := OpenLogFile(path, 4, 2)
+	require.NoError(t, err)
+	_, err = file.WriteString("first")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	for _, content := range []string{"second", "third"} {
+		file, err = OpenLogFile(path, 4, 2)
+		require.NoError(t, err)
+		_, err = file.WriteString(content)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+	}
+
+	current, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "third", string(current))
+	backup, err := os.ReadFile(path + ".1")
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(backup))
+	oldest, err := os.ReadFile(path + ".2")
+	require.NoError(t, err)
+	assert.Equal(t, "first", string(oldest))
+}
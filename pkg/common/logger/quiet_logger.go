@@ -0,0 +1,36 @@
+package logger
+
+import (
+	"github.com/Layr-Labs/eigenx-cli/pkg/common/iface"
+)
+
+// QuietLogger demotes titles and info messages to debug level, leaving warnings and
+// errors untouched. It is used when a command should only print its final result.
+type QuietLogger struct {
+	inner iface.Logger
+}
+
+// NewQuietLogger wraps inner so that only warnings, errors and (when verbose) debug output remain
+func NewQuietLogger(inner iface.Logger) *QuietLogger {
+	return &QuietLogger{inner: inner}
+}
+
+func (l *QuietLogger) Title(msg string, args ...any) {
+	l.inner.Debug(msg, args...)
+}
+
+func (l *QuietLogger) Info(msg string, args ...any) {
+	l.inner.Debug(msg, args...)
+}
+
+func (l *QuietLogger) Warn(msg string, args ...any) {
+	l.inner.Warn(msg, args...)
+}
+
+func (l *QuietLogger) Error(msg string, args ...any) {
+	l.inner.Error(msg, args...)
+}
+
+func (l *QuietLogger) Debug(msg string, args ...any) {
+	l.inner.Debug(msg, args...)
+}
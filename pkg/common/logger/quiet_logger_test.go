@@ -0,0 +1,24 @@
+package logger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuietLogger(t *testing.T) {
+	inner := NewNoopLogger()
+	quiet := NewQuietLogger(inner)
+
+	quiet.Title("building %s", "image")
+	quiet.Info("pushing %s", "image")
+	quiet.Warn("slow registry")
+	quiet.Error("push failed")
+	quiet.Debug("details")
+
+	assert.Equal(t, []string{"building image", "pushing image", "details"}, inner.GetMessagesByLevel("DEBUG"))
+	assert.Equal(t, []string{"slow registry"}, inner.GetMessagesByLevel("WARN"))
+	assert.Equal(t, []string{"push failed"}, inner.GetMessagesByLevel("ERROR"))
+	assert.Empty(t, inner.GetEntriesByLevel("INFO"))
+	assert.Empty(t, inner.GetEntriesByLevel("TITLE"))
+}
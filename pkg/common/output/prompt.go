@@ -64,11 +64,20 @@ func InputString(prompt, help, defaultValue string, validator func(string) error
 
 // SelectString prompts the user to select from a list of string options
 func SelectString(prompt string, options []string) (string, error) {
+	return SelectStringWithDefault(prompt, options, "")
+}
+
+// SelectStringWithDefault prompts the user to select one of options with defaultOption
+// preselected. An empty defaultOption preselects the first option.
+func SelectStringWithDefault(prompt string, options []string, defaultOption string) (string, error) {
 	var result string
 	s := &survey.Select{
 		Message: prompt,
 		Options: options,
 	}
+	if defaultOption != "" {
+		s.Default = defaultOption
+	}
 
 	err := survey.AskOne(s, &result)
 	return result, err
@@ -0,0 +1,143 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+)
+
+// replacementFeeBumpPercentage raises the fees of a pending transaction that is re-sent to speed
+// it up. Nodes only accept a replacement paying at least 10% more than the transaction it replaces.
+const replacementFeeBumpPercentage = 12
+
+// bumpFee raises fee by replacementFeeBumpPercentage
+func bumpFee(fee *big.Int) *big.Int {
+	bumped := new(big.Int).Mul(fee, big.NewInt(100+replacementFeeBumpPercentage))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+// speedUpFees returns the tip and fee cap to re-send tx with: its own fees bumped by
+// replacementFeeBumpPercentage, or the current ones when the network has moved above that
+func speedUpFees(tx *types.Transaction, gasTipCap, gasFeeCap *big.Int) (*big.Int, *big.Int) {
+	tip := bumpFee(tx.GasTipCap())
+	if gasTipCap.Cmp(tip) > 0 {
+		tip = gasTipCap
+	}
+	feeCap := bumpFee(tx.GasFeeCap())
+	if gasFeeCap.Cmp(feeCap) > 0 {
+		feeCap = gasFeeCap
+	}
+	if tip.Cmp(feeCap) > 0 {
+		feeCap = tip
+	}
+	return tip, feeCap
+}
+
+// speedUpTransaction returns an unsigned copy of tx with new fees. The nonce, gas limit,
+// recipient, value, data and authorizations are kept, so the copy performs the same call.
+func speedUpTransaction(tx *types.Transaction, gasTipCap, gasFeeCap *big.Int) (*types.Transaction, error) {
+	switch tx.Type() {
+	case types.DynamicFeeTxType:
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  gasTipCap,
+			GasFeeCap:  gasFeeCap,
+			Gas:        tx.Gas(),
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		}), nil
+	case types.SetCodeTxType:
+		return types.NewTx(&types.SetCodeTx{
+			ChainID:    uint256.MustFromBig(tx.ChainId()),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  uint256.MustFromBig(gasTipCap),
+			GasFeeCap:  uint256.MustFromBig(gasFeeCap),
+			Gas:        tx.Gas(),
+			To:         *tx.To(),
+			Value:      uint256.MustFromBig(tx.Value()),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+			AuthList:   tx.SetCodeAuthorizations(),
+		}), nil
+	default:
+		return nil, fmt.Errorf("transaction type %d can't be sped up", tx.Type())
+	}
+}
+
+// checkPendingTransactions checks for earlier transactions of this account still waiting in the
+// mempool, which the next transaction would otherwise queue behind. When the replacePending
+// callback agrees, the oldest one is re-sent at a higher gas price so the queue moves again.
+func (cc *ContractCaller) checkPendingTransactions(ctx context.Context) error {
+	callCtx, cancel := cc.rpcContext(ctx)
+	pending, err := cc.ethclient.PendingNonceAt(callCtx, cc.SelfAddress)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to get nonce: %w", err)
+	}
+	callCtx, cancel = cc.rpcContext(ctx)
+	mined, err := cc.ethclient.NonceAt(callCtx, cc.SelfAddress, nil)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to get mined nonce: %w", err)
+	}
+	if pending <= mined {
+		return nil
+	}
+
+	cc.logger.Warn("%d earlier transaction(s) from %s are still pending (nonces %d to %d); a new transaction waits until they are mined", pending-mined, cc.SelfAddress.Hex(), mined, pending-1)
+	if cc.replacePending == nil {
+		return nil
+	}
+	replace, err := cc.replacePending()
+	if err != nil || !replace {
+		return err
+	}
+	return cc.speedUpPending(ctx, mined)
+}
+
+// speedUpPending re-sends the pending transaction with nonce at a higher gas price. It doesn't
+// wait for the replacement to be mined; the next transaction queues behind it.
+func (cc *ContractCaller) speedUpPending(ctx context.Context, nonce uint64) error {
+	callCtx, cancel := cc.rpcContext(ctx)
+	tx, err := cc.ethclient.PendingTransaction(callCtx, cc.SelfAddress, nonce)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to read pending transaction with nonce %d: %w", nonce, err)
+	}
+	if tx == nil {
+		return fmt.Errorf("the RPC node doesn't hold the pending transaction with nonce %d; wait for it to be mined or proceed without speeding it up", nonce)
+	}
+
+	gasTipCap, gasFeeCap, err := cc.suggestFees(ctx)
+	if err != nil {
+		return err
+	}
+	gasTipCap, gasFeeCap = speedUpFees(tx, gasTipCap, gasFeeCap)
+	replacement, err := speedUpTransaction(tx, gasTipCap, gasFeeCap)
+	if err != nil {
+		return err
+	}
+
+	signedTx, err := types.SignTx(replacement, types.LatestSignerForChainID(cc.chainID), cc.privateKey)
+	if err != nil {
+		return fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	sendCtx, cancel := cc.rpcContext(ctx)
+	err = cc.ethclient.SendTransaction(sendCtx, signedTx)
+	cancel()
+	if err != nil {
+		if strings.Contains(strings.ToLower(err.Error()), "replacement transaction underpriced") {
+			return fmt.Errorf("failed to speed up pending transaction with nonce %d: %w (it was already re-sent at a higher gas price; wait for it to be mined)", nonce, err)
+		}
+		return fmt.Errorf("failed to speed up pending transaction with nonce %d: %w", nonce, err)
+	}
+	cc.logger.Info("Re-sent pending transaction with nonce %d at a higher gas price (hash: %s, replaces %s)", nonce, signedTx.Hash().Hex(), tx.Hash().Hex())
+	return nil
+}
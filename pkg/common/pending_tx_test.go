@@ -0,0 +1,62 @@
+package common
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBumpFee(t *testing.T) {
+	fee := big.NewInt(1_000_000_000)
+	bumped := bumpFee(fee)
+
+	assert.Equal(t, big.NewInt(1_120_000_000), bumped)
+	assert.Equal(t, big.NewInt(1_000_000_000), fee, "the input is not modified")
+
+	// Nodes require a replacement to pay at least 10% more
+	minimum := new(big.Int).Div(new(big.Int).Mul(fee, big.NewInt(110)), big.NewInt(100))
+	assert.True(t, bumped.Cmp(minimum) >= 0)
+}
+
+func TestSpeedUpFees(t *testing.T) {
+	tx := types.NewTx(&types.DynamicFeeTx{GasTipCap: big.NewInt(100), GasFeeCap: big.NewInt(1000)})
+
+	tip, feeCap := speedUpFees(tx, big.NewInt(50), big.NewInt(500))
+	assert.Equal(t, big.NewInt(112), tip, "the pending fees are bumped")
+	assert.Equal(t, big.NewInt(1120), feeCap)
+
+	tip, feeCap = speedUpFees(tx, big.NewInt(200), big.NewInt(3000))
+	assert.Equal(t, big.NewInt(200), tip, "higher current fees are used as is")
+	assert.Equal(t, big.NewInt(3000), feeCap)
+}
+
+func TestSpeedUpTransaction(t *testing.T) {
+	to := common.HexToAddress("0x1234")
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(11155111),
+		Nonce:     7,
+		GasTipCap: big.NewInt(100),
+		GasFeeCap: big.NewInt(1000),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(5),
+		Data:      []byte{0xde, 0xad},
+	})
+
+	replacement, err := speedUpTransaction(tx, big.NewInt(112), big.NewInt(1120))
+	require.NoError(t, err)
+	assert.Equal(t, tx.Nonce(), replacement.Nonce())
+	assert.Equal(t, tx.Gas(), replacement.Gas())
+	assert.Equal(t, tx.To(), replacement.To())
+	assert.Equal(t, tx.Value(), replacement.Value())
+	assert.Equal(t, tx.Data(), replacement.Data())
+	assert.Equal(t, big.NewInt(112), replacement.GasTipCap())
+	assert.Equal(t, big.NewInt(1120), replacement.GasFeeCap())
+
+	_, err = speedUpTransaction(types.NewTx(&types.LegacyTx{}), big.NewInt(1), big.NewInt(1))
+	assert.Error(t, err)
+}
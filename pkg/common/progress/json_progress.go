@@ -0,0 +1,91 @@
+package progress
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/common/iface"
+)
+
+// JSONProgressEvent is a single progress update written by JSONProgressTracker
+type JSONProgressEvent struct {
+	Stage string `json:"stage"`
+	Pct   int    `json:"pct"`
+	Label string `json:"label"`
+	Time  string `json:"time"`
+}
+
+// JSONProgressTracker writes every progress update as a JSON line, for tools that
+// render their own progress indicator
+type JSONProgressTracker struct {
+	mu       sync.Mutex
+	target   io.Writer
+	progress map[string]*iface.ProgressInfo
+	order    []string
+}
+
+func NewJSONProgressTracker(target io.Writer) *JSONProgressTracker {
+	return &JSONProgressTracker{
+		target:   target,
+		progress: make(map[string]*iface.ProgressInfo),
+	}
+}
+
+// ProgressRows returns all progress entries, in the order they were first reported.
+// It is safe to call from multiple goroutines.
+func (t *JSONProgressTracker) ProgressRows() []iface.ProgressRow {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	rows := make([]iface.ProgressRow, 0, len(t.order))
+	for _, id := range t.order {
+		info := t.progress[id]
+		rows = append(rows, iface.ProgressRow{
+			Module: id,
+			Pct:    info.Percentage,
+			Label:  info.DisplayText,
+		})
+	}
+	return rows
+}
+
+func (t *JSONProgressTracker) Set(id string, pct int, label string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ts := time.Now().UTC().Format(time.RFC3339)
+
+	if info, exists := t.progress[id]; exists {
+		info.Percentage = pct
+		info.DisplayText = label
+		info.Timestamp = ts
+	} else {
+		t.progress[id] = &iface.ProgressInfo{
+			Percentage:  pct,
+			DisplayText: label,
+			Timestamp:   ts,
+		}
+		t.order = append(t.order, id)
+	}
+
+	// Every update is emitted, including repeats after a retry
+	line, err := json.Marshal(JSONProgressEvent{Stage: id, Pct: pct, Label: label, Time: ts})
+	if err != nil {
+		return
+	}
+	_, _ = t.target.Write(append(line, '\n'))
+}
+
+func (t *JSONProgressTracker) Render() {
+	// no-op - every update is written at Set
+}
+
+func (t *JSONProgressTracker) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.progress = make(map[string]*iface.ProgressInfo)
+	t.order = t.order[:0]
+}
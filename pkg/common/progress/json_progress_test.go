@@ -0,0 +1,36 @@
+package progress
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONProgressTracker(t *testing.T) {
+	var buf bytes.Buffer
+	tracker := NewJSONProgressTracker(&buf)
+
+	tracker.Set("build", 0, "Building")
+	tracker.Set("build", 100, "Built")
+	tracker.Set("push", 0, "Pushing")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+
+	var event JSONProgressEvent
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &event))
+	assert.Equal(t, "build", event.Stage)
+	assert.Equal(t, 100, event.Pct)
+	assert.Equal(t, "Built", event.Label)
+	assert.NotEmpty(t, event.Time)
+
+	rows := tracker.ProgressRows()
+	require.Len(t, rows, 2)
+	assert.Equal(t, "build", rows[0].Module)
+	assert.Equal(t, 100, rows[0].Pct)
+	assert.Equal(t, "push", rows[1].Module)
+}
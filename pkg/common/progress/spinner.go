@@ -0,0 +1,60 @@
+package progress
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+const spinnerInterval = 100 * time.Millisecond
+
+// Spinner shows an animated message on a single line while a slow operation runs.
+// It draws nothing when stdout is not a terminal.
+type Spinner struct {
+	message string
+	target  *os.File
+	stop    chan struct{}
+	done    sync.WaitGroup
+	once    sync.Once
+}
+
+// StartSpinner starts a spinner with the given message. Call Stop when the operation completes.
+func StartSpinner(message string) *Spinner {
+	s := &Spinner{
+		message: message,
+		target:  os.Stdout,
+		stop:    make(chan struct{}),
+	}
+	if !IsTTY() {
+		return s
+	}
+
+	s.done.Add(1)
+	go func() {
+		defer s.done.Done()
+		ticker := time.NewTicker(spinnerInterval)
+		defer ticker.Stop()
+
+		for i := 0; ; i++ {
+			fmt.Fprintf(s.target, "\r\033[K%s %s", spinnerFrames[i%len(spinnerFrames)], s.message)
+			select {
+			case <-s.stop:
+				fmt.Fprint(s.target, "\r\033[K")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return s
+}
+
+// Stop stops the spinner and clears its line. It is safe to call more than once.
+func (s *Spinner) Stop() {
+	s.once.Do(func() {
+		close(s.stop)
+		s.done.Wait()
+	})
+}
@@ -2,12 +2,17 @@ package common
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/Layr-Labs/eigenx-cli/pkg/common/iface"
 	"github.com/Layr-Labs/eigenx-cli/pkg/common/logger"
@@ -21,6 +26,9 @@ type loggerContextKey struct{}
 // progressTrackerContextKey is used to store the progress tracker in the context
 type progressTrackerContextKey struct{}
 
+// quietOutputContextKey marks a context whose command should only print its final result
+type quietOutputContextKey struct{}
+
 // GetLoggerFromCLIContext creates a logger based on the CLI context
 // It checks the verbose flag and returns the appropriate logger
 func GetLoggerFromCLIContext(cCtx *cli.Context) (iface.Logger, iface.ProgressTracker) {
@@ -60,6 +68,25 @@ func WithProgressTracker(ctx context.Context, tracker iface.ProgressTracker) con
 	return context.WithValue(ctx, progressTrackerContextKey{}, tracker)
 }
 
+// WithQuietOutput marks the context so tool output (build and push logs, watch countdowns) is suppressed
+func WithQuietOutput(ctx context.Context) context.Context {
+	return context.WithValue(ctx, quietOutputContextKey{}, true)
+}
+
+// IsQuietOutput reports whether tool output should be suppressed for this context
+func IsQuietOutput(ctx context.Context) bool {
+	quiet, _ := ctx.Value(quietOutputContextKey{}).(bool)
+	return quiet
+}
+
+// RPCContext derives the context for a single RPC call, bounded by timeout when it is positive
+func RPCContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
 // LoggerFromContext retrieves the logger from the context
 // If no logger is found, it returns a non-verbose logger as fallback
 func LoggerFromContext(cCtx *cli.Context) iface.Logger {
@@ -147,6 +174,69 @@ func PeelBoolFromFlags(args []string, longFlag, shortFlag string) bool {
 	return value
 }
 
+// PeelStringFromFlags returns the last value given for a string flag in raw argv, accepting
+// both "--flag value" and "--flag=value". The second result reports whether the flag was found.
+func PeelStringFromFlags(args []string, longFlag string) (string, bool) {
+	value, found := "", false
+	for i := 0; i < len(args); i++ {
+		token := args[i]
+		switch {
+		case token == "--":
+			return value, found
+		case token == longFlag && i+1 < len(args):
+			value, found = args[i+1], true
+			i++
+		case strings.HasPrefix(token, longFlag+"="):
+			value, found = strings.TrimPrefix(token, longFlag+"="), true
+		}
+	}
+	return value, found
+}
+
+// CommandLineForLog returns the command path and flag names of raw argv for logging. Flag
+// values and positional arguments may hold secrets, so they are left out.
+func CommandLineForLog(commands []*cli.Command, args []string) string {
+	var parts []string
+	for _, token := range args {
+		if token == "--" {
+			break
+		}
+		if strings.HasPrefix(token, "-") {
+			name, _, _ := strings.Cut(token, "=")
+			parts = append(parts, name)
+			continue
+		}
+		for _, command := range commands {
+			if command.HasName(token) {
+				parts = append(parts, token)
+				commands = command.Subcommands
+				break
+			}
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// ResolveLogFilePath maps the --log-file value to a path. "default" selects
+// <config dir>/logs/eigenx.log; a leading ~ is expanded to the home directory.
+func ResolveLogFilePath(value string) (string, error) {
+	if value == DefaultLogFileValue {
+		configDir, err := GetGlobalConfigDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(configDir, "logs", "eigenx.log"), nil
+	}
+	if strings.HasPrefix(value, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("unable to determine home directory: %w", err)
+		}
+		value = filepath.Join(homeDir, value[2:])
+	}
+	return filepath.Abs(value)
+}
+
 // ValidateAppName validates that an app name follows Docker image naming restrictions
 func ValidateAppName(name string) error {
 	if name == "" {
@@ -196,6 +286,63 @@ func Parallel[T1, T2 any](fn1 func() (T1, error), fn2 func() (T2, error)) (T1, T
 	return res1, res2, err2
 }
 
+// ParallelCtx executes two functions concurrently with a shared context that is cancelled as soon
+// as either fails, and returns both results and the errors of both functions joined. The
+// cancellation error of the function stopped because of the other's failure is left out.
+func ParallelCtx[T1, T2 any](ctx context.Context, fn1 func(context.Context) (T1, error), fn2 func(context.Context) (T2, error)) (T1, T2, error) {
+	var res1 T1
+	var res2 T2
+	var err1, err2 error
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if res1, err1 = fn1(runCtx); err1 != nil {
+			cancel()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		if res2, err2 = fn2(runCtx); err2 != nil {
+			cancel()
+		}
+	}()
+	wg.Wait()
+
+	if err1 != nil && err2 != nil && ctx.Err() == nil {
+		if errors.Is(err2, context.Canceled) {
+			err2 = nil
+		} else if errors.Is(err1, context.Canceled) {
+			err1 = nil
+		}
+	}
+	return res1, res2, errors.Join(err1, err2)
+}
+
+// ParallelEach calls fn for every index below count, running at most limit calls at once, and
+// returns the error of each call by index. A failed call does not stop the others.
+func ParallelEach(count, limit int, fn func(i int) error) []error {
+	errs := make([]error, count)
+	slots := make(chan struct{}, max(limit, 1))
+
+	var wg sync.WaitGroup
+	wg.Add(count)
+	for i := range count {
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			errs[i] = fn(i)
+		}()
+	}
+	wg.Wait()
+	return errs
+}
+
 // IsMainnetEnvironment checks if the given environment is a mainnet environment
 func IsMainnetEnvironment(env string) bool {
 	return strings.Contains(env, "mainnet")
@@ -241,3 +388,69 @@ func CreateTempDir(prefix string) (string, error) {
 	}
 	return tempDir, nil
 }
+
+// ReservedEnvVarReason reports whether name is a protocol-reserved environment variable
+// and, if so, why. The comparison is case-insensitive and ignores the _PUBLIC suffix, which only
+// marks a variable as public, so EIGEN_MACHINE_TYPE is reserved as well as EIGEN_MACHINE_TYPE_PUBLIC.
+func ReservedEnvVarReason(name string) (string, bool) {
+	base := strings.TrimSuffix(strings.ToUpper(name), "_PUBLIC")
+	for reserved, reason := range ReservedEnvVars {
+		if base == strings.TrimSuffix(reserved, "_PUBLIC") {
+			return reason, true
+		}
+	}
+	return "", false
+}
+
+// SanitizeTerminalOutput makes untrusted output safe to print to a terminal. Invalid UTF-8
+// bytes and control characters are escaped as \xNN, except newlines (LF or CRLF), tabs and ANSI color
+// (SGR) sequences, which are passed through so that ordinary colored logs render as-is.
+func SanitizeTerminalOutput(s string) string {
+	var sb strings.Builder
+	sb.Grow(len(s))
+
+	for i := 0; i < len(s); {
+		if s[i] == '\x1b' {
+			if n := sgrSequenceLength(s[i:]); n > 0 {
+				sb.WriteString(s[i : i+n])
+				i += n
+				continue
+			}
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size <= 1:
+			fmt.Fprintf(&sb, "\\x%02x", s[i])
+		case r == '\n' || r == '\t' || (r == '\r' && strings.HasPrefix(s[i+1:], "\n")):
+			sb.WriteRune(r)
+		case unicode.IsControl(r):
+			for j := 0; j < size; j++ {
+				fmt.Fprintf(&sb, "\\x%02x", s[i+j])
+			}
+		default:
+			sb.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+	return sb.String()
+}
+
+// sgrSequenceLength returns the length of the ANSI SGR sequence (ESC [ params m) at the
+// start of s, or 0 if s does not start with one
+func sgrSequenceLength(s string) int {
+	if len(s) < 3 || s[0] != '\x1b' || s[1] != '[' {
+		return 0
+	}
+	for i := 2; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == 'm':
+			return i + 1
+		case c == ';' || (c >= '0' && c <= '9'):
+			continue
+		default:
+			return 0
+		}
+	}
+	return 0
+}
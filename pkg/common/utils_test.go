@@ -1,9 +1,15 @@
 package common
 
 import (
+	"context"
+	"errors"
 	"reflect"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/urfave/cli/v2"
 )
 
 func TestGetLogger_ReturnsLoggerAndTracker(t *testing.T) {
@@ -146,3 +152,225 @@ func TestValidateAppName(t *testing.T) {
 		})
 	}
 }
+
+func TestReservedEnvVarReason(t *testing.T) {
+	tests := []struct {
+		name     string
+		reserved bool
+	}{
+		{EigenMachineTypeEnvVar, true},
+		{strings.ToLower(EigenMachineTypeEnvVar), true},
+		{MnemonicEnvVar, true},
+		{"EIGEN_MACHINE_TYPE", true},
+		{"mnemonic_public", true},
+		{"DOMAIN", false},
+		{"EIGEN_MACHINE_TYPE_PUBLIC_EXTRA", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, reserved := ReservedEnvVarReason(tt.name)
+			if reserved != tt.reserved {
+				t.Errorf("ReservedEnvVarReason(%q) reserved = %v, expected %v", tt.name, reserved, tt.reserved)
+			}
+			if reserved && reason == "" {
+				t.Errorf("ReservedEnvVarReason(%q) returned an empty reason", tt.name)
+			}
+		})
+	}
+}
+
+func TestSanitizeTerminalOutput(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"plain text", "hello world\n\tindented", "hello world\n\tindented"},
+		{"unicode", "héllo ✓ 日本", "héllo ✓ 日本"},
+		{"color codes kept", "\x1b[1;31merror\x1b[0m", "\x1b[1;31merror\x1b[0m"},
+		{"cursor movement escaped", "\x1b[2Jcleared", "\\x1b[2Jcleared"},
+		{"invalid utf8", "bad\xff\xfebytes", "bad\\xff\\xfebytes"},
+		{"control characters", "bell\x07nul\x00cr\r", "bell\\x07nul\\x00cr\\x0d"},
+		{"crlf kept", "line1\r\nline2", "line1\r\nline2"},
+		{"c1 control", "a\u009bb", "a\\xc2\\x9bb"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeTerminalOutput(tt.input); got != tt.expected {
+				t.Errorf("SanitizeTerminalOutput(%q) = %q, expected %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPeelStringFromFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+		found    bool
+	}{
+		{"separate value", []string{"app", "deploy", "--log-file", "/tmp/a.log"}, "/tmp/a.log", true},
+		{"equals form", []string{"--log-file=default", "app", "info"}, "default", true},
+		{"last wins", []string{"--log-file", "a", "--log-file=b"}, "b", true},
+		{"missing value", []string{"app", "--log-file"}, "", false},
+		{"after terminator", []string{"app", "--", "--log-file", "x"}, "", false},
+		{"absent", []string{"app", "info"}, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := PeelStringFromFlags(tt.args, "--log-file")
+			if got != tt.expected || found != tt.found {
+				t.Errorf("PeelStringFromFlags(%q) = (%q, %v), expected (%q, %v)", tt.args, got, found, tt.expected, tt.found)
+			}
+		})
+	}
+}
+
+func TestCommandLineForLog(t *testing.T) {
+	commands := []*cli.Command{
+		{Name: "app", Subcommands: []*cli.Command{{Name: "deploy"}, {Name: "env", Subcommands: []*cli.Command{{Name: "set"}}}}},
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{"flag values", []string{"app", "deploy", "--private-key", "0xabc", "--log-file=/tmp/a.log", "ghcr.io/acme/app"}, "app deploy --private-key --log-file"},
+		{"positional secrets", []string{"--verbose", "app", "env", "set", "my-app", "TOKEN=hunter2"}, "--verbose app env set"},
+		{"after terminator", []string{"app", "deploy", "--", "--secret"}, "app deploy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CommandLineForLog(commands, tt.args); got != tt.expected {
+				t.Errorf("CommandLineForLog(%q) = %q, expected %q", tt.args, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRPCContext(t *testing.T) {
+	ctx, cancel := RPCContext(context.Background(), time.Minute)
+	defer cancel()
+	if _, ok := ctx.Deadline(); !ok {
+		t.Error("RPCContext with a timeout should set a deadline")
+	}
+
+	unbounded, cancelUnbounded := RPCContext(context.Background(), 0)
+	defer cancelUnbounded()
+	if _, ok := unbounded.Deadline(); ok {
+		t.Error("RPCContext with a zero timeout should not set a deadline")
+	}
+
+	cancelUnbounded()
+	if unbounded.Err() == nil {
+		t.Error("cancel should end the derived context")
+	}
+}
+
+func TestParallelCtx(t *testing.T) {
+	n, s, err := ParallelCtx(context.Background(),
+		func(context.Context) (int, error) { return 1, nil },
+		func(context.Context) (string, error) { return "a", nil },
+	)
+	if err != nil || n != 1 || s != "a" {
+		t.Errorf("ParallelCtx = %d, %q, %v; want 1, \"a\", nil", n, s, err)
+	}
+
+	// A failure cancels the other function, whose cancellation error is not reported
+	failure := errors.New("status call failed")
+	_, _, err = ParallelCtx(context.Background(),
+		func(context.Context) (int, error) { return 0, failure },
+		func(ctx context.Context) (string, error) {
+			select {
+			case <-ctx.Done():
+				return "", ctx.Err()
+			case <-time.After(10 * time.Second):
+				return "", errors.New("sibling was not cancelled")
+			}
+		},
+	)
+	if err == nil || !errors.Is(err, failure) || errors.Is(err, context.Canceled) {
+		t.Errorf("ParallelCtx error = %v; want only %v", err, failure)
+	}
+
+	// Independent failures are both reported
+	other := errors.New("block number call failed")
+	_, _, err = ParallelCtx(context.Background(),
+		func(context.Context) (int, error) { return 0, failure },
+		func(context.Context) (string, error) { return "", other },
+	)
+	if !errors.Is(err, failure) || !errors.Is(err, other) {
+		t.Errorf("ParallelCtx error = %v; want both %v and %v", err, failure, other)
+	}
+}
+
+func TestParallelEach(t *testing.T) {
+	var running, peak atomic.Int32
+	failure := errors.New("push failed")
+
+	errs := ParallelEach(6, 2, func(i int) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		if i == 3 {
+			return failure
+		}
+		return nil
+	})
+
+	if len(errs) != 6 {
+		t.Fatalf("ParallelEach returned %d errors; want 6", len(errs))
+	}
+	for i, err := range errs {
+		if (i == 3) != errors.Is(err, failure) {
+			t.Errorf("error %d = %v", i, err)
+		}
+	}
+	if peak.Load() > 2 {
+		t.Errorf("ParallelEach ran %d calls at once; want at most 2", peak.Load())
+	}
+}
+
+func TestUpgradeByTimeForSubmission(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	fresh := NewUpgradeByTime(now)
+
+	tests := []struct {
+		name          string
+		upgradeByTime uint32
+		wantRefreshed bool
+	}{
+		{name: "just assembled", upgradeByTime: fresh},
+		{name: "well within the window", upgradeByTime: uint32(now.Add(30 * time.Minute).Unix())},
+		{name: "close to lapsing", upgradeByTime: uint32(now.Add(5 * time.Minute).Unix()), wantRefreshed: true},
+		{name: "lapsed", upgradeByTime: uint32(now.Add(-time.Minute).Unix()), wantRefreshed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, refreshed := upgradeByTimeForSubmission(tt.upgradeByTime, now)
+			if refreshed != tt.wantRefreshed {
+				t.Fatalf("refreshed = %v, want %v", refreshed, tt.wantRefreshed)
+			}
+			want := tt.upgradeByTime
+			if tt.wantRefreshed {
+				want = fresh
+			}
+			if got != want {
+				t.Errorf("upgrade-by time = %d, want %d", got, want)
+			}
+		})
+	}
+}
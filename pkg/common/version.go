@@ -41,6 +41,11 @@ var BuildDownloadURL = func(version, arch, distro string) string {
 		version + "/eigenx-cli-" + distro + "-" + arch + "-" + version + ext
 }
 
+// BuildChecksumURL returns the URL of the SHA-256 checksum published next to a release archive
+var BuildChecksumURL = func(downloadURL string) string {
+	return downloadURL + ".sha256"
+}
+
 // GetLatestVersionFromS3 fetches the latest version from the S3 bucket
 // If version is "latest", it fetches from the VERSION file
 // Otherwise, it returns the specified version (for explicit version upgrades)
@@ -159,17 +164,20 @@ func PrintUpdateNotification(info *UpdateInfo) {
 	// Build version line with proper padding (59 chars total visible width to match other lines)
 	versionText := fmt.Sprintf("  Current: %s  Latest: %s", info.CurrentVersion, info.LatestVersion)
 	padding := strings.Repeat(" ", 61-len(versionText))
-	versionLine := fmt.Sprintf("  Current: \033[2m%s\033[0m  Latest: \033[1;32m%s\033[0m%s",
-		info.CurrentVersion, info.LatestVersion, padding)
+	versionLine := fmt.Sprintf("  Current: %s  Latest: %s%s",
+		Style(info.CurrentVersion, StyleDim), Style(info.LatestVersion, StyleBold, StyleGreen), padding)
+
+	border := Style("│", StyleYellow)
+	blankLine := border + strings.Repeat(" ", 61) + border
 
 	fmt.Println()
-	fmt.Printf("\033[33m╭─────────────────────────────────────────────────────────────╮\033[0m\n")
-	fmt.Printf("\033[33m│\033[0m  \033[1mA new version of eigenx is available!\033[0m                      \033[33m│\033[0m\n")
-	fmt.Printf("\033[33m│\033[0m                                                             \033[33m│\033[0m\n")
-	fmt.Printf("\033[33m│\033[0m%s\033[33m│\033[0m\n", versionLine)
-	fmt.Printf("\033[33m│\033[0m                                                             \033[33m│\033[0m\n")
-	fmt.Printf("\033[33m│\033[0m  Run \033[1;36meigenx upgrade\033[0m to update                               \033[33m│\033[0m\n")
-	fmt.Printf("\033[33m╰─────────────────────────────────────────────────────────────╯\033[0m\n")
+	fmt.Println(Style("╭─────────────────────────────────────────────────────────────╮", StyleYellow))
+	fmt.Printf("%s  %s                      %s\n", border, Style("A new version of eigenx is available!", StyleBold), border)
+	fmt.Println(blankLine)
+	fmt.Printf("%s%s%s\n", border, versionLine, border)
+	fmt.Println(blankLine)
+	fmt.Printf("%s  Run %s to update                               %s\n", border, Style("eigenx upgrade", StyleBold, StyleCyan), border)
+	fmt.Println(Style("╰─────────────────────────────────────────────────────────────╯", StyleYellow))
 	fmt.Println()
 }
 
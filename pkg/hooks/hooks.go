@@ -98,6 +98,11 @@ func collectFlagValues(ctx *cli.Context) map[string]interface{} {
 func setupTelemetry(cCtx *cli.Context) telemetry.Client {
 	logger := common.LoggerFromContext(cCtx)
 
+	// --no-telemetry overrides the saved preference for this invocation only
+	if cCtx.Bool("no-telemetry") {
+		return telemetry.NewNoopClient()
+	}
+
 	// Get global telemetry preference
 	globalPref, err := common.GetGlobalTelemetryPreference()
 	if err != nil {
@@ -150,6 +155,12 @@ func WithFirstRunSetup(cCtx *cli.Context) error {
 		return nil // Not first run, continue normally
 	}
 
+	// Leave first-run setup pending under --quiet so its notices show on the next run
+	if common.IsQuietOutput(cCtx.Context) {
+		logger.Debug("Skipping first-run setup in quiet mode")
+		return nil
+	}
+
 	fmt.Println()
 	fmt.Println("Welcome to EigenX CLI!")
 	fmt.Println()
@@ -195,7 +206,7 @@ func setDeploymentEnvironment(logger iface.Logger) error {
 		return nil // Don't fail the command
 	}
 
-	fmt.Printf("✅ Deployment environment: \033[1m%s\033[0m\n", defaultEnv)
+	fmt.Printf("✅ Deployment environment: %s\n", common.Style(defaultEnv, common.StyleBold))
 	fmt.Println("You can change this later with: eigenx environment set <env>")
 
 	return nil
@@ -203,6 +214,10 @@ func setDeploymentEnvironment(logger iface.Logger) error {
 
 // handleTelemetrySetup handles the telemetry setup part of first-run setup
 func handleTelemetrySetup(cCtx *cli.Context, logger iface.Logger) error {
+	// Leave the preference unset so --no-telemetry does not persist a choice
+	if cCtx.Bool("no-telemetry") {
+		return nil
+	}
 
 	// Check for global flags that control telemetry behavior
 	opts := common.TelemetryPromptOptions{
@@ -229,6 +244,7 @@ func WithMetricEmission(action cli.ActionFunc) cli.ActionFunc {
 		// Run command action
 		err := action(ctx)
 
+		// setupTelemetry returns the noop client when --no-telemetry is set
 		client := setupTelemetry(ctx)
 		ctx.Context = telemetry.ContextWithClient(ctx.Context, client)
 		// emit result metrics
@@ -268,7 +284,7 @@ func WithVersionCheck(action cli.ActionFunc) cli.ActionFunc {
 		if versionCheckChannel != nil {
 			select {
 			case updateInfo := <-versionCheckChannel:
-				if updateInfo != nil && updateInfo.Available {
+				if updateInfo != nil && updateInfo.Available && !common.IsQuietOutput(ctx.Context) {
 					common.PrintUpdateNotification(updateInfo)
 				}
 			default:
@@ -35,6 +35,9 @@ type TemplateEntry struct {
 	Description string `json:"description"`
 	PostProcess struct {
 		ReplaceNameIn []string `json:"replaceNameIn,omitempty"`
+		// Variables are prompted for (or set with --var) and substituted in SubstituteIn
+		Variables    []TemplateVariable `json:"variables,omitempty"`
+		SubstituteIn []string           `json:"substituteIn,omitempty"`
 	} `json:"postProcess,omitempty"`
 }
 
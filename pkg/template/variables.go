@@ -0,0 +1,102 @@
+package template
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+)
+
+// ProjectNameVariable is always available to templates and holds the new project's name
+const ProjectNameVariable = "PROJECT_NAME"
+
+// variableNamePattern matches names usable as {{NAME}}, which must be Go identifiers
+var variableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// TemplateVariable is a placeholder a template declares for `eigenx app create` to fill in
+type TemplateVariable struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Default     string `json:"default,omitempty"`
+}
+
+// ParseVariableAssignments parses NAME=VALUE pairs as given with --var
+func ParseVariableAssignments(assignments []string) (map[string]string, error) {
+	values := make(map[string]string, len(assignments))
+	for _, assignment := range assignments {
+		name, value, ok := strings.Cut(assignment, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid template variable %q, expected NAME=VALUE", assignment)
+		}
+		values[strings.TrimSpace(name)] = value
+	}
+	return values, nil
+}
+
+// SubstituteVariables renders content as a text/template in which each variable can be written
+// as {{NAME}} or {{.NAME}}. Referencing an undefined variable is an error.
+func SubstituteVariables(name, content string, values map[string]string) (string, error) {
+	funcs := make(texttemplate.FuncMap, len(values))
+	for varName, value := range values {
+		if !variableNamePattern.MatchString(varName) {
+			return "", fmt.Errorf("invalid template variable name %q: use letters, digits and underscores", varName)
+		}
+		funcs[varName] = func() string { return value }
+	}
+
+	tmpl, err := texttemplate.New(name).Funcs(funcs).Option("missingkey=error").Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, values); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	return out.String(), nil
+}
+
+// SubstituteVariablesInFiles renders each of filenames, relative to projectDir, in place
+func SubstituteVariablesInFiles(projectDir string, filenames []string, values map[string]string) error {
+	for _, filename := range filenames {
+		filePath := filepath.Join(projectDir, filename)
+		info, err := os.Stat(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", filename, err)
+		}
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", filename, err)
+		}
+
+		rendered, err := SubstituteVariables(filename, string(content), values)
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(filePath, []byte(rendered), info.Mode().Perm()); err != nil {
+			return fmt.Errorf("failed to update %s: %w", filename, err)
+		}
+	}
+	return nil
+}
+
+// UnknownVariables returns the names in values that are neither declared nor PROJECT_NAME, sorted
+func UnknownVariables(declared []TemplateVariable, values map[string]string) []string {
+	known := map[string]bool{ProjectNameVariable: true}
+	for _, v := range declared {
+		known[v.Name] = true
+	}
+
+	var unknown []string
+	for name := range values {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
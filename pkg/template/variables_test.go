@@ -0,0 +1,77 @@
+package template_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-cli/pkg/template"
+)
+
+func TestParseVariableAssignments(t *testing.T) {
+	values, err := template.ParseVariableAssignments([]string{"AUTHOR=Jane Doe", "EMPTY=", "URL=https://example.com/?a=b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]string{"AUTHOR": "Jane Doe", "EMPTY": "", "URL": "https://example.com/?a=b"}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("expected %v, got %v", want, values)
+	}
+
+	for _, invalid := range []string{"AUTHOR", "=value"} {
+		if _, err := template.ParseVariableAssignments([]string{invalid}); err == nil {
+			t.Errorf("expected error for %q", invalid)
+		}
+	}
+}
+
+func TestSubstituteVariables(t *testing.T) {
+	values := map[string]string{"PROJECT_NAME": "my-app", "AUTHOR": "Jane Doe"}
+
+	got, err := template.SubstituteVariables("README.md", "# {{PROJECT_NAME}}\nBy {{.AUTHOR}}\n", values)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "# my-app\nBy Jane Doe\n"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	if _, err := template.SubstituteVariables("README.md", "{{LICENSE}}", values); err == nil {
+		t.Error("expected error for an undefined variable")
+	}
+	if _, err := template.SubstituteVariables("README.md", "{{.LICENSE}}", values); err == nil {
+		t.Error("expected error for an undefined field")
+	}
+	if _, err := template.SubstituteVariables("README.md", "", map[string]string{"BAD-NAME": "x"}); err == nil {
+		t.Error("expected error for an invalid variable name")
+	}
+}
+
+func TestSubstituteVariablesInFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "package.json")
+	if err := os.WriteFile(path, []byte(`{"name": "{{PROJECT_NAME}}", "license": "{{LICENSE}}"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	err := template.SubstituteVariablesInFiles(dir, []string{"package.json"}, map[string]string{"PROJECT_NAME": "my-app", "LICENSE": "MIT"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"name": "my-app", "license": "MIT"}`; string(content) != want {
+		t.Errorf("expected %q, got %q", want, content)
+	}
+}
+
+func TestUnknownVariables(t *testing.T) {
+	declared := []template.TemplateVariable{{Name: "AUTHOR"}}
+	got := template.UnknownVariables(declared, map[string]string{"AUTHOR": "", "PROJECT_NAME": "", "LICNSE": "", "ZED": ""})
+	if want := []string{"LICNSE", "ZED"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
@@ -16,4 +16,6 @@ for i in $(ls release); do
         fileName="eigenx-cli-${i}-${VERSION}.tar.gz"
         tar -czvf "./release/${fileName}" -C "./release/${i}/" eigenx
     fi
+    # Published next to the archive so eigenx upgrade can verify the download
+    (cd ./release && sha256sum "${fileName}" > "${fileName}.sha256")
 done